@@ -4,16 +4,20 @@ import (
 	"context"
 	"log"
 	"ms1-documents/internal/config"
+	"ms1-documents/internal/exchange"
 	"ms1-documents/internal/handler"
 	"ms1-documents/internal/messaging"
 	"ms1-documents/internal/middleware"
 	"ms1-documents/internal/repository"
 	"ms1-documents/internal/service"
+	"ms1-documents/internal/tax"
 	"ms1-documents/internal/utils"
 	"ms1-documents/internal/validator"
+	"ms1-documents/pkg/money"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +38,33 @@ import (
 //
 // @tag.name            documents
 // @tag.description     Operaciones relacionadas con documentos fiscales
+//
+// @tag.name            series
+// @tag.description     Numeración correlativa por emisor y serie
+//
+// @tag.name            exchange-rates
+// @tag.description     Tipos de cambio SUNAT usados en documentos en moneda extranjera
+//
+// @tag.name            void-summaries
+// @tag.description     Comunicaciones de baja (RA) con los documentos anulados
+//
+// @tag.name            daily-summaries
+// @tag.description     Resúmenes diarios (RC) de boletas y sus notas
+//
+// @tag.name            issuers
+// @tag.description     Registro de empresas emisoras y su configuración de emisión
+//
+// @tag.name            customers
+// @tag.description     Libreta de clientes (receptores) de cada emisor
+//
+// @tag.name            products
+// @tag.description     Catálogo de productos y servicios de cada emisor
+//
+// @tag.name            business-rules
+// @tag.description     Reglas de negocio propias de cada emisor que se aplican a sus documentos
+//
+// @tag.name            catalogs
+// @tag.description     Catálogos de SUNAT con los que se validan los documentos
 func main() {
 	if err := config.InitLogger(); err != nil {
 		log.Fatal("Error inicializando logger:", err)
@@ -57,11 +88,71 @@ func main() {
 
 	repositorioDocumentos := repository.NewDocumentRepository(baseDatos)
 	publicador := messaging.NewMessagePublisher(mensajeria)
-	validadorDocumentos := validator.NewDocumentValidator()
-
-	servicioDocumentos := service.NewDocumentService(repositorioDocumentos, publicador, validadorDocumentos, configuracion.RabbitMQURI)
+	modoRedondeo, err := money.ParseModoRedondeo(configuracion.RoundingMode)
+	if err != nil {
+		config.Logger.Fatal("Modo de redondeo invalido", zap.Error(err))
+	}
+	modoAritmetico, err := validator.ParseModoAritmetico(configuracion.ArithmeticMode)
+	if err != nil {
+		config.Logger.Fatal("Modo de validacion aritmetica invalido", zap.Error(err))
+	}
+	tasasIGV := tax.TablaPorDefecto()
+	if configuracion.IgvRatesFile != "" {
+		tasasIGV, err = tax.CargarTablaTasasIGV(configuracion.IgvRatesFile)
+		if err != nil {
+			config.Logger.Fatal("Tabla de tasas IGV invalida", zap.Error(err))
+		}
+	}
+	// EXCHANGE_RATES_FILE solo siembra la colección; los tipos de cambio se resuelven siempre desde MongoDB
+	tiposCambio := repository.NewExchangeRateRepository(baseDatos)
+	if configuracion.ExchangeRatesFile != "" {
+		registros, err := exchange.LeerArchivoCSV(configuracion.ExchangeRatesFile)
+		if err != nil {
+			config.Logger.Fatal("Tabla de tipos de cambio invalida", zap.Error(err))
+		}
+		contexto, cancel := utils.CrearContextoConTimeoutDB(context.Background())
+		err = tiposCambio.Registrar(contexto, registros)
+		cancel()
+		if err != nil {
+			config.Logger.Fatal("No se pudieron registrar los tipos de cambio", zap.Error(err))
+		}
+	}
+	validadorDocumentos := validator.NewDocumentValidator(
+		validator.ConModoRedondeo(modoRedondeo),
+		validator.ConModoAritmetico(modoAritmetico),
+		validator.ConProveedorTasasIGV(tasasIGV),
+		validator.ConProveedorTipoCambio(tiposCambio),
+	)
+
+	permitirCorrelativoCliente, err := strconv.ParseBool(configuracion.AllowClientCorrelative)
+	if err != nil {
+		config.Logger.Fatal("ALLOW_CLIENT_CORRELATIVE invalido", zap.Error(err))
+	}
+	repositorioSeries := repository.NewSeriesRepository(baseDatos)
+	repositorioEmisores := repository.NewIssuerRepository(baseDatos)
+	repositorioClientes := repository.NewCustomerRepository(baseDatos)
+	repositorioProductos := repository.NewProductRepository(baseDatos)
+	repositorioReglas := repository.NewBusinessRuleRepository(baseDatos)
+
+	servicioDocumentos := service.NewDocumentService(repositorioDocumentos, publicador, validadorDocumentos, configuracion.RabbitMQURI,
+		service.ConNumeracion(repositorioSeries, permitirCorrelativoCliente),
+		service.ConComunicacionesBaja(repository.NewVoidSummaryRepository(baseDatos)),
+		service.ConEmisores(repositorioEmisores),
+		service.ConClientes(repositorioClientes),
+		service.ConProductos(repositorioProductos),
+		service.ConReglasNegocio(repositorioReglas),
+	)
 
 	manejadorDocumentos := handler.NewDocumentHandler(servicioDocumentos)
+	manejadorTiposCambio := handler.NewExchangeRateHandler(tiposCambio)
+	manejadorCatalogos := handler.NewCatalogHandler()
+	manejadorResumenes := handler.NewDailySummaryHandler(
+		service.NewDailySummaryService(repositorioDocumentos, repository.NewDailySummaryRepository(baseDatos)),
+	)
+	manejadorEmisores := handler.NewIssuerHandler(service.NewIssuerService(repositorioEmisores, validadorDocumentos))
+	manejadorClientes := handler.NewCustomerHandler(service.NewCustomerService(repositorioClientes))
+	manejadorProductos := handler.NewProductHandler(service.NewProductService(repositorioProductos, validadorDocumentos))
+	manejadorReglas := handler.NewBusinessRuleHandler(service.NewBusinessRuleService(repositorioReglas, validadorDocumentos))
 
 	gin.SetMode(gin.ReleaseMode)
 	enrutador := gin.New()
@@ -71,10 +162,41 @@ func main() {
 
 	enrutador.POST("/documents", manejadorDocumentos.CrearDocumento)
 	enrutador.GET("/documents", manejadorDocumentos.ObtenerDocumentos)
+	enrutador.GET("/documents/overdue", manejadorDocumentos.ListarDocumentosVencidos)
 	enrutador.GET("/documents/:id", manejadorDocumentos.ObtenerDocumento)
+	enrutador.GET("/documents/:id/ubl", manejadorDocumentos.ObtenerDocumentoUBL)
+	enrutador.GET("/documents/:id/pdf", manejadorDocumentos.ObtenerDocumentoPDF)
+	enrutador.GET("/documents/:id/html", manejadorDocumentos.ObtenerDocumentoHTML)
 	enrutador.PUT("/documents/:id", manejadorDocumentos.ActualizarDocumento)
 	enrutador.DELETE("/documents/:id", manejadorDocumentos.EliminarDocumento)
+	enrutador.POST("/documents/:id/void", manejadorDocumentos.AnularDocumento)
 	enrutador.POST("/documents/verify", manejadorDocumentos.VerificarDocumento)
+	enrutador.POST("/documents/validate", manejadorDocumentos.ValidarDocumento)
+	enrutador.GET("/series", manejadorDocumentos.ListarSeries)
+	enrutador.GET("/void-summaries", manejadorDocumentos.ListarComunicacionesBaja)
+	enrutador.POST("/daily-summaries", manejadorResumenes.GenerarResumenes)
+	enrutador.GET("/daily-summaries", manejadorResumenes.ListarResumenes)
+	enrutador.GET("/daily-summaries/:id", manejadorResumenes.ObtenerResumen)
+	enrutador.POST("/issuers", manejadorEmisores.CrearEmisor)
+	enrutador.GET("/issuers", manejadorEmisores.ObtenerEmisores)
+	enrutador.GET("/issuers/:ruc", manejadorEmisores.ObtenerEmisor)
+	enrutador.PUT("/issuers/:ruc", manejadorEmisores.ActualizarEmisor)
+	enrutador.DELETE("/issuers/:ruc", manejadorEmisores.EliminarEmisor)
+	enrutador.POST("/issuers/:ruc/products", manejadorProductos.CrearProducto)
+	enrutador.GET("/issuers/:ruc/products", manejadorProductos.ObtenerProductos)
+	enrutador.GET("/issuers/:ruc/products/:codigo", manejadorProductos.ObtenerProducto)
+	enrutador.PUT("/issuers/:ruc/products/:codigo", manejadorProductos.ActualizarProducto)
+	enrutador.DELETE("/issuers/:ruc/products/:codigo", manejadorProductos.EliminarProducto)
+	enrutador.POST("/issuers/:ruc/rules", manejadorReglas.CrearRegla)
+	enrutador.GET("/issuers/:ruc/rules", manejadorReglas.ObtenerReglas)
+	enrutador.GET("/issuers/:ruc/rules/:nombre", manejadorReglas.ObtenerRegla)
+	enrutador.PUT("/issuers/:ruc/rules/:nombre", manejadorReglas.ActualizarRegla)
+	enrutador.DELETE("/issuers/:ruc/rules/:nombre", manejadorReglas.EliminarRegla)
+	enrutador.GET("/customers", manejadorClientes.BuscarClientes)
+	enrutador.GET("/catalogs", manejadorCatalogos.ListarCatalogos)
+	enrutador.GET("/catalogs/:id", manejadorCatalogos.ObtenerCatalogo)
+	enrutador.POST("/admin/exchange-rates", manejadorTiposCambio.RegistrarTiposCambio)
+	enrutador.GET("/admin/exchange-rates", manejadorTiposCambio.ListarTiposCambio)
 
 	enrutador.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
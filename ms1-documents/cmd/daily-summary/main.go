@@ -0,0 +1,53 @@
+// Command daily-summary genera los resúmenes diarios (RC) de boletas para una fecha de emisión y los
+// imprime en JSON. Está pensado para ejecutarse cada mañana desde cron:
+//
+//	daily-summary                       # boletas de ayer de todos los emisores
+//	daily-summary -fecha 2026-02-12 -ruc 20123456786
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/repository"
+	"ms1-documents/internal/service"
+	"ms1-documents/internal/utils"
+	"os"
+)
+
+func main() {
+	fecha := flag.String("fecha", service.FechaResumenPorDefecto(), "fecha de emisión a informar (AAAA-MM-DD, hora de Perú)")
+	rucEmisor := flag.String("ruc", "", "RUC del emisor; vacío procesa a todos")
+	flag.Parse()
+
+	configuracion := config.Load()
+
+	baseDatos, err := config.NewDatabase(configuracion.MongoURI, configuracion.MongoDB, configuracion.MongoCollection)
+	if err != nil {
+		log.Fatal("Error conectando a MongoDB: ", err)
+	}
+	defer baseDatos.Disconnect()
+
+	servicioResumenes := service.NewDailySummaryService(
+		repository.NewDocumentRepository(baseDatos),
+		repository.NewDailySummaryRepository(baseDatos),
+	)
+
+	contexto, cancelar := utils.CrearContextoConTimeoutDB(context.Background())
+	defer cancelar()
+
+	resumenes, err := servicioResumenes.GenerarResumenes(contexto, *fecha, *rucEmisor)
+	if err != nil {
+		log.Fatal("Error generando resúmenes diarios: ", err)
+	}
+
+	log.Printf("%d resúmenes diarios generados para %s", len(resumenes), *fecha)
+
+	codificador := json.NewEncoder(os.Stdout)
+	codificador.SetIndent("", "  ")
+	if err := codificador.Encode(resumenes); err != nil {
+		log.Fatal("Error escribiendo resúmenes: ", err)
+	}
+}
@@ -9,18 +9,15 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {
-            "name": "API Support",
-            "email": "support@example.com"
-        },
+        "contact": {},
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/documents": {
+        "/admin/exchange-rates": {
             "get": {
-                "description": "Obtiene la lista completa de documentos fiscales",
+                "description": "Obtiene los tipos de cambio registrados ordenados por moneda y fecha",
                 "consumes": [
                     "application/json"
                 ],
@@ -28,16 +25,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "exchange-rates"
+                ],
+                "summary": "Listar tipos de cambio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Moneda (USD o EUR)",
+                        "name": "moneda",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Listar todos los documentos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Document"
+                                "$ref": "#/definitions/exchange.TipoCambioDiario"
                             }
                         }
                     },
@@ -50,33 +55,38 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Crea un nuevo documento fiscal con sus items y validación",
+                "description": "Agrega o reemplaza tipos de cambio venta a soles publicados por SUNAT. Acepta una lista JSON\no un CSV (Content-Type text/csv) con cabecera fecha,moneda,tipoCambio. Si alguna fila es inválida no se registra ninguna.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "exchange-rates"
                 ],
-                "summary": "Crear nuevo documento",
+                "summary": "Registrar tipos de cambio",
                 "parameters": [
                     {
-                        "description": "Datos del documento",
-                        "name": "document",
+                        "description": "Tipos de cambio",
+                        "name": "tiposCambio",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Document"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/exchange.TipoCambioDiario"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Document"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -94,9 +104,167 @@ const docTemplate = `{
                 }
             }
         },
-        "/documents/verify": {
+        "/catalogs": {
+            "get": {
+                "description": "Devuelve los catálogos de SUNAT disponibles con su versión y cantidad de códigos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalogs"
+                ],
+                "summary": "Listar catálogos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/catalog.Resumen"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/catalogs/{id}": {
+            "get": {
+                "description": "Devuelve los códigos de un catálogo de SUNAT: 01 tipos de documento, 03 unidades de medida,\n06 tipos de documento de identidad, 07 afectación del IGV, 09 y 10 motivos de nota de crédito\ny débito, 51 tipos de operación y 54 bienes y servicios sujetos a detracción",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalogs"
+                ],
+                "summary": "Obtener catálogo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Número del catálogo (01, 03, ...)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/catalog.Catalogo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers": {
+            "get": {
+                "description": "Autocompletado de receptores: devuelve hasta 20 clientes del emisor cuyo nombre (sin distinguir mayúsculas)\no número de documento empieza con q. La libreta se llena al crear documentos con guardarCliente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Buscar clientes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "rucEmisor",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prefijo del nombre o del número de documento",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Cliente"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/daily-summaries": {
+            "get": {
+                "description": "Obtiene los resúmenes diarios generados, opcionalmente filtrados por emisor y fecha de emisión informada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "daily-summaries"
+                ],
+                "summary": "Listar resúmenes diarios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha de emisión informada (AAAA-MM-DD)",
+                        "name": "fecha",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ResumenDiario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Verifica la validez de la firma digital de un documento",
+                "description": "Genera un resumen diario (RC) por emisor con las boletas y las notas que las modifican emitidas en la fecha indicada\n(por defecto ayer, hora de Perú) que son nuevas, cambiaron o se anularon desde el último resumen de esa fecha.\nLos emisores sin nada pendiente no generan resumen.",
                 "consumes": [
                     "application/json"
                 ],
@@ -104,17 +272,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "daily-summaries"
                 ],
-                "summary": "Verificar firma de documento",
+                "summary": "Generar resúmenes diarios",
                 "parameters": [
                     {
-                        "description": "Documento y firma",
+                        "description": "Fecha y emisor",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.VerifyDocumentRequest"
+                            "$ref": "#/definitions/handler.GenerarResumenDiarioRequest"
                         }
                     }
                 ],
@@ -122,7 +289,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.VerifyDocumentResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ResumenDiario"
+                            }
                         }
                     },
                     "400": {
@@ -140,9 +310,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/documents/{id}": {
+        "/daily-summaries/{id}": {
             "get": {
-                "description": "Obtiene un documento específico mediante su ID",
+                "description": "Obtiene un resumen diario por su número. Como los números se repiten entre emisores, rucEmisor es obligatorio si hay más de uno",
                 "consumes": [
                     "application/json"
                 ],
@@ -150,23 +320,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "documents"
+                    "daily-summaries"
                 ],
-                "summary": "Obtener documento por ID",
+                "summary": "Obtener resumen diario",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del documento",
+                        "description": "Número del resumen (RC-AAAAMMDD-n)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "rucEmisor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Document"
+                            "$ref": "#/definitions/domain.ResumenDiario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
                         }
                     },
                     "404": {
@@ -182,9 +364,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Actualiza los datos de un documento existente",
+            }
+        },
+        "/documents": {
+            "get": {
+                "description": "Obtiene la lista completa de documentos fiscales",
                 "consumes": [
                     "application/json"
                 ],
@@ -194,17 +378,41 @@ const docTemplate = `{
                 "tags": [
                     "documents"
                 ],
-                "summary": "Actualizar documento",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID del documento",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "Listar todos los documentos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Document"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Crea un nuevo documento fiscal con sus items y validación. Acepta JSON o un Invoice, CreditNote o DebitNote de UBL 2.1 con Content-Type application/xml",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Crear nuevo documento",
+                "parameters": [
                     {
-                        "description": "Datos actualizados",
+                        "description": "Datos del documento",
                         "name": "document",
                         "in": "body",
                         "required": true,
@@ -214,8 +422,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/domain.Document"
                         }
@@ -226,12 +434,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/errors.AppError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -239,9 +441,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Elimina permanentemente un documento del sistema",
+            }
+        },
+        "/documents/overdue": {
+            "get": {
+                "description": "Obtiene los documentos al crédito emitidos y no anulados con cuotas cuya fecha de vencimiento es anterior a la fecha indicada (hoy por defecto)",
                 "consumes": [
                     "application/json"
                 ],
@@ -251,28 +455,33 @@ const docTemplate = `{
                 "tags": [
                     "documents"
                 ],
-                "summary": "Eliminar documento",
+                "summary": "Listar documentos con cuotas vencidas",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID del documento",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "RUC del emisor",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha de corte (AAAA-MM-DD)",
+                        "name": "fecha",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DocumentoVencido"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/errors.AppError"
                         }
@@ -285,86 +494,2343 @@ const docTemplate = `{
                     }
                 }
             }
-        }
+        },
+        "/documents/validate": {
+            "post": {
+                "description": "Aplica las verificaciones de POST /documents (emisor, catálogo de productos, formato, aritmética, reglas de\nnegocio del emisor, documento de referencia e idDocumento repetido) sin guardar, numerar ni publicar el documento.\nResponde 200 con el resultado de cada verificación aunque el documento no sea válido. Acepta JSON o UBL 2.1\ncomo POST /documents; en el XML cada mensaje empieza con la ruta del elemento",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Validar documento sin crearlo",
+                "parameters": [
+                    {
+                        "description": "Documento a validar",
+                        "name": "document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Document"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReporteValidacion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/verify": {
+            "post": {
+                "description": "Verifica la validez de la firma digital de un documento",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Verificar firma de documento",
+                "parameters": [
+                    {
+                        "description": "Documento y firma",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VerifyDocumentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.VerifyDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}": {
+            "get": {
+                "description": "Obtiene un documento específico mediante su ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Obtener documento por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor; obligatorio si varios emisores usan el mismo ID",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Document"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Actualiza un borrador que aún no se publicó. Los documentos emitidos se corrigen con una nota de crédito o débito",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Actualizar documento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos actualizados",
+                        "name": "document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Document"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina un borrador que nunca se publicó y libera su correlativo. Los documentos emitidos se anulan con POST /documents/{id}/void",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Eliminar borrador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor; obligatorio si varios emisores usan el mismo ID",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/html": {
+            "get": {
+                "description": "Devuelve el comprobante como página HTML con el código QR de SUNAT en SVG",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Representación impresa en HTML",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor; obligatorio si varios emisores usan el mismo ID",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML de la representación impresa",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/pdf": {
+            "get": {
+                "description": "Devuelve el comprobante en PDF con emisor, receptor, items, totales, monto en letras y el código QR de SUNAT",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Representación impresa en PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor; obligatorio si varios emisores usan el mismo ID",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF de la representación impresa",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/ubl": {
+            "get": {
+                "description": "Devuelve el documento como Invoice, CreditNote o DebitNote de UBL 2.1 con la firma almacenada",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Exportar documento en UBL 2.1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor; obligatorio si varios emisores usan el mismo ID",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "XML UBL 2.1",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/void": {
+            "post": {
+                "description": "Da de baja un documento emitido dentro de los 7 días siguientes a su emisión. Las facturas y sus notas se agrupan en la comunicación de baja (RA) del día; las boletas se informan en el resumen diario",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Anular documento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del documento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor; obligatorio si varios emisores usan el mismo ID",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Motivo de la baja",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnularDocumentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AnularDocumentoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/issuers": {
+            "get": {
+                "description": "Devuelve todos los emisores registrados ordenados por RUC",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issuers"
+                ],
+                "summary": "Listar emisores",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Emisor"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registra una empresa emisora con sus datos SUNAT y su configuración de emisión (series, moneda, régimen de IGV y logo)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issuers"
+                ],
+                "summary": "Registrar emisor",
+                "parameters": [
+                    {
+                        "description": "Datos del emisor",
+                        "name": "issuer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Emisor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Emisor"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/issuers/{ruc}": {
+            "get": {
+                "description": "Devuelve un emisor por su RUC",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issuers"
+                ],
+                "summary": "Obtener emisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Emisor"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Reemplaza los datos y la configuración de un emisor. Los documentos ya emitidos conservan los datos vigentes al crearlos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issuers"
+                ],
+                "summary": "Actualizar emisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos actualizados",
+                        "name": "issuer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Emisor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Emisor"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina un emisor del registro. Para suspender la emisión sin perder sus datos, actualice su estado a INACTIVO",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issuers"
+                ],
+                "summary": "Eliminar emisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/issuers/{ruc}/products": {
+            "get": {
+                "description": "Devuelve el catálogo de productos del emisor ordenado por código",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Listar productos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Producto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Agrega un producto o servicio al catálogo del emisor con su código interno, código SUNAT (UNSPSC),\nunidad de medida del catálogo 03, precio por defecto y tipo de afectación del IGV",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Registrar producto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del producto",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Producto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Producto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/issuers/{ruc}/products/{codigo}": {
+            "get": {
+                "description": "Devuelve un producto del catálogo del emisor por su código interno",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Obtener producto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código interno del producto",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Producto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Reemplaza los datos de un producto del catálogo. Los documentos ya creados conservan los datos con que se emitieron",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Actualizar producto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código interno del producto",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos actualizados",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Producto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Producto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Quita un producto del catálogo del emisor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Eliminar producto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código interno del producto",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/issuers/{ruc}/rules": {
+            "get": {
+                "description": "Devuelve las reglas del emisor ordenadas por nombre, activas e inactivas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-rules"
+                ],
+                "summary": "Listar reglas de negocio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ReglaNegocio"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Agrega una regla propia del emisor que se aplica a sus documentos después de las validaciones de SUNAT.\nLa expresión usa los campos JSON del documento o del item según el ámbito, por ejemplo\n` + "`" + `montoTotal \u003c= 50000` + "`" + `, ` + "`" + `ordenCompra != \"\"` + "`" + ` o ` + "`" + `!(descripcion contiene [\"tabaco\", \"licor\"])` + "`" + `,\ny la condición opcional limita a qué documentos se aplica, por ejemplo ` + "`" + `rucReceptor en [\"20100070970\"]` + "`" + `",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-rules"
+                ],
+                "summary": "Registrar regla de negocio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la regla",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReglaNegocio"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReglaNegocio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/issuers/{ruc}/rules/{nombre}": {
+            "get": {
+                "description": "Devuelve una regla del emisor por su nombre",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-rules"
+                ],
+                "summary": "Obtener regla de negocio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre de la regla",
+                        "name": "nombre",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReglaNegocio"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Reemplaza la regla. El cambio se aplica a los documentos que se creen o actualicen desde ese momento;\npara dejar de aplicarla sin borrarla se usa el estado INACTIVA",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-rules"
+                ],
+                "summary": "Actualizar regla de negocio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre de la regla",
+                        "name": "nombre",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos actualizados",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReglaNegocio"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReglaNegocio"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Quita una regla del emisor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "business-rules"
+                ],
+                "summary": "Eliminar regla de negocio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "ruc",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre de la regla",
+                        "name": "nombre",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/series": {
+            "get": {
+                "description": "Obtiene las series de numeración con el último correlativo asignado y los números liberados que quedaron como huecos, opcionalmente filtradas por emisor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Listar series y correlativos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SerieCorrelativo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/void-summaries": {
+            "get": {
+                "description": "Obtiene las comunicaciones de baja (RA) generadas, opcionalmente filtradas por emisor y fecha de generación",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "void-summaries"
+                ],
+                "summary": "Listar comunicaciones de baja",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUC del emisor",
+                        "name": "rucEmisor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha de generación (AAAA-MM-DD)",
+                        "name": "fecha",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ComunicacionBaja"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.AppError"
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
+        "catalog.Catalogo": {
+            "type": "object",
+            "properties": {
+                "entradas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/catalog.Entrada"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "03"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "Código de tipo de unidad de medida comercial"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                }
+            }
+        },
+        "catalog.Entrada": {
+            "type": "object",
+            "properties": {
+                "atributos": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "codigo": {
+                    "type": "string",
+                    "example": "NIU"
+                },
+                "descripcion": {
+                    "type": "string",
+                    "example": "Unidad (bienes)"
+                }
+            }
+        },
+        "catalog.Resumen": {
+            "type": "object",
+            "properties": {
+                "entradas": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "id": {
+                    "type": "string",
+                    "example": "03"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "Código de tipo de unidad de medida comercial"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                }
+            }
+        },
+        "domain.Anulacion": {
+            "type": "object",
+            "properties": {
+                "fechaAnulacion": {
+                    "type": "string",
+                    "example": "2026-02-13T09:00:00-05:00"
+                },
+                "motivo": {
+                    "type": "string",
+                    "example": "Error en la descripción"
+                }
+            }
+        },
+        "domain.CargoDescuento": {
+            "type": "object",
+            "properties": {
+                "afectaBase": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "codigo": {
+                    "type": "string",
+                    "example": "00"
+                },
+                "esCargo": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "factor": {
+                    "type": "number",
+                    "example": 0.1
+                },
+                "monto": {
+                    "type": "number",
+                    "example": 10
+                },
+                "montoBase": {
+                    "type": "number",
+                    "example": 100
+                }
+            }
+        },
+        "domain.Cliente": {
+            "type": "object",
+            "properties": {
+                "direccion": {
+                    "type": "string",
+                    "example": "Jr. Lampa 456, Lima"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "compras@cliente.pe"
+                },
+                "fechaActualizacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "CLIENTE DEMO S.A."
+                },
+                "numeroDocumento": {
+                    "type": "string",
+                    "example": "20987654326"
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                },
+                "tipoDocumento": {
+                    "type": "string",
+                    "example": "6"
+                }
+            }
+        },
+        "domain.ComunicacionBaja": {
+            "type": "object",
+            "properties": {
+                "fechaGeneracion": {
+                    "type": "string",
+                    "example": "2026-02-13"
+                },
+                "fechaReferencia": {
+                    "type": "string",
+                    "example": "2026-02-12"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "RA-20260213-1"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ItemBaja"
+                    }
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                }
+            }
+        },
+        "domain.ConfiguracionEmisor": {
+            "type": "object",
+            "properties": {
+                "logo": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/logo.png"
+                },
+                "monedaPorDefecto": {
+                    "type": "string",
+                    "example": "PEN"
+                },
+                "regimenIgv": {
+                    "type": "string",
+                    "example": "GENERAL"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "F001",
+                        "B001"
+                    ]
+                }
+            }
+        },
+        "domain.Cuota": {
+            "type": "object",
+            "properties": {
+                "fechaVencimiento": {
+                    "type": "string",
+                    "example": "2026-03-14"
+                },
+                "monto": {
+                    "type": "number",
+                    "example": 590
+                }
+            }
+        },
+        "domain.DatosEmisor": {
+            "type": "object",
+            "properties": {
+                "codigoEstablecimiento": {
+                    "type": "string",
+                    "example": "0000"
+                },
+                "direccion": {
+                    "type": "string",
+                    "example": "Av. Arequipa 123, Lima"
+                },
+                "logo": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/logo.png"
+                },
+                "nombreComercial": {
+                    "type": "string",
+                    "example": "Demo"
+                },
+                "razonSocial": {
+                    "type": "string",
+                    "example": "EMPRESA DEMO S.A.C."
+                },
+                "ubigeo": {
+                    "type": "string",
+                    "example": "150101"
+                }
+            }
+        },
+        "domain.DatosReceptor": {
+            "type": "object",
+            "properties": {
+                "direccion": {
+                    "type": "string",
+                    "example": "Jr. Lampa 456, Lima"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "compras@cliente.pe"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "CLIENTE DEMO S.A."
+                }
+            }
+        },
+        "domain.Detraccion": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "037"
+                },
+                "cuentaBancoNacion": {
+                    "type": "string",
+                    "example": "00-741-123456"
+                },
+                "monto": {
+                    "type": "number",
+                    "example": 142
+                },
+                "porcentaje": {
+                    "type": "number",
+                    "example": 0.12
+                }
+            }
+        },
         "domain.Document": {
             "type": "object",
             "properties": {
-                "fechaEmision": {
+                "anulacion": {
+                    "$ref": "#/definitions/domain.Anulacion"
+                },
+                "cargosDescuentos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CargoDescuento"
+                    }
+                },
+                "detraccion": {
+                    "$ref": "#/definitions/domain.Detraccion"
+                },
+                "emisor": {
+                    "description": "Emisor se copia del registro de emisores al crear el documento; lo que envíe el cliente se ignora",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DatosEmisor"
+                        }
+                    ]
+                },
+                "estado": {
+                    "type": "string",
+                    "example": "EMITIDO"
+                },
+                "fechaEmision": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00Z"
+                },
+                "formaPago": {
+                    "$ref": "#/definitions/domain.FormaPago"
+                },
+                "guardarCliente": {
+                    "description": "GuardarCliente registra o actualiza el receptor en la libreta de clientes del emisor al crear el documento",
+                    "type": "boolean",
+                    "example": true
+                },
+                "icbperTotal": {
+                    "type": "number",
+                    "example": 0
+                },
+                "idDocumento": {
+                    "type": "string",
+                    "example": "F001-00000001"
+                },
+                "igvTotal": {
+                    "type": "number",
+                    "example": 180
+                },
+                "iscTotal": {
+                    "type": "number",
+                    "example": 0
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Item"
+                    }
+                },
+                "moneda": {
+                    "type": "string",
+                    "example": "PEN"
+                },
+                "montoEnLetras": {
+                    "description": "MontoEnLetras es la leyenda 1000 de SUNAT; se calcula al responder y no se almacena",
+                    "type": "string",
+                    "example": "MIL CIENTO OCHENTA CON 00/100 SOLES"
+                },
+                "montoTotal": {
+                    "type": "number",
+                    "example": 1180
+                },
+                "montoTotalSinImpuestos": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "montoTotalSoles": {
+                    "description": "MontoTotalSoles es montoTotal convertido con tipoCambio; solo se informa en moneda extranjera",
+                    "type": "number",
+                    "example": 4380.16
+                },
+                "ordenCompra": {
+                    "description": "OrdenCompra es el número de la orden de compra del adquiriente (cac:OrderReference)",
+                    "type": "string",
+                    "example": "OC-2026-0042"
+                },
+                "percepcion": {
+                    "$ref": "#/definitions/domain.Percepcion"
+                },
+                "receptor": {
+                    "$ref": "#/definitions/domain.DatosReceptor"
+                },
+                "referencia": {
+                    "$ref": "#/definitions/domain.DocumentoReferencia"
+                },
+                "regimenIgv": {
+                    "type": "string",
+                    "example": "GENERAL"
+                },
+                "retencion": {
+                    "$ref": "#/definitions/domain.Retencion"
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                },
+                "rucReceptor": {
+                    "type": "string",
+                    "example": "20987654326"
+                },
+                "saldo": {
+                    "description": "Saldo se calcula al consultar un comprobante por ID a partir de sus notas y no se almacena",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Saldo"
+                        }
+                    ]
+                },
+                "serie": {
+                    "type": "string",
+                    "example": "F001"
+                },
+                "tasaIgv": {
+                    "type": "number",
+                    "example": 0.18
+                },
+                "tipoCambio": {
+                    "description": "TipoCambio es el tipo de cambio venta a soles; en moneda extranjera, si se omite, se toma el de SUNAT a la fecha de emisión",
+                    "type": "number",
+                    "example": 3.712
+                },
+                "tipoDocumento": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "tipoDocumentoReceptor": {
+                    "type": "string",
+                    "example": "6"
+                },
+                "totalCargos": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalDescuentos": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalExonerado": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalGratuito": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalGravado": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "totalInafecto": {
+                    "type": "number",
+                    "example": 0
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "validacion": {
+                    "$ref": "#/definitions/domain.Validacion"
+                }
+            }
+        },
+        "domain.DocumentoReferencia": {
+            "type": "object",
+            "properties": {
+                "codigoMotivo": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "idDocumento": {
+                    "type": "string",
+                    "example": "F001-00000001"
+                },
+                "sustento": {
+                    "type": "string",
+                    "example": "Anulación de la operación"
+                },
+                "tipoDocumento": {
+                    "type": "string",
+                    "example": "01"
+                }
+            }
+        },
+        "domain.DocumentoVencido": {
+            "type": "object",
+            "properties": {
+                "cuotasVencidas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Cuota"
+                    }
+                },
+                "diasVencido": {
+                    "description": "DiasVencido cuenta desde la cuota vencida más antigua",
+                    "type": "integer",
+                    "example": 12
+                },
+                "fechaEmision": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00Z"
+                },
+                "idDocumento": {
+                    "type": "string",
+                    "example": "F001-00000001"
+                },
+                "moneda": {
+                    "type": "string",
+                    "example": "PEN"
+                },
+                "montoPendiente": {
+                    "type": "number",
+                    "example": 1180
+                },
+                "montoVencido": {
+                    "type": "number",
+                    "example": 590
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                },
+                "rucReceptor": {
+                    "type": "string",
+                    "example": "20987654326"
+                }
+            }
+        },
+        "domain.Emisor": {
+            "type": "object",
+            "properties": {
+                "codigoEstablecimiento": {
+                    "description": "CodigoEstablecimiento es el código de anexo de 4 dígitos; 0000 si se omite",
+                    "type": "string",
+                    "example": "0000"
+                },
+                "configuracion": {
+                    "$ref": "#/definitions/domain.ConfiguracionEmisor"
+                },
+                "direccion": {
+                    "type": "string",
+                    "example": "Av. Arequipa 123, Lima"
+                },
+                "estado": {
+                    "type": "string",
+                    "example": "ACTIVO"
+                },
+                "fechaActualizacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "fechaCreacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "nombreComercial": {
+                    "type": "string",
+                    "example": "Demo"
+                },
+                "razonSocial": {
+                    "type": "string",
+                    "example": "EMPRESA DEMO S.A.C."
+                },
+                "ruc": {
+                    "type": "string",
+                    "example": "20123456786"
+                },
+                "ubigeo": {
+                    "description": "Ubigeo es el código INEI de 6 dígitos del domicilio fiscal",
+                    "type": "string",
+                    "example": "150101"
+                }
+            }
+        },
+        "domain.FormaPago": {
+            "type": "object",
+            "properties": {
+                "cuotas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Cuota"
+                    }
+                },
+                "montoPendiente": {
+                    "type": "number",
+                    "example": 1180
+                },
+                "tipo": {
+                    "type": "string",
+                    "example": "Credito"
+                }
+            }
+        },
+        "domain.Item": {
+            "type": "object",
+            "properties": {
+                "cantidad": {
+                    "description": "Cantidad admite decimales (2.5 KGM, 0.75 HUR)",
+                    "type": "number",
+                    "example": 5
+                },
+                "cargosDescuentos": {
+                    "description": "CargosDescuentos de item: precioTotal = precioUnitario × cantidad − descuentos + cargos que afectan la base",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CargoDescuento"
+                    }
+                },
+                "codigoProducto": {
+                    "description": "CodigoProducto referencia el catálogo de productos del emisor; los datos que el item omita se toman de ahí",
+                    "type": "string",
+                    "example": "P-001"
+                },
+                "codigoProductoSunat": {
+                    "description": "CodigoProductoSunat es el código UNSPSC de 8 dígitos",
+                    "type": "string",
+                    "example": "50161509"
+                },
+                "descripcion": {
+                    "type": "string",
+                    "example": "Producto A"
+                },
+                "icbperTotal": {
+                    "type": "number",
+                    "example": 0
+                },
+                "igvTotal": {
+                    "type": "number",
+                    "example": 90
+                },
+                "iscTotal": {
+                    "type": "number",
+                    "example": 0
+                },
+                "precioTotal": {
+                    "type": "number",
+                    "example": 500
+                },
+                "precioUnitario": {
+                    "type": "number",
+                    "example": 100
+                },
+                "tipoAfectacionIgv": {
+                    "description": "TipoAfectacionIgv usa el catálogo 07; si se omite se asume gravado oneroso (10)",
+                    "type": "string",
+                    "example": "10"
+                },
+                "unidadMedida": {
+                    "description": "UnidadMedida usa el catálogo 03; si se omite se asume unidad de bienes (NIU)",
+                    "type": "string",
+                    "example": "NIU"
+                }
+            }
+        },
+        "domain.ItemBaja": {
+            "type": "object",
+            "properties": {
+                "idDocumento": {
+                    "type": "string",
+                    "example": "F001-00000001"
+                },
+                "motivo": {
+                    "type": "string",
+                    "example": "Error en la descripción"
+                },
+                "tipoDocumento": {
+                    "type": "string",
+                    "example": "01"
+                }
+            }
+        },
+        "domain.ItemResumen": {
+            "type": "object",
+            "properties": {
+                "documentoReferencia": {
+                    "type": "string",
+                    "example": "B001-00000001"
+                },
+                "estado": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "icbperTotal": {
+                    "type": "number",
+                    "example": 0
+                },
+                "idDocumento": {
+                    "type": "string",
+                    "example": "B001-00000001"
+                },
+                "igvTotal": {
+                    "type": "number",
+                    "example": 18
+                },
+                "iscTotal": {
+                    "type": "number",
+                    "example": 0
+                },
+                "moneda": {
+                    "type": "string",
+                    "example": "PEN"
+                },
+                "montoTotal": {
+                    "type": "number",
+                    "example": 118
+                },
+                "numeroReceptor": {
+                    "type": "string",
+                    "example": "12345678"
+                },
+                "tipoCambio": {
+                    "type": "number",
+                    "example": 3.712
+                },
+                "tipoDocumento": {
+                    "type": "string",
+                    "example": "03"
+                },
+                "tipoDocumentoReceptor": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "totalCargos": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalExonerado": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalGratuito": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalGravado": {
+                    "type": "number",
+                    "example": 100
+                },
+                "totalInafecto": {
+                    "type": "number",
+                    "example": 0
+                }
+            }
+        },
+        "domain.Percepcion": {
+            "type": "object",
+            "properties": {
+                "codigo": {
                     "type": "string",
-                    "example": "2026-02-12T10:00:00Z"
+                    "example": "51"
                 },
-                "idDocumento": {
+                "monto": {
+                    "type": "number",
+                    "example": 23.6
+                },
+                "montoBase": {
+                    "type": "number",
+                    "example": 1180
+                },
+                "montoTotalCobrado": {
+                    "type": "number",
+                    "example": 1203.6
+                },
+                "porcentaje": {
+                    "type": "number",
+                    "example": 0.02
+                }
+            }
+        },
+        "domain.Producto": {
+            "type": "object",
+            "properties": {
+                "codigo": {
                     "type": "string",
-                    "example": "DOC-001"
+                    "example": "P-001"
                 },
-                "igvTotal": {
+                "codigoProductoSunat": {
+                    "description": "CodigoProductoSunat es el código UNSPSC de 8 dígitos",
+                    "type": "string",
+                    "example": "50221101"
+                },
+                "descripcion": {
+                    "type": "string",
+                    "example": "Arroz extra"
+                },
+                "fechaActualizacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "fechaCreacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "precioUnitario": {
+                    "description": "PrecioUnitario es el valor unitario sin impuestos que se usa si el item no trae precio",
                     "type": "number",
-                    "example": 180
+                    "example": 4.2
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                },
+                "tipoAfectacionIgv": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "unidadMedida": {
+                    "type": "string",
+                    "example": "KGM"
+                }
+            }
+        },
+        "domain.ReglaNegocio": {
+            "type": "object",
+            "properties": {
+                "ambito": {
+                    "type": "string",
+                    "example": "DOCUMENTO"
+                },
+                "campo": {
+                    "description": "Campo es el campo al que apuntan los errores; vacío apunta al documento o al item",
+                    "type": "string",
+                    "example": "ordenCompra"
+                },
+                "condicion": {
+                    "description": "Condicion limita la regla a los documentos o items en que es verdadera; vacía se aplica siempre",
+                    "type": "string",
+                    "example": "rucReceptor en [\"20987654326\"]"
+                },
+                "descripcion": {
+                    "type": "string",
+                    "example": "Los clientes corporativos exigen su orden de compra"
+                },
+                "estado": {
+                    "type": "string",
+                    "example": "ACTIVA"
+                },
+                "expresion": {
+                    "description": "Expresion es lo que el documento o item debe cumplir",
+                    "type": "string",
+                    "example": "ordenCompra != \"\""
+                },
+                "fechaActualizacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "fechaCreacion": {
+                    "type": "string",
+                    "example": "2026-02-12T10:00:00-05:00"
+                },
+                "mensaje": {
+                    "type": "string",
+                    "example": "El cliente exige el número de su orden de compra"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "orden-compra-obligatoria"
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                }
+            }
+        },
+        "domain.ReporteValidacion": {
+            "type": "object",
+            "properties": {
+                "documento": {
+                    "description": "Documento queda como se guardaría, con los datos que se completan desde el emisor, el catálogo y los cálculos",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Document"
+                        }
+                    ]
+                },
+                "errores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.FieldError"
+                    }
+                },
+                "valido": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "verificaciones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Verificacion"
+                    }
+                }
+            }
+        },
+        "domain.ResumenDiario": {
+            "type": "object",
+            "properties": {
+                "fechaGeneracion": {
+                    "type": "string",
+                    "example": "2026-02-13"
+                },
+                "fechaReferencia": {
+                    "type": "string",
+                    "example": "2026-02-12"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "RC-20260213-1"
                 },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Item"
+                        "$ref": "#/definitions/domain.ItemResumen"
                     }
                 },
-                "montoTotal": {
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                },
+                "totalesPorCategoria": {
+                    "description": "TotalesPorCategoria suma en soles los documentos informados que no están anulados; las notas de crédito restan",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.TotalesCategoria"
+                        }
+                    ]
+                },
+                "totalesPorEstado": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TotalEstado"
+                    }
+                }
+            }
+        },
+        "domain.Retencion": {
+            "type": "object",
+            "properties": {
+                "monto": {
+                    "type": "number",
+                    "example": 35.4
+                },
+                "montoBase": {
                     "type": "number",
                     "example": 1180
                 },
-                "montoTotalSinImpuestos": {
+                "porcentaje": {
                     "type": "number",
-                    "example": 1000
+                    "example": 0.03
+                }
+            }
+        },
+        "domain.Saldo": {
+            "type": "object",
+            "properties": {
+                "saldoNeto": {
+                    "type": "number",
+                    "example": 1062
                 },
-                "rucEmisor": {
-                    "type": "string",
-                    "example": "20123456789"
+                "totalNotasCredito": {
+                    "type": "number",
+                    "example": 118
                 },
-                "rucReceptor": {
+                "totalNotasDebito": {
+                    "type": "number",
+                    "example": 0
+                }
+            }
+        },
+        "domain.SerieCorrelativo": {
+            "type": "object",
+            "properties": {
+                "liberados": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rucEmisor": {
                     "type": "string",
-                    "example": "20987654321"
+                    "example": "20123456786"
                 },
-                "uuid": {
+                "serie": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "F001"
                 },
-                "validacion": {
-                    "$ref": "#/definitions/domain.Validacion"
+                "ultimoCorrelativo": {
+                    "type": "integer",
+                    "example": 125
                 }
             }
         },
-        "domain.Item": {
+        "domain.TotalEstado": {
             "type": "object",
             "properties": {
-                "cantidad": {
+                "descripcion": {
+                    "type": "string",
+                    "example": "Adicionar"
+                },
+                "documentos": {
                     "type": "integer",
-                    "example": 5
+                    "example": 1
                 },
-                "descripcion": {
+                "estado": {
                     "type": "string",
-                    "example": "Producto A"
+                    "example": "1"
+                },
+                "montoTotal": {
+                    "type": "number",
+                    "example": 118
+                }
+            }
+        },
+        "domain.TotalesCategoria": {
+            "type": "object",
+            "properties": {
+                "icbperTotal": {
+                    "type": "number",
+                    "example": 0
                 },
                 "igvTotal": {
                     "type": "number",
-                    "example": 90
+                    "example": 18
                 },
-                "precioTotal": {
+                "iscTotal": {
                     "type": "number",
-                    "example": 500
+                    "example": 0
                 },
-                "precioUnitario": {
+                "montoTotal": {
+                    "type": "number",
+                    "example": 118
+                },
+                "totalExonerado": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalGratuito": {
+                    "type": "number",
+                    "example": 0
+                },
+                "totalGravado": {
                     "type": "number",
                     "example": 100
+                },
+                "totalInafecto": {
+                    "type": "number",
+                    "example": 0
                 }
             }
         },
         "domain.Validacion": {
             "type": "object",
             "properties": {
+                "digestValue": {
+                    "type": "string",
+                    "example": "q1ZbYx3CqjbUuzZ0cHXCDRbTtu6TYsjBwiyxPM1gLdU="
+                },
                 "estado": {
                     "type": "string",
-                    "example": "VALIDO"
+                    "example": "Válido"
                 },
                 "fechaValidacion": {
                     "type": "string",
@@ -376,6 +2842,29 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.Verificacion": {
+            "type": "object",
+            "properties": {
+                "errores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.FieldError"
+                    }
+                },
+                "etapa": {
+                    "type": "string",
+                    "example": "ARITMETICA"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "aritmetica"
+                },
+                "resultado": {
+                    "type": "string",
+                    "example": "INCUMPLIDA"
+                }
+            }
+        },
         "errors.AppError": {
             "type": "object",
             "properties": {
@@ -383,6 +2872,13 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Bad Request"
                 },
+                "errors": {
+                    "description": "Errors detalla cada regla incumplida en los errores de validación del documento",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errors.FieldError"
+                    }
+                },
                 "message": {
                     "type": "string",
                     "example": "Error en la solicitud"
@@ -393,6 +2889,79 @@ const docTemplate = `{
                 }
             }
         },
+        "errors.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "NO_COINCIDE"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "precioTotal del item 3 no coincide con precioUnitario × cantidad. Esperado: 100.00, recibido: 90.00"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/items/3/precioTotal"
+                }
+            }
+        },
+        "exchange.TipoCambioDiario": {
+            "type": "object",
+            "properties": {
+                "fecha": {
+                    "type": "string",
+                    "example": "2026-02-12"
+                },
+                "moneda": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "tipoCambio": {
+                    "type": "number",
+                    "example": 3.712
+                }
+            }
+        },
+        "handler.AnularDocumentoRequest": {
+            "type": "object",
+            "required": [
+                "motivo"
+            ],
+            "properties": {
+                "motivo": {
+                    "type": "string",
+                    "example": "Error en el RUC del receptor"
+                }
+            }
+        },
+        "handler.AnularDocumentoResponse": {
+            "type": "object",
+            "properties": {
+                "comunicacionBaja": {
+                    "$ref": "#/definitions/domain.ComunicacionBaja"
+                },
+                "documento": {
+                    "$ref": "#/definitions/domain.Document"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GenerarResumenDiarioRequest": {
+            "type": "object",
+            "properties": {
+                "fecha": {
+                    "type": "string",
+                    "example": "2026-02-12"
+                },
+                "rucEmisor": {
+                    "type": "string",
+                    "example": "20123456786"
+                }
+            }
+        },
         "handler.VerifyDocumentRequest": {
             "type": "object",
             "required": [
@@ -424,6 +2993,42 @@ const docTemplate = `{
         {
             "description": "Operaciones relacionadas con documentos fiscales",
             "name": "documents"
+        },
+        {
+            "description": "Numeración correlativa por emisor y serie",
+            "name": "series"
+        },
+        {
+            "description": "Tipos de cambio SUNAT usados en documentos en moneda extranjera",
+            "name": "exchange-rates"
+        },
+        {
+            "description": "Comunicaciones de baja (RA) con los documentos anulados",
+            "name": "void-summaries"
+        },
+        {
+            "description": "Resúmenes diarios (RC) de boletas y sus notas",
+            "name": "daily-summaries"
+        },
+        {
+            "description": "Registro de empresas emisoras y su configuración de emisión",
+            "name": "issuers"
+        },
+        {
+            "description": "Libreta de clientes (receptores) de cada emisor",
+            "name": "customers"
+        },
+        {
+            "description": "Catálogo de productos y servicios de cada emisor",
+            "name": "products"
+        },
+        {
+            "description": "Reglas de negocio propias de cada emisor que se aplican a sus documentos",
+            "name": "business-rules"
+        },
+        {
+            "description": "Catálogos de SUNAT con los que se validan los documentos",
+            "name": "catalogs"
         }
     ]
 }`
@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Identificadores de los catálogos de SUNAT incluidos en el servicio
+const (
+	TiposDocumento            = "01"
+	UnidadesMedida            = "03"
+	TiposIdentidad            = "06"
+	AfectacionesIGV           = "07"
+	MotivosNotaCredito        = "09"
+	MotivosNotaDebito         = "10"
+	TiposOperacion            = "51"
+	BienesServiciosDetraccion = "54"
+)
+
+// Cada catálogo es un archivo datos/catalogo_<id>.json con la versión de los datos; al actualizar
+// un catálogo se cambia su versión para que los clientes renueven lo que tengan guardado
+//
+//go:embed datos/*.json
+var archivos embed.FS
+
+// Entrada es un código del catálogo. Atributos lleva los datos propios de cada catálogo, como la
+// categoría tributaria del 07 o el porcentaje del 54.
+type Entrada struct {
+	Codigo      string            `json:"codigo" example:"NIU"`
+	Descripcion string            `json:"descripcion" example:"Unidad (bienes)"`
+	Atributos   map[string]string `json:"atributos,omitempty"`
+}
+
+// Catalogo es un catálogo de SUNAT con los códigos que admite el sistema, en el orden oficial
+type Catalogo struct {
+	ID       string    `json:"id" example:"03"`
+	Nombre   string    `json:"nombre" example:"Código de tipo de unidad de medida comercial"`
+	Version  string    `json:"version" example:"2026-02-01"`
+	Entradas []Entrada `json:"entradas"`
+
+	indice map[string]int
+}
+
+// Resumen identifica un catálogo sin sus entradas
+type Resumen struct {
+	ID       string `json:"id" example:"03"`
+	Nombre   string `json:"nombre" example:"Código de tipo de unidad de medida comercial"`
+	Version  string `json:"version" example:"2026-02-01"`
+	Entradas int    `json:"entradas" example:"21"`
+}
+
+var catalogos = cargar()
+
+// cargar lee los catálogos incluidos en el binario; un archivo inválido es un error de compilación
+// de los datos, por lo que detiene el servicio al iniciar
+func cargar() map[string]*Catalogo {
+	nombres, err := archivos.ReadDir("datos")
+	if err != nil {
+		panic(err)
+	}
+
+	resultado := make(map[string]*Catalogo, len(nombres))
+	for _, archivo := range nombres {
+		contenido, err := archivos.ReadFile(path.Join("datos", archivo.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		catalogo, err := leer(contenido)
+		if err != nil {
+			panic(fmt.Sprintf("catálogo %s: %v", archivo.Name(), err))
+		}
+		if archivo.Name() != "catalogo_"+catalogo.ID+".json" {
+			panic(fmt.Sprintf("catálogo %s: el id %q no coincide con el nombre del archivo", archivo.Name(), catalogo.ID))
+		}
+		resultado[catalogo.ID] = catalogo
+	}
+	return resultado
+}
+
+func leer(contenido []byte) (*Catalogo, error) {
+	var catalogo Catalogo
+	if err := json.Unmarshal(contenido, &catalogo); err != nil {
+		return nil, err
+	}
+	if catalogo.ID == "" || catalogo.Nombre == "" || catalogo.Version == "" {
+		return nil, fmt.Errorf("id, nombre y version son obligatorios")
+	}
+	if len(catalogo.Entradas) == 0 {
+		return nil, fmt.Errorf("no tiene entradas")
+	}
+
+	catalogo.indice = make(map[string]int, len(catalogo.Entradas))
+	for posicion, entrada := range catalogo.Entradas {
+		if strings.TrimSpace(entrada.Codigo) == "" || strings.TrimSpace(entrada.Descripcion) == "" {
+			return nil, fmt.Errorf("entrada %d: codigo y descripcion son obligatorios", posicion)
+		}
+		if _, repetido := catalogo.indice[entrada.Codigo]; repetido {
+			return nil, fmt.Errorf("código %q repetido", entrada.Codigo)
+		}
+		catalogo.indice[entrada.Codigo] = posicion
+	}
+	return &catalogo, nil
+}
+
+// Obtener devuelve el catálogo con el identificador de SUNAT (01, 03, ...)
+func Obtener(id string) (*Catalogo, bool) {
+	catalogo, ok := catalogos[id]
+	return catalogo, ok
+}
+
+// Listar resume los catálogos disponibles ordenados por identificador
+func Listar() []Resumen {
+	resumenes := make([]Resumen, 0, len(catalogos))
+	for _, catalogo := range catalogos {
+		resumenes = append(resumenes, Resumen{
+			ID:       catalogo.ID,
+			Nombre:   catalogo.Nombre,
+			Version:  catalogo.Version,
+			Entradas: len(catalogo.Entradas),
+		})
+	}
+	sort.Slice(resumenes, func(i, j int) bool { return resumenes[i].ID < resumenes[j].ID })
+	return resumenes
+}
+
+// Buscar devuelve la entrada del código en el catálogo
+func (c *Catalogo) Buscar(codigo string) (Entrada, bool) {
+	posicion, ok := c.indice[codigo]
+	if !ok {
+		return Entrada{}, false
+	}
+	return c.Entradas[posicion], true
+}
+
+// Contiene indica si el código existe en el catálogo
+func (c *Catalogo) Contiene(codigo string) bool {
+	_, ok := c.indice[codigo]
+	return ok
+}
+
+// debeObtener es para los catálogos que el servicio necesita; su ausencia es un error de los datos incluidos
+func debeObtener(id string) *Catalogo {
+	catalogo, ok := catalogos[id]
+	if !ok {
+		panic(fmt.Sprintf("catálogo %s no incluido", id))
+	}
+	return catalogo
+}
+
+// debeContener es para los códigos que el servicio elige por su significado; su ausencia es un error de los
+// datos incluidos
+func debeContener(id, codigo string) string {
+	if !debeObtener(id).Contiene(codigo) {
+		panic(fmt.Sprintf("código %s no incluido en el catálogo %s", codigo, id))
+	}
+	return codigo
+}
@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListar(t *testing.T) {
+	resumenes := Listar()
+
+	var ids []string
+	for _, resumen := range resumenes {
+		ids = append(ids, resumen.ID)
+		if resumen.Version == "" || resumen.Entradas == 0 {
+			t.Errorf("Expected version and entries for catalog %s, got %q and %d", resumen.ID, resumen.Version, resumen.Entradas)
+		}
+	}
+
+	esperado := "01,03,06,07,09,10,51,54"
+	if strings.Join(ids, ",") != esperado {
+		t.Errorf("Expected catalogs %s, got %s", esperado, strings.Join(ids, ","))
+	}
+}
+
+func TestObtener(t *testing.T) {
+	catalogo, ok := Obtener(UnidadesMedida)
+	if !ok {
+		t.Fatal("Expected catalog 03 to exist")
+	}
+
+	entrada, ok := catalogo.Buscar("KGM")
+	if !ok || entrada.Descripcion != "Kilogramo" {
+		t.Errorf("Expected KGM Kilogramo, got %+v", entrada)
+	}
+
+	if catalogo.Entradas[0].Codigo != "NIU" {
+		t.Errorf("Expected entries in file order starting with NIU, got %s", catalogo.Entradas[0].Codigo)
+	}
+
+	if _, ok := Obtener("99"); ok {
+		t.Error("Expected catalog 99 not to exist")
+	}
+}
+
+func TestDescripcionYExiste(t *testing.T) {
+	testCases := []struct {
+		id          string
+		codigo      string
+		existe      bool
+		descripcion string
+	}{
+		{MotivosNotaCredito, "01", true, "Anulación de la operación"},
+		{MotivosNotaDebito, "01", true, "Intereses por mora"},
+		{MotivosNotaDebito, "04", false, ""},
+		{TiposIdentidad, "6", true, "Registro Único de Contribuyentes (RUC)"},
+		{TiposOperacion, "1001", true, "Operación sujeta a detracción"},
+		{"99", "01", false, ""},
+	}
+
+	for _, tc := range testCases {
+		descripcion, ok := Descripcion(tc.id, tc.codigo)
+		if ok != tc.existe || descripcion != tc.descripcion {
+			t.Errorf("Expected %v %q for %s/%s, got %v %q", tc.existe, tc.descripcion, tc.id, tc.codigo, ok, descripcion)
+		}
+
+		if Existe(tc.id, tc.codigo) != tc.existe {
+			t.Errorf("Expected Existe(%s, %s) to be %v", tc.id, tc.codigo, tc.existe)
+		}
+	}
+}
+
+func TestAfectacionIGV(t *testing.T) {
+	testCases := []struct {
+		codigo    string
+		categoria string
+		gratuito  bool
+	}{
+		{"10", "GRAVADO", false},
+		{"13", "GRAVADO", true},
+		{"20", "EXONERADO", false},
+		{"37", "INAFECTO", true},
+	}
+
+	for _, tc := range testCases {
+		afectacion, ok := AfectacionIGV(tc.codigo)
+		if !ok || afectacion.Categoria != tc.categoria || afectacion.Gratuito != tc.gratuito {
+			t.Errorf("Expected %s gratuito=%v for %s, got %+v", tc.categoria, tc.gratuito, tc.codigo, afectacion)
+		}
+	}
+
+	if _, ok := AfectacionIGV("40"); ok {
+		t.Error("Expected 40 not to be an IGV affectation")
+	}
+}
+
+func TestDetraccion(t *testing.T) {
+	servicios, ok := Detraccion("037")
+	if !ok || servicios.Porcentaje.String() != "0.12" || servicios.UmbralAplicable().String() != "700.00" {
+		t.Errorf("Expected 037 at 0.12 over 700.00, got %+v", servicios)
+	}
+
+	transporte, ok := Detraccion("027")
+	if !ok || transporte.Porcentaje.String() != "0.04" || transporte.UmbralAplicable().String() != "400.00" {
+		t.Errorf("Expected 027 at 0.04 over 400.00, got %+v", transporte)
+	}
+}
+
+func TestLeer_Invalido(t *testing.T) {
+	testCases := []struct {
+		name      string
+		contenido string
+		mensaje   string
+	}{
+		{"JSON inválido", `{"id": `, "unexpected end"},
+		{"Sin versión", `{"id": "03", "nombre": "Unidades", "entradas": [{"codigo": "NIU", "descripcion": "Unidad"}]}`, "version son obligatorios"},
+		{"Sin entradas", `{"id": "03", "nombre": "Unidades", "version": "1", "entradas": []}`, "no tiene entradas"},
+		{"Sin descripción", `{"id": "03", "nombre": "Unidades", "version": "1", "entradas": [{"codigo": "NIU"}]}`, "entrada 0"},
+		{"Código repetido", `{"id": "03", "nombre": "Unidades", "version": "1", "entradas": [{"codigo": "NIU", "descripcion": "Unidad"}, {"codigo": "NIU", "descripcion": "Otra"}]}`, `código "NIU" repetido`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := leer([]byte(tc.contenido))
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got %v", tc.mensaje, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package catalog
+
+import (
+	"fmt"
+	"ms1-documents/pkg/money"
+)
+
+// UmbralDetraccion es el importe de la operación en soles desde el que, al superarlo, corresponde la detracción
+var UmbralDetraccion = money.MustParse("700.00")
+
+// Afectacion es una entrada del catálogo 07 con la categoría en la que suma a los subtotales del documento
+type Afectacion struct {
+	Codigo      string
+	Descripcion string
+	Categoria   string
+	Gratuito    bool
+}
+
+// BienServicioDetraccion es una entrada del catálogo 54 con el porcentaje vigente del SPOT
+type BienServicioDetraccion struct {
+	Codigo      string
+	Descripcion string
+	Porcentaje  money.Money
+	// Umbral reemplaza a UmbralDetraccion cuando la norma fija otro importe mínimo
+	Umbral money.Money
+}
+
+// UmbralAplicable devuelve el importe que la operación debe superar para estar sujeta a detracción
+func (b BienServicioDetraccion) UmbralAplicable() money.Money {
+	if b.Umbral.EsCero() {
+		return UmbralDetraccion
+	}
+	return b.Umbral
+}
+
+var (
+	afectaciones = leerAfectaciones(debeObtener(AfectacionesIGV))
+	detracciones = leerDetracciones(debeObtener(BienesServiciosDetraccion))
+)
+
+// Tipos de operación del catálogo 51 que se informan en el XML UBL según la venta
+var (
+	OperacionVentaInterna = debeContener(TiposOperacion, "0101")
+	OperacionDetraccion   = debeContener(TiposOperacion, "1001")
+	OperacionPercepcion   = debeContener(TiposOperacion, "2001")
+)
+
+// Descripcion devuelve la descripción del código en el catálogo indicado
+func Descripcion(id, codigo string) (string, bool) {
+	catalogo, ok := catalogos[id]
+	if !ok {
+		return "", false
+	}
+	entrada, ok := catalogo.Buscar(codigo)
+	return entrada.Descripcion, ok
+}
+
+// Existe indica si el código pertenece al catálogo indicado
+func Existe(id, codigo string) bool {
+	catalogo, ok := catalogos[id]
+	return ok && catalogo.Contiene(codigo)
+}
+
+// AfectacionIGV busca el tipo de afectación del catálogo 07
+func AfectacionIGV(codigo string) (Afectacion, bool) {
+	afectacion, ok := afectaciones[codigo]
+	return afectacion, ok
+}
+
+// Detraccion busca el bien o servicio sujeto a detracción del catálogo 54
+func Detraccion(codigo string) (BienServicioDetraccion, bool) {
+	bienServicio, ok := detracciones[codigo]
+	return bienServicio, ok
+}
+
+func leerAfectaciones(catalogo *Catalogo) map[string]Afectacion {
+	resultado := make(map[string]Afectacion, len(catalogo.Entradas))
+	for _, entrada := range catalogo.Entradas {
+		categoria := entrada.Atributos["categoria"]
+		gratuito := entrada.Atributos["gratuito"]
+		if categoria == "" || (gratuito != "true" && gratuito != "false") {
+			panic(fmt.Sprintf("catálogo %s, código %s: categoria y gratuito (true o false) son obligatorios", catalogo.ID, entrada.Codigo))
+		}
+		resultado[entrada.Codigo] = Afectacion{
+			Codigo:      entrada.Codigo,
+			Descripcion: entrada.Descripcion,
+			Categoria:   categoria,
+			Gratuito:    gratuito == "true",
+		}
+	}
+	return resultado
+}
+
+func leerDetracciones(catalogo *Catalogo) map[string]BienServicioDetraccion {
+	resultado := make(map[string]BienServicioDetraccion, len(catalogo.Entradas))
+	for _, entrada := range catalogo.Entradas {
+		porcentaje, err := money.Parse(entrada.Atributos["porcentaje"])
+		if err != nil || !porcentaje.EsPositivo() {
+			panic(fmt.Sprintf("catálogo %s, código %s: porcentaje inválido", catalogo.ID, entrada.Codigo))
+		}
+
+		bienServicio := BienServicioDetraccion{Codigo: entrada.Codigo, Descripcion: entrada.Descripcion, Porcentaje: porcentaje}
+		if umbral, ok := entrada.Atributos["umbral"]; ok {
+			if bienServicio.Umbral, err = money.Parse(umbral); err != nil {
+				panic(fmt.Sprintf("catálogo %s, código %s: umbral inválido", catalogo.ID, entrada.Codigo))
+			}
+		}
+		resultado[entrada.Codigo] = bienServicio
+	}
+	return resultado
+}
@@ -2,15 +2,18 @@ package config
 
 import (
 	"context"
+	"errors"
 	"log"
 	"ms1-documents/internal/utils"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Database struct {
 	Client     *mongo.Client
+	DB         *mongo.Database
 	Collection *mongo.Collection
 }
 
@@ -29,10 +32,12 @@ func NewDatabase(uri, dbName, collectionName string) (*Database, error) {
 		return nil, err
 	}
 
-	collection := client.Database(dbName).Collection(collectionName)
+	baseDatos := client.Database(dbName)
+	collection := baseDatos.Collection(collectionName)
 
 	db := &Database{
 		Client:     client,
+		DB:         baseDatos,
 		Collection: collection,
 	}
 
@@ -46,12 +51,119 @@ func NewDatabase(uri, dbName, collectionName string) (*Database, error) {
 }
 
 func (db *Database) createIndexes(contexto context.Context) error {
+	// Cada emisor numera sus propias series, así que idDocumento solo es único dentro del emisor
+	_, err := db.Collection.Indexes().DropOne(contexto, "idDocumento_1")
+	var errComando mongo.CommandError
+	if err != nil && !(errors.As(err, &errComando) && (errComando.Name == "IndexNotFound" || errComando.Name == "NamespaceNotFound")) {
+		return err
+	}
+
 	indexModel := mongo.IndexModel{
-		Keys:    map[string]interface{}{"idDocumento": 1},
+		Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "idDocumento", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err = db.Collection.Indexes().CreateOne(contexto, indexModel)
+	if err != nil {
+		return err
+	}
+
+	indiceSeries := mongo.IndexModel{
+		Keys: bson.D{{Key: "rucEmisor", Value: 1}, {Key: "serie", Value: 1}},
+	}
+
+	_, err = db.DB.Collection(utils.SeriesCollection).Indexes().CreateOne(contexto, indiceSeries)
+	if err != nil {
+		return err
+	}
+
+	// Una comunicación por emisor, día y fecha de emisión, con número único en el día
+	indicesComunicaciones := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "fechaGeneracion", Value: 1}, {Key: "fechaReferencia", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, err = db.DB.Collection(utils.VoidSummariesCollection).Indexes().CreateMany(contexto, indicesComunicaciones)
+	if err != nil {
+		return err
+	}
+
+	indicesResumenes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "rucEmisor", Value: 1}, {Key: "fechaReferencia", Value: 1}, {Key: "secuencia", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"secuencia": bson.M{"$exists": true}}),
+		},
+	}
+
+	_, err = db.DB.Collection(utils.DailySummariesCollection).Indexes().CreateMany(contexto, indicesResumenes)
+	if err != nil {
+		return err
+	}
+
+	indiceEmisores := mongo.IndexModel{
+		Keys:    bson.D{{Key: "ruc", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err = db.DB.Collection(utils.IssuersCollection).Indexes().CreateOne(contexto, indiceEmisores)
+	if err != nil {
+		return err
+	}
+
+	// Un cliente por emisor y documento de identidad; la búsqueda por nombre usa el prefijo normalizado
+	indicesClientes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "tipoDocumento", Value: 1}, {Key: "numeroDocumento", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "rucEmisor", Value: 1}, {Key: "nombreBusqueda", Value: 1}},
+		},
+	}
+
+	_, err = db.DB.Collection(utils.CustomersCollection).Indexes().CreateMany(contexto, indicesClientes)
+	if err != nil {
+		return err
+	}
+
+	indiceProductos := mongo.IndexModel{
+		Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "codigo", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err = db.DB.Collection(utils.ProductsCollection).Indexes().CreateOne(contexto, indiceProductos)
+	if err != nil {
+		return err
+	}
+
+	indiceReglas := mongo.IndexModel{
+		Keys:    bson.D{{Key: "rucEmisor", Value: 1}, {Key: "nombre", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err = db.DB.Collection(utils.BusinessRulesCollection).Indexes().CreateOne(contexto, indiceReglas)
+	if err != nil {
+		return err
+	}
+
+	// Una cotización por moneda y fecha; la búsqueda de la vigente recorre las fechas de una moneda
+	indiceTiposCambio := mongo.IndexModel{
+		Keys:    bson.D{{Key: "moneda", Value: 1}, {Key: "fecha", Value: 1}},
 		Options: options.Index().SetUnique(true),
 	}
 
-	_, err := db.Collection.Indexes().CreateOne(contexto, indexModel)
+	_, err = db.DB.Collection(utils.ExchangeRatesCollection).Indexes().CreateOne(contexto, indiceTiposCambio)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,38 @@
+package domain
+
+// Ámbitos de una regla de negocio: se evalúa una vez sobre el documento o una vez por cada item
+const (
+	AmbitoReglaDocumento = "DOCUMENTO"
+	AmbitoReglaItem      = "ITEM"
+)
+
+// Estados de una regla de negocio; las inactivas se conservan pero no se aplican
+const (
+	EstadoReglaActiva   = "ACTIVA"
+	EstadoReglaInactiva = "INACTIVA"
+)
+
+// ReglaNegocio es una regla propia de un emisor que sus documentos deben cumplir además de las de SUNAT.
+// Condicion y Expresion se escriben sobre los nombres JSON de los campos del documento, o del item con
+// ámbito ITEM (montoTotal <= 50000, descripcion contiene "tabaco").
+type ReglaNegocio struct {
+	RucEmisor   string `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	Nombre      string `json:"nombre" bson:"nombre" example:"orden-compra-obligatoria"`
+	Descripcion string `json:"descripcion,omitempty" bson:"descripcion,omitempty" example:"Los clientes corporativos exigen su orden de compra"`
+	Ambito      string `json:"ambito" bson:"ambito" example:"DOCUMENTO"`
+	// Condicion limita la regla a los documentos o items en que es verdadera; vacía se aplica siempre
+	Condicion string `json:"condicion,omitempty" bson:"condicion,omitempty" example:"rucReceptor en [\"20987654326\"]"`
+	// Expresion es lo que el documento o item debe cumplir
+	Expresion string `json:"expresion" bson:"expresion" example:"ordenCompra != \"\""`
+	// Campo es el campo al que apuntan los errores; vacío apunta al documento o al item
+	Campo              string `json:"campo,omitempty" bson:"campo,omitempty" example:"ordenCompra"`
+	Mensaje            string `json:"mensaje" bson:"mensaje" example:"El cliente exige el número de su orden de compra"`
+	Estado             string `json:"estado" bson:"estado" example:"ACTIVA"`
+	FechaCreacion      string `json:"fechaCreacion,omitempty" bson:"fechaCreacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+	FechaActualizacion string `json:"fechaActualizacion,omitempty" bson:"fechaActualizacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+}
+
+// EstaActiva indica si la regla se aplica a los documentos del emisor
+func (r *ReglaNegocio) EstaActiva() bool {
+	return r.Estado == EstadoReglaActiva
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "ms1-documents/pkg/money"
+
+// CargoDescuento es un descuento o cargo de item o global con motivo del catálogo 53.
+// EsCargo y AfectaBase se derivan del código al validar el documento.
+type CargoDescuento struct {
+	Codigo     string      `json:"codigo" bson:"codigo" example:"00"`
+	Factor     money.Money `json:"factor,omitzero" bson:"factor,omitempty" swaggertype:"number" example:"0.10"`
+	MontoBase  money.Money `json:"montoBase,omitzero" bson:"montoBase,omitempty" swaggertype:"number" example:"100.00"`
+	Monto      money.Money `json:"monto" bson:"monto" swaggertype:"number" example:"10.00"`
+	EsCargo    bool        `json:"esCargo" bson:"esCargo" example:"false"`
+	AfectaBase bool        `json:"afectaBase" bson:"afectaBase" example:"true"`
+}
+
+type motivoCargoDescuento struct {
+	esCargo    bool
+	afectaBase bool
+	global     bool
+}
+
+// Subconjunto del catálogo 53 que admite el sistema
+var motivosCargoDescuento = map[string]motivoCargoDescuento{
+	"00": {esCargo: false, afectaBase: true, global: false},
+	"01": {esCargo: false, afectaBase: false, global: false},
+	"02": {esCargo: false, afectaBase: true, global: true},
+	"03": {esCargo: false, afectaBase: false, global: true},
+	"46": {esCargo: true, afectaBase: false, global: true},
+	"47": {esCargo: true, afectaBase: true, global: false},
+	"48": {esCargo: true, afectaBase: false, global: false},
+	"49": {esCargo: true, afectaBase: true, global: true},
+	"50": {esCargo: true, afectaBase: false, global: true},
+}
+
+// MotivoCargoDescuento indica si el código es un cargo, si afecta la base imponible y si es de nivel global
+func MotivoCargoDescuento(codigo string) (esCargo, afectaBase, global, ok bool) {
+	motivo, ok := motivosCargoDescuento[codigo]
+	return motivo.esCargo, motivo.afectaBase, motivo.global, ok
+}
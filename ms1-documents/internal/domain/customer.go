@@ -0,0 +1,41 @@
+package domain
+
+import "strings"
+
+// DatosReceptor son el nombre, la dirección y el correo del adquiriente tal como figuran en el documento
+type DatosReceptor struct {
+	Nombre    string `json:"nombre" bson:"nombre" example:"CLIENTE DEMO S.A."`
+	Direccion string `json:"direccion,omitempty" bson:"direccion,omitempty" example:"Jr. Lampa 456, Lima"`
+	Email     string `json:"email,omitempty" bson:"email,omitempty" example:"compras@cliente.pe"`
+}
+
+// Cliente es un adquiriente frecuente de un emisor, identificado por su documento de identidad
+type Cliente struct {
+	RucEmisor       string `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	TipoDocumento   string `json:"tipoDocumento" bson:"tipoDocumento" example:"6"`
+	NumeroDocumento string `json:"numeroDocumento" bson:"numeroDocumento" example:"20987654326"`
+	Nombre          string `json:"nombre" bson:"nombre" example:"CLIENTE DEMO S.A."`
+	Direccion       string `json:"direccion,omitempty" bson:"direccion,omitempty" example:"Jr. Lampa 456, Lima"`
+	Email           string `json:"email,omitempty" bson:"email,omitempty" example:"compras@cliente.pe"`
+	// NombreBusqueda es el nombre en mayúsculas que usa la búsqueda por prefijo
+	NombreBusqueda     string `json:"-" bson:"nombreBusqueda"`
+	FechaActualizacion string `json:"fechaActualizacion,omitempty" bson:"fechaActualizacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+}
+
+// ClienteDesdeDocumento arma la entrada de la libreta de clientes con el receptor del documento
+func ClienteDesdeDocumento(doc *Document) *Cliente {
+	return &Cliente{
+		RucEmisor:       doc.RucEmisor,
+		TipoDocumento:   doc.TipoDocumentoReceptor,
+		NumeroDocumento: doc.RucReceptor,
+		Nombre:          doc.Receptor.Nombre,
+		Direccion:       doc.Receptor.Direccion,
+		Email:           doc.Receptor.Email,
+		NombreBusqueda:  NormalizarBusqueda(doc.Receptor.Nombre),
+	}
+}
+
+// NormalizarBusqueda lleva un texto a la forma en que se compara en la búsqueda de clientes
+func NormalizarBusqueda(texto string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(texto), " "))
+}
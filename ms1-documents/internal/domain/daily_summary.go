@@ -0,0 +1,92 @@
+package domain
+
+import "ms1-documents/pkg/money"
+
+// Estados de cada línea del resumen diario según el catálogo 19 de SUNAT
+const (
+	EstadoResumenAdicionar = "1"
+	EstadoResumenModificar = "2"
+	EstadoResumenAnular    = "3"
+)
+
+// DescripcionesEstadoResumen describe los códigos del catálogo 19
+var DescripcionesEstadoResumen = map[string]string{
+	EstadoResumenAdicionar: "Adicionar",
+	EstadoResumenModificar: "Modificar",
+	EstadoResumenAnular:    "Anulado",
+}
+
+// ResumenDiario (RC-AAAAMMDD-n) informa a SUNAT las boletas de un emisor y sus notas emitidas en una
+// misma fecha. Cada resumen incluye solo lo que cambió desde los anteriores de esa fecha.
+type ResumenDiario struct {
+	ID              string        `json:"id" bson:"id" example:"RC-20260213-1"`
+	RucEmisor       string        `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	FechaGeneracion string        `json:"fechaGeneracion" bson:"fechaGeneracion" example:"2026-02-13"`
+	FechaReferencia string        `json:"fechaReferencia" bson:"fechaReferencia" example:"2026-02-12"`
+	Items           []ItemResumen `json:"items" bson:"items"`
+	// TotalesPorCategoria suma en soles los documentos informados que no están anulados; las notas de crédito restan
+	TotalesPorCategoria TotalesCategoria `json:"totalesPorCategoria" bson:"totalesPorCategoria"`
+	TotalesPorEstado    []TotalEstado    `json:"totalesPorEstado" bson:"totalesPorEstado"`
+	// Secuencia numera los resúmenes del emisor para fechaReferencia; su índice único impide guardar dos
+	// resúmenes armados sobre los mismos anteriores
+	Secuencia int `json:"-" bson:"secuencia,omitempty"`
+}
+
+// ItemResumen es una línea del resumen con los importes del documento en su moneda
+type ItemResumen struct {
+	IDDocumento           string      `json:"idDocumento" bson:"idDocumento" example:"B001-00000001"`
+	TipoDocumento         string      `json:"tipoDocumento" bson:"tipoDocumento" example:"03"`
+	TipoDocumentoReceptor string      `json:"tipoDocumentoReceptor" bson:"tipoDocumentoReceptor" example:"1"`
+	NumeroReceptor        string      `json:"numeroReceptor" bson:"numeroReceptor" example:"12345678"`
+	DocumentoReferencia   string      `json:"documentoReferencia,omitempty" bson:"documentoReferencia,omitempty" example:"B001-00000001"`
+	Moneda                string      `json:"moneda" bson:"moneda" example:"PEN"`
+	TipoCambio            money.Money `json:"tipoCambio,omitzero" bson:"tipoCambio,omitempty" swaggertype:"number" example:"3.712"`
+	Estado                string      `json:"estado" bson:"estado" example:"1"`
+	TotalGravado          money.Money `json:"totalGravado" bson:"totalGravado" swaggertype:"number" example:"100.00"`
+	TotalExonerado        money.Money `json:"totalExonerado" bson:"totalExonerado" swaggertype:"number" example:"0.00"`
+	TotalInafecto         money.Money `json:"totalInafecto" bson:"totalInafecto" swaggertype:"number" example:"0.00"`
+	TotalGratuito         money.Money `json:"totalGratuito" bson:"totalGratuito" swaggertype:"number" example:"0.00"`
+	IscTotal              money.Money `json:"iscTotal" bson:"iscTotal" swaggertype:"number" example:"0.00"`
+	IgvTotal              money.Money `json:"igvTotal" bson:"igvTotal" swaggertype:"number" example:"18.00"`
+	IcbperTotal           money.Money `json:"icbperTotal" bson:"icbperTotal" swaggertype:"number" example:"0.00"`
+	TotalCargos           money.Money `json:"totalCargos" bson:"totalCargos" swaggertype:"number" example:"0.00"`
+	MontoTotal            money.Money `json:"montoTotal" bson:"montoTotal" swaggertype:"number" example:"118.00"`
+}
+
+// MismosImportes indica si dos líneas informan los mismos datos del documento, sin considerar el estado
+func (i ItemResumen) MismosImportes(otro ItemResumen) bool {
+	return i.TipoDocumentoReceptor == otro.TipoDocumentoReceptor &&
+		i.NumeroReceptor == otro.NumeroReceptor &&
+		i.DocumentoReferencia == otro.DocumentoReferencia &&
+		i.Moneda == otro.Moneda &&
+		i.TipoCambio.Igual(otro.TipoCambio) &&
+		i.TotalGravado.Igual(otro.TotalGravado) &&
+		i.TotalExonerado.Igual(otro.TotalExonerado) &&
+		i.TotalInafecto.Igual(otro.TotalInafecto) &&
+		i.TotalGratuito.Igual(otro.TotalGratuito) &&
+		i.IscTotal.Igual(otro.IscTotal) &&
+		i.IgvTotal.Igual(otro.IgvTotal) &&
+		i.IcbperTotal.Igual(otro.IcbperTotal) &&
+		i.TotalCargos.Igual(otro.TotalCargos) &&
+		i.MontoTotal.Igual(otro.MontoTotal)
+}
+
+// TotalesCategoria agrupa los importes por categoría tributaria
+type TotalesCategoria struct {
+	TotalGravado   money.Money `json:"totalGravado" bson:"totalGravado" swaggertype:"number" example:"100.00"`
+	TotalExonerado money.Money `json:"totalExonerado" bson:"totalExonerado" swaggertype:"number" example:"0.00"`
+	TotalInafecto  money.Money `json:"totalInafecto" bson:"totalInafecto" swaggertype:"number" example:"0.00"`
+	TotalGratuito  money.Money `json:"totalGratuito" bson:"totalGratuito" swaggertype:"number" example:"0.00"`
+	IscTotal       money.Money `json:"iscTotal" bson:"iscTotal" swaggertype:"number" example:"0.00"`
+	IgvTotal       money.Money `json:"igvTotal" bson:"igvTotal" swaggertype:"number" example:"18.00"`
+	IcbperTotal    money.Money `json:"icbperTotal" bson:"icbperTotal" swaggertype:"number" example:"0.00"`
+	MontoTotal     money.Money `json:"montoTotal" bson:"montoTotal" swaggertype:"number" example:"118.00"`
+}
+
+// TotalEstado cuenta los documentos del resumen con un mismo estado del catálogo 19 y su importe en soles
+type TotalEstado struct {
+	Estado      string      `json:"estado" bson:"estado" example:"1"`
+	Descripcion string      `json:"descripcion" bson:"descripcion" example:"Adicionar"`
+	Documentos  int         `json:"documentos" bson:"documentos" example:"1"`
+	MontoTotal  money.Money `json:"montoTotal" bson:"montoTotal" swaggertype:"number" example:"118.00"`
+}
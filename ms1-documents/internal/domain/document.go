@@ -1,32 +1,225 @@
 package domain
 
+import (
+	"encoding/json"
+	"ms1-documents/pkg/letras"
+	"ms1-documents/pkg/money"
+	"time"
+)
+
 const (
 	ISO8601Format = "2006-01-02T15:04:05Z07:00"
 )
 
+const (
+	TipoFactura     = "01"
+	TipoBoleta      = "03"
+	TipoNotaCredito = "07"
+	TipoNotaDebito  = "08"
+)
+
+const (
+	MonedaPEN = "PEN"
+	MonedaUSD = "USD"
+	MonedaEUR = "EUR"
+)
+
+func EsMonedaValida(moneda string) bool {
+	return moneda == MonedaPEN || moneda == MonedaUSD || moneda == MonedaEUR
+}
+
+const (
+	IdentidadSinDocumento      = "0"
+	IdentidadDNI               = "1"
+	IdentidadCarnetExtranjeria = "4"
+	IdentidadRUC               = "6"
+	IdentidadPasaporte         = "7"
+)
+
+func EsNota(tipoDocumento string) bool {
+	return tipoDocumento == TipoNotaCredito || tipoDocumento == TipoNotaDebito
+}
+
 type Item struct {
-	Descripcion    string  `json:"descripcion" bson:"descripcion" example:"Producto A"`
-	PrecioUnitario float64 `json:"precioUnitario" bson:"precioUnitario" example:"100.00"`
-	Cantidad       int     `json:"cantidad" bson:"cantidad" example:"5"`
-	PrecioTotal    float64 `json:"precioTotal" bson:"precioTotal" example:"500.00"`
-	IgvTotal       float64 `json:"igvTotal" bson:"igvTotal" example:"90.00"`
+	CodigoProducto      string           `json:"codigoProducto,omitempty" bson:"codigoProducto,omitempty" example:"P-001"`
+	CodigoProductoSunat string           `json:"codigoProductoSunat,omitempty" bson:"codigoProductoSunat,omitempty" example:"50161509"`
+	Descripcion         string           `json:"descripcion" bson:"descripcion" example:"Producto A"`
+	PrecioUnitario      money.Money      `json:"precioUnitario" bson:"precioUnitario" swaggertype:"number" example:"100.00"`
+	Cantidad            money.Money      `json:"cantidad" bson:"cantidad" swaggertype:"number" example:"5"`
+	UnidadMedida        string           `json:"unidadMedida,omitempty" bson:"unidadMedida,omitempty" example:"NIU"`
+	PrecioTotal         money.Money      `json:"precioTotal" bson:"precioTotal" swaggertype:"number" example:"500.00"`
+	IgvTotal            money.Money      `json:"igvTotal" bson:"igvTotal" swaggertype:"number" example:"90.00"`
+	TipoAfectacionIgv   string           `json:"tipoAfectacionIgv" bson:"tipoAfectacionIgv" example:"10"`
+	IscTotal            money.Money      `json:"iscTotal,omitzero" bson:"iscTotal,omitempty" swaggertype:"number" example:"0.00"`
+	IcbperTotal         money.Money      `json:"icbperTotal,omitzero" bson:"icbperTotal,omitempty" swaggertype:"number" example:"0.00"`
+	CargosDescuentos    []CargoDescuento `json:"cargosDescuentos,omitempty" bson:"cargosDescuentos,omitempty"`
+}
+
+var ZonaHorariaPeru = time.FixedZone("PET", -5*60*60)
+
+const (
+	EstadoBorrador = "BORRADOR"
+	EstadoEmitido  = "EMITIDO"
+	EstadoAnulado  = "ANULADO"
+)
+
+type Anulacion struct {
+	Motivo         string `json:"motivo" bson:"motivo" example:"Error en la descripción"`
+	FechaAnulacion string `json:"fechaAnulacion" bson:"fechaAnulacion" example:"2026-02-13T09:00:00-05:00"`
 }
 
+const (
+	EstadoValido   = "Válido"
+	EstadoInvalido = "Inválido"
+)
+
 type Validacion struct {
 	FechaValidacion string `json:"fechaValidacion,omitempty" bson:"fechaValidacion,omitempty" example:"2026-02-12T10:00:00Z"`
 	Firma           string `json:"firma,omitempty" bson:"firma,omitempty" example:"abc123def456"`
-	Estado          string `json:"estado,omitempty" bson:"estado,omitempty" example:"VALIDO"`
+	DigestValue     string `json:"digestValue,omitempty" bson:"digestValue,omitempty" example:"q1ZbYx3CqjbUuzZ0cHXCDRbTtu6TYsjBwiyxPM1gLdU="`
+	Estado          string `json:"estado,omitempty" bson:"estado,omitempty" example:"Válido"`
+}
+
+type DocumentoReferencia struct {
+	IDDocumento   string `json:"idDocumento" bson:"idDocumento" example:"F001-00000001"`
+	TipoDocumento string `json:"tipoDocumento" bson:"tipoDocumento" example:"01"`
+	CodigoMotivo  string `json:"codigoMotivo" bson:"codigoMotivo" example:"01"`
+	Sustento      string `json:"sustento,omitempty" bson:"sustento,omitempty" example:"Anulación de la operación"`
+}
+
+// Saldo resume el efecto de las notas sobre una factura o boleta
+type Saldo struct {
+	TotalNotasCredito money.Money `json:"totalNotasCredito" swaggertype:"number" example:"118.00"`
+	TotalNotasDebito  money.Money `json:"totalNotasDebito" swaggertype:"number" example:"0.00"`
+	SaldoNeto         money.Money `json:"saldoNeto" swaggertype:"number" example:"1062.00"`
 }
 
 type Document struct {
-	IDDocumento            string      `json:"idDocumento" bson:"idDocumento" example:"DOC-001"`
-	UUID                   string      `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
-	RucEmisor              string      `json:"rucEmisor" bson:"rucEmisor" example:"20123456789"`
-	RucReceptor            string      `json:"rucReceptor" bson:"rucReceptor" example:"20987654321"`
-	FechaEmision           string      `json:"fechaEmision" bson:"fechaEmision" example:"2026-02-12T10:00:00Z"`
-	MontoTotalSinImpuestos float64     `json:"montoTotalSinImpuestos" bson:"montoTotalSinImpuestos" example:"1000.00"`
-	IgvTotal               float64     `json:"igvTotal" bson:"igvTotal" example:"180.00"`
-	MontoTotal             float64     `json:"montoTotal" bson:"montoTotal" example:"1180.00"`
-	Items                  []Item      `json:"items" bson:"items"`
-	Validacion             *Validacion `json:"validacion,omitempty" bson:"validacion,omitempty"`
+	IDDocumento            string               `json:"idDocumento" bson:"idDocumento" example:"F001-00000001"`
+	Serie                  string               `json:"serie" bson:"serie" example:"F001"`
+	TipoDocumento          string               `json:"tipoDocumento" bson:"tipoDocumento" example:"01"`
+	UUID                   string               `json:"uuid" bson:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RucEmisor              string               `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	Emisor                 *DatosEmisor         `json:"emisor,omitempty" bson:"emisor,omitempty"`
+	TipoDocumentoReceptor  string               `json:"tipoDocumentoReceptor" bson:"tipoDocumentoReceptor" example:"6"`
+	RucReceptor            string               `json:"rucReceptor" bson:"rucReceptor" example:"20987654326"`
+	Receptor               *DatosReceptor       `json:"receptor,omitempty" bson:"receptor,omitempty"`
+	OrdenCompra            string               `json:"ordenCompra,omitempty" bson:"ordenCompra,omitempty" example:"OC-2026-0042"`
+	GuardarCliente         bool                 `json:"guardarCliente,omitempty" bson:"-" example:"true"`
+	FechaEmision           string               `json:"fechaEmision" bson:"fechaEmision" example:"2026-02-12T10:00:00Z"`
+	FechaEmisionLocal      string               `json:"-" bson:"fechaEmisionLocal,omitempty"`
+	Moneda                 string               `json:"moneda" bson:"moneda" example:"PEN"`
+	TipoCambio             money.Money          `json:"tipoCambio,omitzero" bson:"tipoCambio,omitempty" swaggertype:"number" example:"3.712"`
+	TotalGravado           money.Money          `json:"totalGravado" bson:"totalGravado" swaggertype:"number" example:"1000.00"`
+	TotalExonerado         money.Money          `json:"totalExonerado" bson:"totalExonerado" swaggertype:"number" example:"0.00"`
+	TotalInafecto          money.Money          `json:"totalInafecto" bson:"totalInafecto" swaggertype:"number" example:"0.00"`
+	TotalGratuito          money.Money          `json:"totalGratuito" bson:"totalGratuito" swaggertype:"number" example:"0.00"`
+	MontoTotalSinImpuestos money.Money          `json:"montoTotalSinImpuestos" bson:"montoTotalSinImpuestos" swaggertype:"number" example:"1000.00"`
+	IscTotal               money.Money          `json:"iscTotal,omitzero" bson:"iscTotal,omitempty" swaggertype:"number" example:"0.00"`
+	IcbperTotal            money.Money          `json:"icbperTotal,omitzero" bson:"icbperTotal,omitempty" swaggertype:"number" example:"0.00"`
+	TotalDescuentos        money.Money          `json:"totalDescuentos,omitzero" bson:"totalDescuentos,omitempty" swaggertype:"number" example:"0.00"`
+	TotalCargos            money.Money          `json:"totalCargos,omitzero" bson:"totalCargos,omitempty" swaggertype:"number" example:"0.00"`
+	RegimenIgv             string               `json:"regimenIgv,omitempty" bson:"regimenIgv,omitempty" example:"GENERAL"`
+	TasaIgv                money.Money          `json:"tasaIgv,omitzero" bson:"tasaIgv,omitempty" swaggertype:"number" example:"0.18"`
+	IgvTotal               money.Money          `json:"igvTotal" bson:"igvTotal" swaggertype:"number" example:"180.00"`
+	MontoTotal             money.Money          `json:"montoTotal" bson:"montoTotal" swaggertype:"number" example:"1180.00"`
+	MontoEnLetras          string               `json:"montoEnLetras,omitempty" bson:"-" example:"MIL CIENTO OCHENTA CON 00/100 SOLES"`
+	MontoTotalSoles        money.Money          `json:"montoTotalSoles,omitzero" bson:"-" swaggertype:"number" example:"4380.16"`
+	Items                  []Item               `json:"items" bson:"items"`
+	CargosDescuentos       []CargoDescuento     `json:"cargosDescuentos,omitempty" bson:"cargosDescuentos,omitempty"`
+	Referencia             *DocumentoReferencia `json:"referencia,omitempty" bson:"referencia,omitempty"`
+	FormaPago              *FormaPago           `json:"formaPago,omitempty" bson:"formaPago,omitempty"`
+	Detraccion             *Detraccion          `json:"detraccion,omitempty" bson:"detraccion,omitempty"`
+	Percepcion             *Percepcion          `json:"percepcion,omitempty" bson:"percepcion,omitempty"`
+	Retencion              *Retencion           `json:"retencion,omitempty" bson:"retencion,omitempty"`
+	Saldo                  *Saldo               `json:"saldo,omitempty" bson:"-"`
+	Validacion             *Validacion          `json:"validacion,omitempty" bson:"validacion,omitempty"`
+	Estado                 string               `json:"estado,omitempty" bson:"estado,omitempty" example:"EMITIDO"`
+	Anulacion              *Anulacion           `json:"anulacion,omitempty" bson:"anulacion,omitempty"`
+	// TotalAcreditado reserva el tope de crédito de las notas; no se expone en la API
+	TotalAcreditado money.Money `json:"-" bson:"totalAcreditado,omitempty"`
+}
+
+// DiaEmision devuelve el día AAAA-MM-DD de emisión en hora de Perú
+func (d *Document) DiaEmision() string {
+	if d.FechaEmisionLocal != "" {
+		return d.FechaEmisionLocal
+	}
+	emision, err := time.Parse(time.RFC3339, d.FechaEmision)
+	if err != nil {
+		return ""
+	}
+	return emision.In(ZonaHorariaPeru).Format("2006-01-02")
+}
+
+func (d *Document) CodigoEstado() string {
+	if d.Estado == "" {
+		return EstadoEmitido
+	}
+	return d.Estado
+}
+
+// EsDeBoleta indica si el documento es una boleta o una nota que la modifica
+func (d *Document) EsDeBoleta() bool {
+	if d.TipoDocumento == TipoBoleta {
+		return true
+	}
+	return EsNota(d.TipoDocumento) && d.Referencia != nil && d.Referencia.TipoDocumento == TipoBoleta
+}
+
+func (d *Document) EstaValidado() bool {
+	return d.Validacion != nil && d.Validacion.Estado == EstadoValido
+}
+
+func (d *Document) CodigoFormaPago() string {
+	if d.FormaPago == nil || d.FormaPago.Tipo == "" {
+		return FormaPagoContado
+	}
+	return d.FormaPago.Tipo
+}
+
+// MontoNetoPendiente es montoTotal menos la detracción y la retención
+func (d *Document) MontoNetoPendiente() money.Money {
+	neto := d.MontoTotal
+	if d.Detraccion != nil {
+		neto = neto.Restar(d.montoEnMoneda(d.Detraccion.Monto, d.Detraccion.Porcentaje))
+	}
+	if d.Retencion != nil {
+		neto = neto.Restar(d.montoEnMoneda(d.Retencion.Monto, d.Retencion.Porcentaje))
+	}
+	return neto
+}
+
+func (d *Document) montoEnMoneda(montoSoles, porcentaje money.Money) money.Money {
+	if d.CodigoMoneda() == MonedaPEN && !montoSoles.EsCero() {
+		return montoSoles
+	}
+	return d.MontoTotal.MultiplicarRedondeado(porcentaje, money.DecimalesMonto, money.RedondeoMitadArriba)
+}
+
+func (d *Document) CodigoMoneda() string {
+	if d.Moneda == "" {
+		return MonedaPEN
+	}
+	return d.Moneda
+}
+
+func (d *Document) ConvertirASoles(monto money.Money) money.Money {
+	if d.CodigoMoneda() == MonedaPEN {
+		return monto
+	}
+	return monto.MultiplicarRedondeado(d.TipoCambio, money.DecimalesMonto, money.RedondeoMitadArriba)
+}
+
+func (d Document) MarshalJSON() ([]byte, error) {
+	type documento Document
+	salida := documento(d)
+	salida.Moneda = d.CodigoMoneda()
+	salida.Estado = d.CodigoEstado()
+	salida.MontoEnLetras, _ = letras.MontoEnLetras(d.MontoTotal, d.CodigoMoneda())
+	salida.MontoTotalSoles = money.Money{}
+	if d.CodigoMoneda() != MonedaPEN {
+		salida.MontoTotalSoles = d.ConvertirASoles(d.MontoTotal)
+	}
+	return json.Marshal(salida)
 }
@@ -0,0 +1,75 @@
+package domain
+
+// Estados de un emisor; solo los activos pueden emitir documentos
+const (
+	EstadoEmisorActivo   = "ACTIVO"
+	EstadoEmisorInactivo = "INACTIVO"
+)
+
+// CodigoEstablecimientoPrincipal es el código de anexo del domicilio fiscal registrado en SUNAT
+const CodigoEstablecimientoPrincipal = "0000"
+
+// ConfiguracionEmisor guarda las preferencias de emisión. Sin series se admite cualquier serie válida;
+// la moneda y el régimen se aplican a los documentos que no los indican.
+type ConfiguracionEmisor struct {
+	Series           []string `json:"series,omitempty" bson:"series,omitempty" example:"F001,B001"`
+	MonedaPorDefecto string   `json:"monedaPorDefecto,omitempty" bson:"monedaPorDefecto,omitempty" example:"PEN"`
+	RegimenIgv       string   `json:"regimenIgv,omitempty" bson:"regimenIgv,omitempty" example:"GENERAL"`
+	Logo             string   `json:"logo,omitempty" bson:"logo,omitempty" example:"https://cdn.example.com/logo.png"`
+}
+
+// Emisor es la empresa que emite comprobantes, con los datos que SUNAT exige en el XML
+type Emisor struct {
+	RUC             string `json:"ruc" bson:"ruc" example:"20123456786"`
+	RazonSocial     string `json:"razonSocial" bson:"razonSocial" example:"EMPRESA DEMO S.A.C."`
+	NombreComercial string `json:"nombreComercial,omitempty" bson:"nombreComercial,omitempty" example:"Demo"`
+	Direccion       string `json:"direccion" bson:"direccion" example:"Av. Arequipa 123, Lima"`
+	// Ubigeo es el código INEI de 6 dígitos del domicilio fiscal
+	Ubigeo string `json:"ubigeo" bson:"ubigeo" example:"150101"`
+	// CodigoEstablecimiento es el código de anexo de 4 dígitos; 0000 si se omite
+	CodigoEstablecimiento string              `json:"codigoEstablecimiento" bson:"codigoEstablecimiento" example:"0000"`
+	Estado                string              `json:"estado" bson:"estado" example:"ACTIVO"`
+	Configuracion         ConfiguracionEmisor `json:"configuracion" bson:"configuracion"`
+	FechaCreacion         string              `json:"fechaCreacion,omitempty" bson:"fechaCreacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+	FechaActualizacion    string              `json:"fechaActualizacion,omitempty" bson:"fechaActualizacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+}
+
+// EstaActivo indica si el emisor puede emitir documentos
+func (e *Emisor) EstaActivo() bool {
+	return e.Estado == EstadoEmisorActivo
+}
+
+// AdmiteSerie indica si la serie está entre las configuradas; sin series configuradas se admite cualquiera
+func (e *Emisor) AdmiteSerie(serie string) bool {
+	if len(e.Configuracion.Series) == 0 {
+		return true
+	}
+	for _, configurada := range e.Configuracion.Series {
+		if configurada == serie {
+			return true
+		}
+	}
+	return false
+}
+
+// Datos devuelve la copia de los datos del emisor que se guarda con cada documento
+func (e *Emisor) Datos() *DatosEmisor {
+	return &DatosEmisor{
+		RazonSocial:           e.RazonSocial,
+		NombreComercial:       e.NombreComercial,
+		Direccion:             e.Direccion,
+		Ubigeo:                e.Ubigeo,
+		CodigoEstablecimiento: e.CodigoEstablecimiento,
+		Logo:                  e.Configuracion.Logo,
+	}
+}
+
+// DatosEmisor son los datos del emisor vigentes al crear el documento; no cambian si luego se edita el emisor
+type DatosEmisor struct {
+	RazonSocial           string `json:"razonSocial" bson:"razonSocial" example:"EMPRESA DEMO S.A.C."`
+	NombreComercial       string `json:"nombreComercial,omitempty" bson:"nombreComercial,omitempty" example:"Demo"`
+	Direccion             string `json:"direccion" bson:"direccion" example:"Av. Arequipa 123, Lima"`
+	Ubigeo                string `json:"ubigeo" bson:"ubigeo" example:"150101"`
+	CodigoEstablecimiento string `json:"codigoEstablecimiento" bson:"codigoEstablecimiento" example:"0000"`
+	Logo                  string `json:"logo,omitempty" bson:"logo,omitempty" example:"https://cdn.example.com/logo.png"`
+}
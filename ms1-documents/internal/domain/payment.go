@@ -0,0 +1,37 @@
+package domain
+
+import "ms1-documents/pkg/money"
+
+// Formas de pago que SUNAT exige declarar en las facturas (cac:PaymentTerms con ID FormaPago)
+const (
+	FormaPagoContado = "Contado"
+	FormaPagoCredito = "Credito"
+)
+
+// Cuota es un pago parcial de una venta al crédito; fechaVencimiento es AAAA-MM-DD
+type Cuota struct {
+	Monto            money.Money `json:"monto" bson:"monto" swaggertype:"number" example:"590.00"`
+	FechaVencimiento string      `json:"fechaVencimiento" bson:"fechaVencimiento" example:"2026-03-14"`
+}
+
+// FormaPago declara si la venta es al contado o al crédito. En crédito, montoPendiente es el neto
+// pendiente de pago y se reparte en las cuotas.
+type FormaPago struct {
+	Tipo           string      `json:"tipo" bson:"tipo" example:"Credito"`
+	MontoPendiente money.Money `json:"montoPendiente,omitzero" bson:"montoPendiente,omitempty" swaggertype:"number" example:"1180.00"`
+	Cuotas         []Cuota     `json:"cuotas,omitempty" bson:"cuotas,omitempty"`
+}
+
+// DocumentoVencido resume un documento al crédito con cuotas cuya fecha de vencimiento ya pasó
+type DocumentoVencido struct {
+	IDDocumento    string      `json:"idDocumento" example:"F001-00000001"`
+	RucEmisor      string      `json:"rucEmisor" example:"20123456786"`
+	RucReceptor    string      `json:"rucReceptor" example:"20987654326"`
+	FechaEmision   string      `json:"fechaEmision" example:"2026-02-12T10:00:00Z"`
+	Moneda         string      `json:"moneda" example:"PEN"`
+	MontoPendiente money.Money `json:"montoPendiente" swaggertype:"number" example:"1180.00"`
+	CuotasVencidas []Cuota     `json:"cuotasVencidas"`
+	MontoVencido   money.Money `json:"montoVencido" swaggertype:"number" example:"590.00"`
+	// DiasVencido cuenta desde la cuota vencida más antigua
+	DiasVencido int `json:"diasVencido" example:"12"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "ms1-documents/pkg/money"
+
+// UnidadMedidaPorDefecto es la unidad de bienes del catálogo 03 que se asume si el item no indica otra
+const UnidadMedidaPorDefecto = "NIU"
+
+func (i *Item) CodigoUnidadMedida() string {
+	if i.UnidadMedida == "" {
+		return UnidadMedidaPorDefecto
+	}
+	return i.UnidadMedida
+}
+
+// Producto es una entrada del catálogo de productos y servicios de un emisor
+type Producto struct {
+	RucEmisor   string `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	Codigo      string `json:"codigo" bson:"codigo" example:"P-001"`
+	Descripcion string `json:"descripcion" bson:"descripcion" example:"Arroz extra"`
+	// CodigoProductoSunat es el código UNSPSC de 8 dígitos
+	CodigoProductoSunat string `json:"codigoProductoSunat,omitempty" bson:"codigoProductoSunat,omitempty" example:"50221101"`
+	UnidadMedida        string `json:"unidadMedida" bson:"unidadMedida" example:"KGM"`
+	// PrecioUnitario es el valor unitario sin impuestos que se usa si el item no trae precio
+	PrecioUnitario     money.Money `json:"precioUnitario" bson:"precioUnitario" swaggertype:"number" example:"4.20"`
+	TipoAfectacionIgv  string      `json:"tipoAfectacionIgv" bson:"tipoAfectacionIgv" example:"10"`
+	FechaCreacion      string      `json:"fechaCreacion,omitempty" bson:"fechaCreacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+	FechaActualizacion string      `json:"fechaActualizacion,omitempty" bson:"fechaActualizacion,omitempty" example:"2026-02-12T10:00:00-05:00"`
+}
+
+// Completar llena los datos que el item omite con los del catálogo
+func (p *Producto) Completar(item *Item) {
+	if item.Descripcion == "" {
+		item.Descripcion = p.Descripcion
+	}
+	if item.CodigoProductoSunat == "" {
+		item.CodigoProductoSunat = p.CodigoProductoSunat
+	}
+	if item.UnidadMedida == "" {
+		item.UnidadMedida = p.UnidadMedida
+	}
+	if item.PrecioUnitario.EsCero() {
+		item.PrecioUnitario = p.PrecioUnitario
+	}
+	if item.TipoAfectacionIgv == "" {
+		item.TipoAfectacionIgv = p.TipoAfectacionIgv
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "fmt"
+
+// CorrelativoMaximo es el mayor número de 8 dígitos que admite SUNAT en una serie
+const CorrelativoMaximo = 99999999
+
+// SerieCorrelativo es el contador de numeración de una serie de un emisor. Liberados son los huecos de
+// la serie: números reservados que quedaron sin documento y no se reasignan para no romper el orden cronológico.
+type SerieCorrelativo struct {
+	RucEmisor         string  `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	Serie             string  `json:"serie" bson:"serie" example:"F001"`
+	UltimoCorrelativo int64   `json:"ultimoCorrelativo" bson:"ultimoCorrelativo" example:"125"`
+	Liberados         []int64 `json:"liberados" bson:"liberados,omitempty"`
+}
+
+// FormatoIDDocumento arma el idDocumento con el correlativo de 8 dígitos que usa SUNAT
+func FormatoIDDocumento(serie string, correlativo int64) string {
+	return fmt.Sprintf("%s-%08d", serie, correlativo)
+}
+
+// SiguienteCorrelativo es el único número nuevo que un cliente puede enviar sin saltar la numeración
+func (s *SerieCorrelativo) SiguienteCorrelativo() int64 {
+	return s.UltimoCorrelativo + 1
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "ms1-documents/internal/catalog"
+
+// Tipos de afectación al IGV según el catálogo 07 de SUNAT
+const (
+	AfectacionGravadoOneroso   = "10"
+	AfectacionExoneradoOneroso = "20"
+	AfectacionInafectoOneroso  = "30"
+)
+
+// Categorías en las que se agrupan los tipos de afectación para los subtotales del documento
+const (
+	CategoriaGravado   = "GRAVADO"
+	CategoriaExonerado = "EXONERADO"
+	CategoriaInafecto  = "INAFECTO"
+)
+
+// CategoriaAfectacion devuelve la categoría del código de afectación y si es una transferencia gratuita
+func CategoriaAfectacion(codigo string) (categoria string, gratuito bool, ok bool) {
+	afectacion, ok := catalog.AfectacionIGV(codigo)
+	return afectacion.Categoria, afectacion.Gratuito, ok
+}
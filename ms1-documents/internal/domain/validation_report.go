@@ -0,0 +1,71 @@
+package domain
+
+import "ms1-documents/pkg/errors"
+
+// Resultados de cada verificación del reporte de validación
+const (
+	ResultadoCumplida   = "CUMPLIDA"
+	ResultadoIncumplida = "INCUMPLIDA"
+	// ResultadoOmitida indica que no se revisó porque falló una verificación de la que depende
+	ResultadoOmitida = "OMITIDA"
+)
+
+// Etapas en que se agrupan las verificaciones del reporte
+const (
+	EtapaEmisor        = "EMISOR"
+	EtapaCatalogo      = "CATALOGO"
+	EtapaFormato       = "FORMATO"
+	EtapaAritmetica    = "ARITMETICA"
+	EtapaReglasNegocio = "REGLAS_NEGOCIO"
+	EtapaReferencia    = "REFERENCIA"
+	EtapaDuplicado     = "DUPLICADO"
+)
+
+// ReporteValidacion es el resultado de revisar un documento con las mismas verificaciones que al crearlo,
+// sin guardarlo ni publicarlo
+type ReporteValidacion struct {
+	Valido bool `json:"valido" example:"false"`
+	// Documento queda como se guardaría, con los datos que se completan desde el emisor, el catálogo y los cálculos
+	Documento      *Document           `json:"documento"`
+	Verificaciones []Verificacion      `json:"verificaciones"`
+	Errores        []errors.FieldError `json:"errores"`
+}
+
+// Verificacion es el resultado de una regla o comprobación del proceso de creación
+type Verificacion struct {
+	Nombre    string              `json:"nombre" example:"aritmetica"`
+	Etapa     string              `json:"etapa" example:"ARITMETICA"`
+	Resultado string              `json:"resultado" example:"INCUMPLIDA"`
+	Errores   []errors.FieldError `json:"errores,omitempty"`
+}
+
+// NuevoReporteValidacion crea un reporte válido hasta que se agregue una verificación incumplida
+func NuevoReporteValidacion(documento *Document) *ReporteValidacion {
+	return &ReporteValidacion{
+		Valido:         true,
+		Documento:      documento,
+		Verificaciones: []Verificacion{},
+		Errores:        []errors.FieldError{},
+	}
+}
+
+// Agregar incorpora verificaciones al reporte; una incumplida lo invalida y suma sus errores
+func (r *ReporteValidacion) Agregar(verificaciones ...Verificacion) {
+	for _, verificacion := range verificaciones {
+		if verificacion.Resultado == ResultadoIncumplida {
+			r.Valido = false
+			r.Errores = append(r.Errores, verificacion.Errores...)
+		}
+		r.Verificaciones = append(r.Verificaciones, verificacion)
+	}
+}
+
+// Cumplida indica si la verificación con ese nombre se revisó sin errores
+func (r *ReporteValidacion) Cumplida(nombre string) bool {
+	for _, verificacion := range r.Verificaciones {
+		if verificacion.Nombre == nombre {
+			return verificacion.Resultado == ResultadoCumplida
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package domain
+
+// PlazoComunicacionBaja es la cantidad de días calendario desde la emisión en que SUNAT acepta dar de baja un comprobante
+const PlazoComunicacionBaja = 7
+
+// ComunicacionBaja (RA-AAAAMMDD-n) agrupa los documentos de un emisor anulados en un mismo día. SUNAT
+// exige que todos compartan la fecha de emisión, por eso hay una por cada fechaReferencia.
+type ComunicacionBaja struct {
+	ID              string     `json:"id" bson:"id" example:"RA-20260213-1"`
+	RucEmisor       string     `json:"rucEmisor" bson:"rucEmisor" example:"20123456786"`
+	FechaGeneracion string     `json:"fechaGeneracion" bson:"fechaGeneracion" example:"2026-02-13"`
+	FechaReferencia string     `json:"fechaReferencia" bson:"fechaReferencia" example:"2026-02-12"`
+	Items           []ItemBaja `json:"items" bson:"items"`
+}
+
+type ItemBaja struct {
+	IDDocumento   string `json:"idDocumento" bson:"idDocumento" example:"F001-00000001"`
+	TipoDocumento string `json:"tipoDocumento" bson:"tipoDocumento" example:"01"`
+	Motivo        string `json:"motivo" bson:"motivo" example:"Error en la descripción"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "ms1-documents/pkg/money"
+
+// Detraccion es el depósito del SPOT que el cliente hace en la cuenta del emisor en el Banco de la Nación.
+// Monto se expresa en soles sin decimales, como se deposita.
+type Detraccion struct {
+	Codigo            string      `json:"codigo" bson:"codigo" example:"037"`
+	Porcentaje        money.Money `json:"porcentaje" bson:"porcentaje" swaggertype:"number" example:"0.12"`
+	Monto             money.Money `json:"monto" bson:"monto" swaggertype:"number" example:"142.00"`
+	CuentaBancoNacion string      `json:"cuentaBancoNacion" bson:"cuentaBancoNacion" example:"00-741-123456"`
+}
+
+// PorcentajesPercepcion son los regímenes de percepción del catálogo 53 con su porcentaje
+var PorcentajesPercepcion = map[string]money.Money{
+	"51": money.MustParse("0.02"),  // Percepción venta interna
+	"52": money.MustParse("0.01"),  // Percepción a la adquisición de combustible
+	"53": money.MustParse("0.005"), // Percepción realizada al agente de percepción con tasa especial
+}
+
+// Percepcion es el importe que el emisor, como agente de percepción, cobra al cliente además del total.
+// Solo se admite en soles; montoTotalCobrado es montoTotal más la percepción.
+type Percepcion struct {
+	Codigo            string      `json:"codigo" bson:"codigo" example:"51"`
+	Porcentaje        money.Money `json:"porcentaje" bson:"porcentaje" swaggertype:"number" example:"0.02"`
+	MontoBase         money.Money `json:"montoBase" bson:"montoBase" swaggertype:"number" example:"1180.00"`
+	Monto             money.Money `json:"monto" bson:"monto" swaggertype:"number" example:"23.60"`
+	MontoTotalCobrado money.Money `json:"montoTotalCobrado" bson:"montoTotalCobrado" swaggertype:"number" example:"1203.60"`
+}
+
+// CodigoRetencion es el código del catálogo 53 con el que se informa la retención del IGV
+const CodigoRetencion = "62"
+
+// PorcentajeRetencion es la tasa vigente del régimen de retenciones del IGV
+var PorcentajeRetencion = money.MustParse("0.03")
+
+// UmbralRetencion es el importe en soles que el comprobante debe superar para que el agente retenga
+var UmbralRetencion = money.MustParse("700.00")
+
+// Retencion es el importe que el cliente, como agente de retención, descuenta del pago al emisor.
+// montoBase y monto se expresan en soles.
+type Retencion struct {
+	Porcentaje money.Money `json:"porcentaje" bson:"porcentaje" swaggertype:"number" example:"0.03"`
+	MontoBase  money.Money `json:"montoBase" bson:"montoBase" swaggertype:"number" example:"1180.00"`
+	Monto      money.Money `json:"monto" bson:"monto" swaggertype:"number" example:"35.40"`
+}
@@ -0,0 +1,227 @@
+package exchange
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const formatoFecha = "2006-01-02"
+
+// DiasMaximosSinPublicacion es cuánto puede retroceder la búsqueda: SUNAT no publica tipo de cambio
+// en fines de semana ni feriados y en esos días rige el último publicado
+const DiasMaximosSinPublicacion = 7
+
+// columnasCSV es la cabecera que debe traer el archivo de tipos de cambio
+var columnasCSV = []string{"fecha", "moneda", "tipoCambio"}
+
+// ProveedorTipoCambio resuelve el tipo de cambio venta a soles publicado por SUNAT para una fecha. Si no
+// hay cotización vigente devuelve un error que cumple errors.Is(err, ErrSinTipoCambio).
+type ProveedorTipoCambio interface {
+	TipoCambio(contexto context.Context, moneda string, fecha time.Time) (money.Money, error)
+}
+
+// ErrSinTipoCambio es la causa de los errores de ErrorSinTipoCambio
+var ErrSinTipoCambio = errors.New("no hay tipo de cambio registrado")
+
+// TipoCambioDiario es el tipo de cambio de una moneda extranjera a soles publicado para una fecha
+type TipoCambioDiario struct {
+	Fecha      string      `json:"fecha" bson:"fecha" example:"2026-02-12"`
+	Moneda     string      `json:"moneda" bson:"moneda" example:"USD"`
+	TipoCambio money.Money `json:"tipoCambio" bson:"tipoCambio" swaggertype:"number" example:"3.712"`
+}
+
+type cotizacion struct {
+	fecha      time.Time
+	tipoCambio money.Money
+}
+
+// TablaTiposCambio guarda en memoria por moneda las cotizaciones ordenadas por fecha. La API usa el
+// repositorio de MongoDB; la tabla tiene los mismos métodos y sirve donde no hay base de datos, como en las pruebas.
+type TablaTiposCambio struct {
+	mu           sync.RWMutex
+	cotizaciones map[string][]cotizacion
+}
+
+func NewTablaTiposCambio(tiposCambio []TipoCambioDiario) (*TablaTiposCambio, error) {
+	tabla := &TablaTiposCambio{cotizaciones: make(map[string][]cotizacion)}
+	if err := tabla.Registrar(context.Background(), tiposCambio); err != nil {
+		return nil, err
+	}
+	return tabla, nil
+}
+
+// LeerArchivoCSV lee un CSV con cabecera fecha,moneda,tipoCambio y valida sus filas
+func LeerArchivoCSV(ruta string) ([]TipoCambioDiario, error) {
+	archivo, err := os.Open(ruta)
+	if err != nil {
+		return nil, err
+	}
+	defer archivo.Close()
+
+	tiposCambio, err := LeerCSV(archivo)
+	if err != nil {
+		return nil, err
+	}
+	return Normalizar(tiposCambio)
+}
+
+// LeerCSV interpreta las filas de un CSV de tipos de cambio sin validarlas; eso lo hace Normalizar
+func LeerCSV(lector io.Reader) ([]TipoCambioDiario, error) {
+	lectorCSV := csv.NewReader(lector)
+	lectorCSV.TrimLeadingSpace = true
+	lectorCSV.FieldsPerRecord = len(columnasCSV)
+
+	cabecera, err := lectorCSV.Read()
+	if err == io.EOF {
+		return nil, errors.New("el CSV de tipos de cambio está vacío")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CSV de tipos de cambio inválido: %w", err)
+	}
+	for indice, columna := range columnasCSV {
+		if !strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(cabecera[indice], "\ufeff")), columna) {
+			return nil, fmt.Errorf("la cabecera del CSV debe ser %s", strings.Join(columnasCSV, ","))
+		}
+	}
+
+	var tiposCambio []TipoCambioDiario
+	for {
+		fila, err := lectorCSV.Read()
+		if err == io.EOF {
+			return tiposCambio, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV de tipos de cambio inválido: %w", err)
+		}
+
+		linea, _ := lectorCSV.FieldPos(0)
+		tipoCambio, err := money.Parse(strings.TrimSpace(fila[2]))
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: tipo de cambio inválido %q", linea, fila[2])
+		}
+		tiposCambio = append(tiposCambio, TipoCambioDiario{
+			Fecha:      strings.TrimSpace(fila[0]),
+			Moneda:     strings.TrimSpace(fila[1]),
+			TipoCambio: tipoCambio,
+		})
+	}
+}
+
+// Normalizar valida las cotizaciones y devuelve la moneda en mayúsculas; falla con la primera inválida
+func Normalizar(tiposCambio []TipoCambioDiario) ([]TipoCambioDiario, error) {
+	normalizados := make([]TipoCambioDiario, 0, len(tiposCambio))
+	for indice, entrada := range tiposCambio {
+		moneda := strings.ToUpper(strings.TrimSpace(entrada.Moneda))
+		if !domain.EsMonedaValida(moneda) || moneda == domain.MonedaPEN {
+			return nil, fmt.Errorf("tipo de cambio %d: moneda %q no admitida, debe ser %s o %s", indice, entrada.Moneda, domain.MonedaUSD, domain.MonedaEUR)
+		}
+
+		if _, err := time.Parse(formatoFecha, entrada.Fecha); err != nil {
+			return nil, fmt.Errorf("tipo de cambio %d: fecha debe tener formato AAAA-MM-DD", indice)
+		}
+
+		if !entrada.TipoCambio.EsPositivo() {
+			return nil, fmt.Errorf("tipo de cambio %d: el tipo de cambio debe ser mayor a cero", indice)
+		}
+
+		normalizados = append(normalizados, TipoCambioDiario{Fecha: entrada.Fecha, Moneda: moneda, TipoCambio: entrada.TipoCambio})
+	}
+	return normalizados, nil
+}
+
+// VentanaVigencia devuelve, en el formato AAAA-MM-DD con que se guardan las cotizaciones, el primer día
+// cuya publicación todavía rige en la fecha y el día de la fecha
+func VentanaVigencia(fecha time.Time) (desde, hasta string) {
+	dia := time.Date(fecha.Year(), fecha.Month(), fecha.Day(), 0, 0, 0, 0, time.UTC)
+	return dia.AddDate(0, 0, -DiasMaximosSinPublicacion).Format(formatoFecha), dia.Format(formatoFecha)
+}
+
+// ErrorSinTipoCambio indica que no hay cotización vigente para la moneda en el día indicado
+func ErrorSinTipoCambio(moneda, dia string) error {
+	return fmt.Errorf("%w para %s al %s", ErrSinTipoCambio, moneda, dia)
+}
+
+// Registrar agrega o reemplaza cotizaciones; si alguna es inválida no se aplica ninguna
+func (t *TablaTiposCambio) Registrar(contexto context.Context, tiposCambio []TipoCambioDiario) error {
+	normalizados, err := Normalizar(tiposCambio)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, entrada := range normalizados {
+		fecha, _ := time.Parse(formatoFecha, entrada.Fecha)
+		t.cotizaciones[entrada.Moneda] = reemplazarCotizacion(t.cotizaciones[entrada.Moneda], cotizacion{fecha, entrada.TipoCambio})
+	}
+	return nil
+}
+
+// reemplazarCotizacion inserta la cotización manteniendo el orden por fecha; una fecha repetida se sobrescribe
+func reemplazarCotizacion(cotizaciones []cotizacion, nueva cotizacion) []cotizacion {
+	posicion := sort.Search(len(cotizaciones), func(i int) bool { return !cotizaciones[i].fecha.Before(nueva.fecha) })
+	if posicion < len(cotizaciones) && cotizaciones[posicion].fecha.Equal(nueva.fecha) {
+		cotizaciones[posicion] = nueva
+		return cotizaciones
+	}
+	cotizaciones = append(cotizaciones, cotizacion{})
+	copy(cotizaciones[posicion+1:], cotizaciones[posicion:])
+	cotizaciones[posicion] = nueva
+	return cotizaciones
+}
+
+// TipoCambio devuelve la cotización publicada para el día de la fecha o, si ese día no hubo
+// publicación, la última de los DiasMaximosSinPublicacion anteriores
+func (t *TablaTiposCambio) TipoCambio(contexto context.Context, moneda string, fecha time.Time) (money.Money, error) {
+	dia := time.Date(fecha.Year(), fecha.Month(), fecha.Day(), 0, 0, 0, 0, time.UTC)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	cotizaciones := t.cotizaciones[moneda]
+	posicion := sort.Search(len(cotizaciones), func(i int) bool { return cotizaciones[i].fecha.After(dia) })
+	if posicion > 0 {
+		anterior := cotizaciones[posicion-1]
+		if dia.Sub(anterior.fecha) <= DiasMaximosSinPublicacion*24*time.Hour {
+			return anterior.tipoCambio, nil
+		}
+	}
+
+	return money.Money{}, ErrorSinTipoCambio(moneda, dia.Format(formatoFecha))
+}
+
+// Listar devuelve las cotizaciones ordenadas por moneda y fecha; con moneda vacía incluye todas
+func (t *TablaTiposCambio) Listar(contexto context.Context, moneda string) ([]TipoCambioDiario, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	monedas := make([]string, 0, len(t.cotizaciones))
+	for codigo := range t.cotizaciones {
+		if moneda == "" || codigo == moneda {
+			monedas = append(monedas, codigo)
+		}
+	}
+	sort.Strings(monedas)
+
+	resultado := []TipoCambioDiario{}
+	for _, codigo := range monedas {
+		for _, cotizacion := range t.cotizaciones[codigo] {
+			resultado = append(resultado, TipoCambioDiario{
+				Fecha:      cotizacion.fecha.Format(formatoFecha),
+				Moneda:     codigo,
+				TipoCambio: cotizacion.tipoCambio,
+			})
+		}
+	}
+	return resultado, nil
+}
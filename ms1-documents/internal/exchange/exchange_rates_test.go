@@ -0,0 +1,198 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"ms1-documents/pkg/money"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fecha(texto string) time.Time {
+	valor, _ := time.Parse(time.RFC3339, texto)
+	return valor
+}
+
+func TestTablaTiposCambio_TipoCambio(t *testing.T) {
+	testCases := []struct {
+		name     string
+		moneda   string
+		fecha    string
+		esperado string
+	}{
+		{"Publicado el mismo día", "USD", "2026-02-12T10:00:00Z", "3.712"},
+		{"Publicaciones desordenadas", "USD", "2026-02-13T23:00:00Z", "3.715"},
+		{"Fin de semana usa el último publicado", "USD", "2026-02-15T10:00:00Z", "3.715"},
+		{"Otra moneda", "EUR", "2026-02-19T10:00:00Z", "4.021"},
+	}
+
+	tabla, err := NewTablaTiposCambio([]TipoCambioDiario{
+		{Fecha: "2026-02-13", Moneda: "USD", TipoCambio: money.MustParse("3.715")},
+		{Fecha: "2026-02-12", Moneda: "usd", TipoCambio: money.MustParse("3.712")},
+		{Fecha: "2026-02-12", Moneda: "EUR", TipoCambio: money.MustParse("4.021")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tipoCambio, err := tabla.TipoCambio(context.Background(), tc.moneda, fecha(tc.fecha))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !tipoCambio.Igual(money.MustParse(tc.esperado)) {
+				t.Errorf("Expected %s, got %s", tc.esperado, tipoCambio)
+			}
+		})
+	}
+}
+
+func TestTablaTiposCambio_SinCotizacion(t *testing.T) {
+	testCases := []struct {
+		name   string
+		moneda string
+		fecha  string
+	}{
+		{"Antes de la primera publicación", "USD", "2026-02-11T10:00:00Z"},
+		{"Última publicación demasiado antigua", "USD", "2026-02-21T10:00:00Z"},
+		{"Moneda sin cotizaciones", "GBP", "2026-02-12T10:00:00Z"},
+	}
+
+	tabla, err := NewTablaTiposCambio([]TipoCambioDiario{
+		{Fecha: "2026-02-13", Moneda: "USD", TipoCambio: money.MustParse("3.715")},
+		{Fecha: "2026-02-12", Moneda: "usd", TipoCambio: money.MustParse("3.712")},
+		{Fecha: "2026-02-12", Moneda: "EUR", TipoCambio: money.MustParse("4.021")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tabla.TipoCambio(context.Background(), tc.moneda, fecha(tc.fecha)); !errors.Is(err, ErrSinTipoCambio) {
+				t.Errorf("Expected %v, got: %v", ErrSinTipoCambio, err)
+			}
+		})
+	}
+}
+
+func TestTablaTiposCambio_Registrar(t *testing.T) {
+	tabla, err := NewTablaTiposCambio([]TipoCambioDiario{
+		{Fecha: "2026-02-13", Moneda: "USD", TipoCambio: money.MustParse("3.715")},
+		{Fecha: "2026-02-12", Moneda: "usd", TipoCambio: money.MustParse("3.712")},
+		{Fecha: "2026-02-12", Moneda: "EUR", TipoCambio: money.MustParse("4.021")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	err = tabla.Registrar(context.Background(), []TipoCambioDiario{
+		{Fecha: "2026-02-12", Moneda: "USD", TipoCambio: money.MustParse("3.700")},
+		{Fecha: "2026-02-16", Moneda: "USD", TipoCambio: money.MustParse("3.720")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	listado, _ := tabla.Listar(context.Background(), "USD")
+	var textos []string
+	for _, tipoCambio := range listado {
+		textos = append(textos, tipoCambio.Fecha+"="+tipoCambio.TipoCambio.String())
+	}
+	esperado := "2026-02-12=3.70,2026-02-13=3.715,2026-02-16=3.72"
+	if strings.Join(textos, ",") != esperado {
+		t.Errorf("Expected %s, got %s", esperado, strings.Join(textos, ","))
+	}
+	if todos, _ := tabla.Listar(context.Background(), ""); len(todos) != 4 {
+		t.Errorf("Expected 4 rates, got %d", len(todos))
+	}
+}
+
+func TestTablaTiposCambio_RegistrarInvalido(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tipoCambio TipoCambioDiario
+	}{
+		{"Moneda no admitida", TipoCambioDiario{Fecha: "2026-02-12", Moneda: "GBP", TipoCambio: money.MustParse("4.5")}},
+		{"Soles", TipoCambioDiario{Fecha: "2026-02-12", Moneda: "PEN", TipoCambio: money.MustParse("1")}},
+		{"Fecha inválida", TipoCambioDiario{Fecha: "12/02/2026", Moneda: "USD", TipoCambio: money.MustParse("3.712")}},
+		{"Tipo de cambio en cero", TipoCambioDiario{Fecha: "2026-02-12", Moneda: "USD", TipoCambio: money.Cero()}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tabla, err := NewTablaTiposCambio([]TipoCambioDiario{
+				{Fecha: "2026-02-13", Moneda: "USD", TipoCambio: money.MustParse("3.715")},
+				{Fecha: "2026-02-12", Moneda: "usd", TipoCambio: money.MustParse("3.712")},
+				{Fecha: "2026-02-12", Moneda: "EUR", TipoCambio: money.MustParse("4.021")},
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			valida := TipoCambioDiario{Fecha: "2026-02-17", Moneda: "USD", TipoCambio: money.MustParse("3.8")}
+			if err := tabla.Registrar(context.Background(), []TipoCambioDiario{valida, tc.tipoCambio}); err == nil {
+				t.Fatal("Expected error")
+			}
+			if listado, _ := tabla.Listar(context.Background(), "USD"); len(listado) != 2 {
+				t.Errorf("Expected the batch to be rejected entirely, got %d USD rates", len(listado))
+			}
+		})
+	}
+}
+
+func TestLeerCSV(t *testing.T) {
+	tiposCambio, err := LeerCSV(strings.NewReader("fecha,moneda,tipoCambio\n2026-02-12, USD, 3.712\n2026-02-12,EUR,4.021\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(tiposCambio) != 2 {
+		t.Fatalf("Expected 2 rates, got %d", len(tiposCambio))
+	}
+	if tiposCambio[0].Moneda != "USD" || !tiposCambio[0].TipoCambio.Igual(money.MustParse("3.712")) {
+		t.Errorf("Expected USD 3.712, got %s %s", tiposCambio[0].Moneda, tiposCambio[0].TipoCambio)
+	}
+}
+
+func TestLeerCSV_Invalido(t *testing.T) {
+	testCases := []struct {
+		name      string
+		contenido string
+	}{
+		{"Vacío", ""},
+		{"Cabecera distinta", "dia,moneda,tc\n2026-02-12,USD,3.712\n"},
+		{"Columnas faltantes", "fecha,moneda,tipoCambio\n2026-02-12,USD\n"},
+		{"Importe inválido", "fecha,moneda,tipoCambio\n2026-02-12,USD,tres\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LeerCSV(strings.NewReader(tc.contenido)); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestLeerArchivoCSV(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "tipos_cambio.csv")
+	if err := os.WriteFile(ruta, []byte("fecha,moneda,tipoCambio\n2026-02-12,usd,3.712\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tiposCambio, err := LeerArchivoCSV(ruta)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(tiposCambio) != 1 || tiposCambio[0].Moneda != "USD" || !tiposCambio[0].TipoCambio.Igual(money.MustParse("3.712")) {
+		t.Errorf("Expected USD 3.712, got %+v", tiposCambio)
+	}
+}
+
+func TestVentanaVigencia(t *testing.T) {
+	desde, hasta := VentanaVigencia(fecha("2026-02-12T22:30:00Z"))
+	if desde != "2026-02-05" || hasta != "2026-02-12" {
+		t.Errorf("Expected 2026-02-05 to 2026-02-12, got %s to %s", desde, hasta)
+	}
+}
@@ -0,0 +1,177 @@
+// Package expression evalúa las expresiones de las reglas de negocio configurables sobre los campos JSON
+// de un struct. Admite literales de texto ("..."), números, true, false y listas ([...]), los operadores
+// ==, !=, <, <=, >, >=, contiene, en, !, && y ||, y paréntesis. Los campos de structs anidados se nombran
+// con punto (receptor.nombre). Los tipos se comprueban al compilar, por lo que una expresión compilada
+// siempre se puede evaluar.
+package expression
+
+import (
+	"fmt"
+	"ms1-documents/pkg/money"
+	"reflect"
+	"strings"
+)
+
+// Tipo es el tipo de un valor de la expresión
+type Tipo int
+
+const (
+	TipoTexto Tipo = iota
+	TipoNumero
+	TipoLogico
+	TipoListaTexto
+	TipoListaNumero
+)
+
+func (t Tipo) String() string {
+	switch t {
+	case TipoTexto:
+		return "texto"
+	case TipoNumero:
+		return "número"
+	case TipoLogico:
+		return "lógico"
+	case TipoListaTexto:
+		return "lista de textos"
+	default:
+		return "lista de números"
+	}
+}
+
+var tipoMoney = reflect.TypeOf(money.Money{})
+
+// ErrorSintaxis indica la posición (1 = primer carácter) en que la expresión no se pudo compilar
+type ErrorSintaxis struct {
+	Posicion int
+	Mensaje  string
+}
+
+func (e *ErrorSintaxis) Error() string {
+	return fmt.Sprintf("posición %d: %s", e.Posicion, e.Mensaje)
+}
+
+// Expresion es una condición compilada para un tipo de struct
+type Expresion struct {
+	texto string
+	tipo  reflect.Type
+	raiz  nodo
+}
+
+// Compilar interpreta el texto como una condición sobre los campos del struct tipo. Los campos
+// desconocidos o de tipos que no se pueden comparar son errores de compilación.
+func Compilar(texto string, tipo reflect.Type) (*Expresion, error) {
+	if strings.TrimSpace(texto) == "" {
+		return nil, &ErrorSintaxis{Posicion: 1, Mensaje: "la expresión está vacía"}
+	}
+
+	analizador, err := nuevoAnalizador(texto, tipo)
+	if err != nil {
+		return nil, err
+	}
+	raiz, err := analizador.expresion()
+	if err != nil {
+		return nil, err
+	}
+	if actual := analizador.actual(); actual.clase != simboloFin {
+		return nil, &ErrorSintaxis{Posicion: actual.posicion, Mensaje: fmt.Sprintf("se esperaba el fin de la expresión, se encontró %q", actual.texto)}
+	}
+	if raiz.tipo() != TipoLogico {
+		return nil, &ErrorSintaxis{Posicion: 1, Mensaje: fmt.Sprintf("la expresión debe ser verdadera o falsa, es de tipo %s", raiz.tipo())}
+	}
+
+	return &Expresion{texto: texto, tipo: tipo, raiz: raiz}, nil
+}
+
+// Evaluar aplica la expresión a un valor (o puntero a un valor) del tipo con que se compiló
+func (e *Expresion) Evaluar(objeto any) bool {
+	valorObjeto := reflect.Indirect(reflect.ValueOf(objeto))
+	if valorObjeto.Type() != e.tipo {
+		panic(fmt.Sprintf("expresión compilada para %s evaluada con %s", e.tipo, valorObjeto.Type()))
+	}
+	return e.raiz.evaluar(valorObjeto).logico
+}
+
+func (e *Expresion) String() string {
+	return e.texto
+}
+
+// ExisteCampo indica si el nombre (con punto para structs anidados) es un campo que las expresiones
+// pueden usar en el tipo
+func ExisteCampo(tipo reflect.Type, nombre string) bool {
+	_, _, err := resolverCampo(tipo, nombre)
+	return err == nil
+}
+
+// valor es el resultado de evaluar un nodo; solo el campo de su tipo tiene sentido
+type valor struct {
+	texto  string
+	numero money.Money
+	logico bool
+	lista  []valor
+}
+
+// resolverCampo busca el campo por su nombre JSON y devuelve los índices para llegar a él
+func resolverCampo(tipo reflect.Type, nombre string) ([]int, Tipo, error) {
+	var indices []int
+	partes := strings.Split(nombre, ".")
+	for posicion, parte := range partes {
+		if tipo.Kind() == reflect.Ptr {
+			tipo = tipo.Elem()
+		}
+		if tipo.Kind() != reflect.Struct {
+			return nil, 0, fmt.Errorf("%s no tiene campos", strings.Join(partes[:posicion], "."))
+		}
+
+		campo, ok := campoPorNombreJSON(tipo, parte)
+		if !ok {
+			return nil, 0, fmt.Errorf("campo desconocido %s", nombre)
+		}
+		indices = append(indices, campo.Index...)
+		tipo = campo.Type
+	}
+
+	switch {
+	case tipo == tipoMoney:
+		return indices, TipoNumero, nil
+	case tipo.Kind() == reflect.String:
+		return indices, TipoTexto, nil
+	case tipo.Kind() == reflect.Bool:
+		return indices, TipoLogico, nil
+	default:
+		return nil, 0, fmt.Errorf("el campo %s no se puede usar en expresiones", nombre)
+	}
+}
+
+func campoPorNombreJSON(tipo reflect.Type, nombre string) (reflect.StructField, bool) {
+	for indice := 0; indice < tipo.NumField(); indice++ {
+		campo := tipo.Field(indice)
+		nombreJSON, _, _ := strings.Cut(campo.Tag.Get("json"), ",")
+		if nombreJSON == nombre && campo.IsExported() {
+			return campo, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// leerCampo recorre los índices; un struct anidado ausente (puntero nil) se lee como valor cero
+func leerCampo(objeto reflect.Value, indices []int, tipo Tipo) valor {
+	actual := objeto
+	for _, indice := range indices {
+		if actual.Kind() == reflect.Ptr {
+			if actual.IsNil() {
+				return valor{}
+			}
+			actual = actual.Elem()
+		}
+		actual = actual.Field(indice)
+	}
+
+	switch tipo {
+	case TipoNumero:
+		return valor{numero: actual.Interface().(money.Money)}
+	case TipoLogico:
+		return valor{logico: actual.Bool()}
+	default:
+		return valor{texto: actual.String()}
+	}
+}
@@ -0,0 +1,131 @@
+package expression
+
+import (
+	"ms1-documents/pkg/money"
+	"reflect"
+	"testing"
+)
+
+type cliente struct {
+	Nombre string `json:"nombre"`
+}
+
+type pedido struct {
+	Numero   string      `json:"numero"`
+	Total    money.Money `json:"total"`
+	Urgente  bool        `json:"urgente,omitempty"`
+	Cliente  *cliente    `json:"cliente,omitempty"`
+	Lineas   []string    `json:"lineas"`
+	interno  string
+	Ignorado string `json:"-"`
+}
+
+var tipoPedido = reflect.TypeOf(pedido{})
+
+func TestEvaluar(t *testing.T) {
+	objeto := &pedido{
+		Numero:  "OC-123",
+		Total:   money.MustParse("1500.50"),
+		Urgente: true,
+		Cliente: &cliente{Nombre: "Licorería El Trébol"},
+	}
+
+	testCases := []struct {
+		expresion string
+		esperado  bool
+	}{
+		{"total <= 2000", true},
+		{"total > 1500.50", false},
+		{"total >= -1", true},
+		{"total == 1500.5", true},
+		{`numero == "OC-123"`, true},
+		{`numero != ""`, true},
+		{"urgente", true},
+		{"urgente == false", false},
+		{`!(numero en ["OC-1", "OC-2"])`, true},
+		{"total en [100, 1500.50]", true},
+		{`cliente.nombre contiene "LICOR"`, true},
+		{`cliente.nombre contiene ["tabaco", "cerveza"]`, false},
+		{`total > 1000 && numero == "" || urgente`, true},
+		{`total > 1000 && (numero == "" || !urgente)`, false},
+		{`numero == "con \"comillas\""`, false},
+	}
+
+	for _, tc := range testCases {
+		expresion, err := Compilar(tc.expresion, tipoPedido)
+		if err != nil {
+			t.Errorf("Expected %q to compile, got: %v", tc.expresion, err)
+			continue
+		}
+		if resultado := expresion.Evaluar(objeto); resultado != tc.esperado {
+			t.Errorf("Expected %q to be %v, got %v", tc.expresion, tc.esperado, resultado)
+		}
+	}
+}
+
+func TestEvaluar_StructAnidadoAusente(t *testing.T) {
+	expresion, err := Compilar(`cliente.nombre == ""`, tipoPedido)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !expresion.Evaluar(pedido{}) {
+		t.Error("Expected a nil nested struct to read as empty")
+	}
+}
+
+func TestCompilar_Errores(t *testing.T) {
+	testCases := []struct {
+		expresion string
+		posicion  int
+	}{
+		{"", 1},
+		{"total <= ", 10},
+		{"total = 10", 7},
+		{"importe > 10", 1},
+		{"lineas == \"x\"", 1},
+		{"interno == \"x\"", 1},
+		{"numero > 10", 8},
+		{"total contiene \"1\"", 7},
+		{"numero en [1, 2]", 8},
+		{"total", 1},
+		{"[\"a\", 1]", 1},
+		{"(total > 1", 11},
+		{"total > 1 urgente", 11},
+		{"numero == \"abierto", 11},
+		{"total > 1 && numero", 11},
+		{"total > 1 # 2", 11},
+	}
+
+	for _, tc := range testCases {
+		_, err := Compilar(tc.expresion, tipoPedido)
+		errorSintaxis, ok := err.(*ErrorSintaxis)
+		if !ok {
+			t.Errorf("Expected syntax error for %q, got: %v", tc.expresion, err)
+			continue
+		}
+		if errorSintaxis.Posicion != tc.posicion {
+			t.Errorf("Expected position %d for %q, got %d (%s)", tc.posicion, tc.expresion, errorSintaxis.Posicion, errorSintaxis.Mensaje)
+		}
+	}
+}
+
+func TestExisteCampo(t *testing.T) {
+	testCases := []struct {
+		nombre   string
+		esperado bool
+	}{
+		{"total", true},
+		{"cliente.nombre", true},
+		{"cliente", false},
+		{"lineas", false},
+		{"numero.valor", false},
+		{"Ignorado", false},
+	}
+
+	for _, tc := range testCases {
+		if existe := ExisteCampo(tipoPedido, tc.nombre); existe != tc.esperado {
+			t.Errorf("Expected ExisteCampo(%q) to be %v, got %v", tc.nombre, tc.esperado, existe)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package expression
+
+import (
+	"fmt"
+	"ms1-documents/pkg/money"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type claseSimbolo int
+
+const (
+	simboloFin claseSimbolo = iota
+	simboloNumero
+	simboloTexto
+	simboloIdentificador
+	simboloOperador
+)
+
+type simbolo struct {
+	clase    claseSimbolo
+	texto    string
+	posicion int
+}
+
+// operadoresDobles se buscan antes que los de un carácter para que <= no se lea como < seguido de =
+var operadoresDobles = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+const operadoresSimples = "<>!()[],"
+
+// palabrasOperador son los operadores que se escriben como palabras
+var palabrasOperador = map[string]bool{"contiene": true, "en": true, "true": true, "false": true}
+
+func separarSimbolos(texto string) ([]simbolo, error) {
+	var simbolos []simbolo
+	runas := []rune(texto)
+	for posicion := 0; posicion < len(runas); {
+		caracter := runas[posicion]
+		inicio := posicion + 1
+
+		switch {
+		case unicode.IsSpace(caracter):
+			posicion++
+
+		case unicode.IsDigit(caracter) || (caracter == '-' && posicion+1 < len(runas) && unicode.IsDigit(runas[posicion+1])):
+			fin := posicion + 1
+			for fin < len(runas) && (unicode.IsDigit(runas[fin]) || runas[fin] == '.') {
+				fin++
+			}
+			simbolos = append(simbolos, simbolo{simboloNumero, string(runas[posicion:fin]), inicio})
+			posicion = fin
+
+		case caracter == '"':
+			fin := posicion + 1
+			for fin < len(runas) && runas[fin] != '"' {
+				if runas[fin] == '\\' {
+					fin++
+				}
+				fin++
+			}
+			if fin >= len(runas) {
+				return nil, &ErrorSintaxis{Posicion: inicio, Mensaje: "texto sin comillas de cierre"}
+			}
+			contenido, err := strconv.Unquote(string(runas[posicion : fin+1]))
+			if err != nil {
+				return nil, &ErrorSintaxis{Posicion: inicio, Mensaje: "texto con un escape inválido"}
+			}
+			simbolos = append(simbolos, simbolo{simboloTexto, contenido, inicio})
+			posicion = fin + 1
+
+		case unicode.IsLetter(caracter):
+			fin := posicion + 1
+			for fin < len(runas) && (unicode.IsLetter(runas[fin]) || unicode.IsDigit(runas[fin]) || runas[fin] == '.' || runas[fin] == '_') {
+				fin++
+			}
+			palabra := string(runas[posicion:fin])
+			clase := simboloIdentificador
+			if palabrasOperador[palabra] {
+				clase = simboloOperador
+			}
+			simbolos = append(simbolos, simbolo{clase, palabra, inicio})
+			posicion = fin
+
+		default:
+			operador := ""
+			for _, doble := range operadoresDobles {
+				if strings.HasPrefix(string(runas[posicion:]), doble) {
+					operador = doble
+					break
+				}
+			}
+			if operador == "" && strings.ContainsRune(operadoresSimples, caracter) {
+				operador = string(caracter)
+			}
+			if operador == "" {
+				return nil, &ErrorSintaxis{Posicion: inicio, Mensaje: fmt.Sprintf("carácter inesperado %q", caracter)}
+			}
+			simbolos = append(simbolos, simbolo{simboloOperador, operador, inicio})
+			posicion += len([]rune(operador))
+		}
+	}
+	return append(simbolos, simbolo{simboloFin, "", len(runas) + 1}), nil
+}
+
+// analizador es un parser descendente recursivo con la precedencia, de menor a mayor:
+// ||, &&, !, comparaciones y operandos
+type analizador struct {
+	simbolos []simbolo
+	posicion int
+	tipo     reflect.Type
+}
+
+func nuevoAnalizador(texto string, tipo reflect.Type) (*analizador, error) {
+	simbolos, err := separarSimbolos(texto)
+	if err != nil {
+		return nil, err
+	}
+	return &analizador{simbolos: simbolos, tipo: tipo}, nil
+}
+
+func (a *analizador) actual() simbolo {
+	return a.simbolos[a.posicion]
+}
+
+func (a *analizador) aceptar(operador string) bool {
+	if actual := a.actual(); actual.clase == simboloOperador && actual.texto == operador {
+		a.posicion++
+		return true
+	}
+	return false
+}
+
+func (a *analizador) esperar(operador string) error {
+	if !a.aceptar(operador) {
+		return a.inesperado(fmt.Sprintf("se esperaba %q", operador))
+	}
+	return nil
+}
+
+func (a *analizador) inesperado(mensaje string) error {
+	actual := a.actual()
+	if actual.clase == simboloFin {
+		return &ErrorSintaxis{Posicion: actual.posicion, Mensaje: mensaje + ", la expresión terminó antes"}
+	}
+	return &ErrorSintaxis{Posicion: actual.posicion, Mensaje: fmt.Sprintf("%s, se encontró %q", mensaje, actual.texto)}
+}
+
+func (a *analizador) expresion() (nodo, error) {
+	return a.logico("||", a.conjuncion)
+}
+
+func (a *analizador) conjuncion() (nodo, error) {
+	return a.logico("&&", a.negacion)
+}
+
+func (a *analizador) logico(operador string, siguiente func() (nodo, error)) (nodo, error) {
+	izquierdo, err := siguiente()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		posicion := a.actual().posicion
+		if !a.aceptar(operador) {
+			return izquierdo, nil
+		}
+		derecho, err := siguiente()
+		if err != nil {
+			return nil, err
+		}
+		if izquierdo.tipo() != TipoLogico || derecho.tipo() != TipoLogico {
+			return nil, &ErrorSintaxis{Posicion: posicion, Mensaje: fmt.Sprintf("%s solo une condiciones verdaderas o falsas", operador)}
+		}
+		izquierdo = &nodoLogico{operador: operador, izquierdo: izquierdo, derecho: derecho}
+	}
+}
+
+func (a *analizador) negacion() (nodo, error) {
+	posicion := a.actual().posicion
+	if !a.aceptar("!") {
+		return a.comparacion()
+	}
+	operando, err := a.negacion()
+	if err != nil {
+		return nil, err
+	}
+	if operando.tipo() != TipoLogico {
+		return nil, &ErrorSintaxis{Posicion: posicion, Mensaje: "! solo niega condiciones verdaderas o falsas"}
+	}
+	return &nodoNegacion{operando: operando}, nil
+}
+
+func (a *analizador) comparacion() (nodo, error) {
+	izquierdo, err := a.operando()
+	if err != nil {
+		return nil, err
+	}
+
+	actual := a.actual()
+	switch actual.texto {
+	case "==", "!=", "<", "<=", ">", ">=", "contiene", "en":
+		if actual.clase != simboloOperador {
+			return izquierdo, nil
+		}
+	default:
+		return izquierdo, nil
+	}
+	a.posicion++
+
+	derecho, err := a.operando()
+	if err != nil {
+		return nil, err
+	}
+	if err := comprobarTipos(actual.texto, izquierdo.tipo(), derecho.tipo()); err != nil {
+		return nil, &ErrorSintaxis{Posicion: actual.posicion, Mensaje: err.Error()}
+	}
+	return &nodoComparacion{operador: actual.texto, izquierdo: izquierdo, derecho: derecho}, nil
+}
+
+func comprobarTipos(operador string, izquierdo, derecho Tipo) error {
+	switch operador {
+	case "==", "!=":
+		if izquierdo != derecho || izquierdo == TipoListaTexto || izquierdo == TipoListaNumero {
+			return fmt.Errorf("%s compara valores del mismo tipo, se recibió %s y %s", operador, izquierdo, derecho)
+		}
+	case "<", "<=", ">", ">=":
+		if izquierdo != TipoNumero || derecho != TipoNumero {
+			return fmt.Errorf("%s compara números, se recibió %s y %s", operador, izquierdo, derecho)
+		}
+	case "contiene":
+		if izquierdo != TipoTexto || (derecho != TipoTexto && derecho != TipoListaTexto) {
+			return fmt.Errorf("contiene busca un texto o una lista de textos dentro de un texto, se recibió %s y %s", izquierdo, derecho)
+		}
+	case "en":
+		if !(izquierdo == TipoTexto && derecho == TipoListaTexto) && !(izquierdo == TipoNumero && derecho == TipoListaNumero) {
+			return fmt.Errorf("en busca un texto o número en una lista del mismo tipo, se recibió %s y %s", izquierdo, derecho)
+		}
+	}
+	return nil
+}
+
+func (a *analizador) operando() (nodo, error) {
+	actual := a.actual()
+	switch {
+	case actual.clase == simboloOperador && actual.texto == "(":
+		a.posicion++
+		interior, err := a.expresion()
+		if err != nil {
+			return nil, err
+		}
+		return interior, a.esperar(")")
+
+	case actual.clase == simboloOperador && actual.texto == "[":
+		return a.lista()
+
+	case actual.clase == simboloOperador && (actual.texto == "true" || actual.texto == "false"):
+		a.posicion++
+		return &nodoLiteral{tipoValor: TipoLogico, valor: valor{logico: actual.texto == "true"}}, nil
+
+	case actual.clase == simboloIdentificador:
+		a.posicion++
+		indices, tipo, err := resolverCampo(a.tipo, actual.texto)
+		if err != nil {
+			return nil, &ErrorSintaxis{Posicion: actual.posicion, Mensaje: err.Error()}
+		}
+		return &nodoCampo{indices: indices, tipoValor: tipo}, nil
+	}
+
+	literal, err := a.literal()
+	if err != nil {
+		return nil, err
+	}
+	return literal, nil
+}
+
+// literal lee un texto o un número, los únicos valores que pueden ir en una lista
+func (a *analizador) literal() (*nodoLiteral, error) {
+	actual := a.actual()
+	switch actual.clase {
+	case simboloTexto:
+		a.posicion++
+		return &nodoLiteral{tipoValor: TipoTexto, valor: valor{texto: actual.texto}}, nil
+	case simboloNumero:
+		numero, err := money.Parse(actual.texto)
+		if err != nil {
+			return nil, &ErrorSintaxis{Posicion: actual.posicion, Mensaje: fmt.Sprintf("número inválido %q", actual.texto)}
+		}
+		a.posicion++
+		return &nodoLiteral{tipoValor: TipoNumero, valor: valor{numero: numero}}, nil
+	}
+	return nil, a.inesperado("se esperaba un campo, un texto, un número, true, false, una lista o (")
+}
+
+func (a *analizador) lista() (nodo, error) {
+	inicio := a.actual().posicion
+	a.posicion++
+
+	var elementos []valor
+	var tipoElementos Tipo
+	for len(elementos) == 0 || a.aceptar(",") {
+		elemento, err := a.literal()
+		if err != nil {
+			return nil, err
+		}
+		if len(elementos) > 0 && elemento.tipoValor != tipoElementos {
+			return nil, &ErrorSintaxis{Posicion: inicio, Mensaje: "la lista mezcla textos y números"}
+		}
+		tipoElementos = elemento.tipoValor
+		elementos = append(elementos, elemento.valor)
+	}
+	if err := a.esperar("]"); err != nil {
+		return nil, err
+	}
+
+	tipoLista := TipoListaTexto
+	if tipoElementos == TipoNumero {
+		tipoLista = TipoListaNumero
+	}
+	return &nodoLiteral{tipoValor: tipoLista, valor: valor{lista: elementos}}, nil
+}
+
+type nodo interface {
+	tipo() Tipo
+	evaluar(objeto reflect.Value) valor
+}
+
+type nodoLiteral struct {
+	tipoValor Tipo
+	valor     valor
+}
+
+func (n *nodoLiteral) tipo() Tipo                  { return n.tipoValor }
+func (n *nodoLiteral) evaluar(reflect.Value) valor { return n.valor }
+
+type nodoCampo struct {
+	indices   []int
+	tipoValor Tipo
+}
+
+func (n *nodoCampo) tipo() Tipo { return n.tipoValor }
+func (n *nodoCampo) evaluar(objeto reflect.Value) valor {
+	return leerCampo(objeto, n.indices, n.tipoValor)
+}
+
+type nodoNegacion struct {
+	operando nodo
+}
+
+func (n *nodoNegacion) tipo() Tipo { return TipoLogico }
+func (n *nodoNegacion) evaluar(objeto reflect.Value) valor {
+	return valor{logico: !n.operando.evaluar(objeto).logico}
+}
+
+type nodoLogico struct {
+	operador  string
+	izquierdo nodo
+	derecho   nodo
+}
+
+func (n *nodoLogico) tipo() Tipo { return TipoLogico }
+func (n *nodoLogico) evaluar(objeto reflect.Value) valor {
+	izquierdo := n.izquierdo.evaluar(objeto).logico
+	if n.operador == "&&" {
+		return valor{logico: izquierdo && n.derecho.evaluar(objeto).logico}
+	}
+	return valor{logico: izquierdo || n.derecho.evaluar(objeto).logico}
+}
+
+type nodoComparacion struct {
+	operador  string
+	izquierdo nodo
+	derecho   nodo
+}
+
+func (n *nodoComparacion) tipo() Tipo { return TipoLogico }
+func (n *nodoComparacion) evaluar(objeto reflect.Value) valor {
+	izquierdo := n.izquierdo.evaluar(objeto)
+	derecho := n.derecho.evaluar(objeto)
+	tipo := n.izquierdo.tipo()
+
+	switch n.operador {
+	case "==":
+		return valor{logico: iguales(tipo, izquierdo, derecho)}
+	case "!=":
+		return valor{logico: !iguales(tipo, izquierdo, derecho)}
+	case "<":
+		return valor{logico: izquierdo.numero.Comparar(derecho.numero) < 0}
+	case "<=":
+		return valor{logico: izquierdo.numero.Comparar(derecho.numero) <= 0}
+	case ">":
+		return valor{logico: izquierdo.numero.Comparar(derecho.numero) > 0}
+	case ">=":
+		return valor{logico: izquierdo.numero.Comparar(derecho.numero) >= 0}
+	case "contiene":
+		buscados := derecho.lista
+		if n.derecho.tipo() == TipoTexto {
+			buscados = []valor{derecho}
+		}
+		texto := strings.ToLower(izquierdo.texto)
+		for _, buscado := range buscados {
+			if strings.Contains(texto, strings.ToLower(buscado.texto)) {
+				return valor{logico: true}
+			}
+		}
+		return valor{}
+	default:
+		for _, elemento := range derecho.lista {
+			if iguales(tipo, izquierdo, elemento) {
+				return valor{logico: true}
+			}
+		}
+		return valor{}
+	}
+}
+
+func iguales(tipo Tipo, izquierdo, derecho valor) bool {
+	switch tipo {
+	case TipoNumero:
+		return izquierdo.numero.Igual(derecho.numero)
+	case TipoLogico:
+		return izquierdo.logico == derecho.logico
+	default:
+		return izquierdo.texto == derecho.texto
+	}
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BusinessRuleHandler struct {
+	service service.BusinessRuleService
+}
+
+func NewBusinessRuleHandler(service service.BusinessRuleService) *BusinessRuleHandler {
+	return &BusinessRuleHandler{
+		service: service,
+	}
+}
+
+// CrearRegla godoc
+// @Summary      Registrar regla de negocio
+// @Description  Agrega una regla propia del emisor que se aplica a sus documentos después de las validaciones de SUNAT.
+// @Description  La expresión usa los campos JSON del documento o del item según el ámbito, por ejemplo
+// @Description  `montoTotal <= 50000`, `ordenCompra != ""` o `!(descripcion contiene ["tabaco", "licor"])`,
+// @Description  y la condición opcional limita a qué documentos se aplica, por ejemplo `rucReceptor en ["20100070970"]`
+// @Tags         business-rules
+// @Accept       json
+// @Produce      json
+// @Param        ruc   path      string               true  "RUC del emisor"
+// @Param        rule  body      domain.ReglaNegocio  true  "Datos de la regla"
+// @Success      201   {object}  domain.ReglaNegocio
+// @Failure      400   {object}  errors.AppError
+// @Failure      409   {object}  errors.AppError
+// @Failure      500   {object}  errors.AppError
+// @Router       /issuers/{ruc}/rules [post]
+func (h *BusinessRuleHandler) CrearRegla(c *gin.Context) {
+	var nuevaRegla domain.ReglaNegocio
+	if utils.ValidarJSON(c, &nuevaRegla) {
+		return
+	}
+	nuevaRegla.RucEmisor = c.Param("ruc")
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.CrearRegla(contexto, &nuevaRegla)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorCreatingBusinessRule) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, nuevaRegla)
+}
+
+// ObtenerReglas godoc
+// @Summary      Listar reglas de negocio
+// @Description  Devuelve las reglas del emisor ordenadas por nombre, activas e inactivas
+// @Tags         business-rules
+// @Produce      json
+// @Param        ruc  path      string  true  "RUC del emisor"
+// @Success      200  {array}   domain.ReglaNegocio
+// @Failure      500  {object}  errors.AppError
+// @Router       /issuers/{ruc}/rules [get]
+func (h *BusinessRuleHandler) ObtenerReglas(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	reglas, err := h.service.ObtenerReglas(contexto, c.Param("ruc"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingBusinessRules) {
+		return
+	}
+
+	c.JSON(http.StatusOK, reglas)
+}
+
+// ObtenerRegla godoc
+// @Summary      Obtener regla de negocio
+// @Description  Devuelve una regla del emisor por su nombre
+// @Tags         business-rules
+// @Produce      json
+// @Param        ruc     path      string  true  "RUC del emisor"
+// @Param        nombre  path      string  true  "Nombre de la regla"
+// @Success      200     {object}  domain.ReglaNegocio
+// @Failure      404     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers/{ruc}/rules/{nombre} [get]
+func (h *BusinessRuleHandler) ObtenerRegla(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	regla, err := h.service.ObtenerRegla(contexto, c.Param("ruc"), c.Param("nombre"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingBusinessRule) {
+		return
+	}
+
+	c.JSON(http.StatusOK, regla)
+}
+
+// ActualizarRegla godoc
+// @Summary      Actualizar regla de negocio
+// @Description  Reemplaza la regla. El cambio se aplica a los documentos que se creen o actualicen desde ese momento;
+// @Description  para dejar de aplicarla sin borrarla se usa el estado INACTIVA
+// @Tags         business-rules
+// @Accept       json
+// @Produce      json
+// @Param        ruc     path      string               true  "RUC del emisor"
+// @Param        nombre  path      string               true  "Nombre de la regla"
+// @Param        rule    body      domain.ReglaNegocio  true  "Datos actualizados"
+// @Success      200     {object}  domain.ReglaNegocio
+// @Failure      400     {object}  errors.AppError
+// @Failure      404     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers/{ruc}/rules/{nombre} [put]
+func (h *BusinessRuleHandler) ActualizarRegla(c *gin.Context) {
+	var reglaActualizada domain.ReglaNegocio
+	if utils.ValidarJSON(c, &reglaActualizada) {
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeoutPersonalizado(c, utils.UpdateOperationTimeout)
+	defer cancel()
+
+	err := h.service.ActualizarRegla(contexto, c.Param("ruc"), c.Param("nombre"), &reglaActualizada)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorUpdatingBusinessRule) {
+		return
+	}
+
+	c.JSON(http.StatusOK, reglaActualizada)
+}
+
+// EliminarRegla godoc
+// @Summary      Eliminar regla de negocio
+// @Description  Quita una regla del emisor
+// @Tags         business-rules
+// @Produce      json
+// @Param        ruc     path      string  true  "RUC del emisor"
+// @Param        nombre  path      string  true  "Nombre de la regla"
+// @Success      200     {object}  map[string]string
+// @Failure      404     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers/{ruc}/rules/{nombre} [delete]
+func (h *BusinessRuleHandler) EliminarRegla(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.EliminarRegla(contexto, c.Param("ruc"), c.Param("nombre"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorDeletingBusinessRule) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": utils.SuccessBusinessRuleDeleted})
+}
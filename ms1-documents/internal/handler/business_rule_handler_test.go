@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockBusinessRuleService struct {
+	creada        *domain.ReglaNegocio
+	nombreEditado string
+	nombreBorrado string
+}
+
+func (m *mockBusinessRuleService) CrearRegla(ctx context.Context, regla *domain.ReglaNegocio) error {
+	if regla.Expresion == "" {
+		return errors.ErrorValidacion("expresion inválida en la posición 1: se esperaba un campo o un valor")
+	}
+	m.creada = regla
+	return nil
+}
+
+func (m *mockBusinessRuleService) ObtenerReglas(ctx context.Context, rucEmisor string) ([]domain.ReglaNegocio, error) {
+	return []domain.ReglaNegocio{{RucEmisor: rucEmisor, Nombre: "monto-maximo"}}, nil
+}
+
+func (m *mockBusinessRuleService) ObtenerRegla(ctx context.Context, rucEmisor, nombre string) (*domain.ReglaNegocio, error) {
+	if nombre != "monto-maximo" {
+		return nil, errors.ErrorNoEncontrado("Regla " + nombre + " no encontrada para el emisor " + rucEmisor)
+	}
+	return &domain.ReglaNegocio{RucEmisor: rucEmisor, Nombre: nombre, Expresion: "montoTotal <= 50000"}, nil
+}
+
+func (m *mockBusinessRuleService) ActualizarRegla(ctx context.Context, rucEmisor, nombre string, regla *domain.ReglaNegocio) error {
+	m.nombreEditado = nombre
+	return nil
+}
+
+func (m *mockBusinessRuleService) EliminarRegla(ctx context.Context, rucEmisor, nombre string) error {
+	m.nombreBorrado = nombre
+	return nil
+}
+
+func setupBusinessRuleRouter(svc service.BusinessRuleService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewBusinessRuleHandler(svc)
+	router := gin.New()
+	router.POST("/issuers/:ruc/rules", handler.CrearRegla)
+	router.GET("/issuers/:ruc/rules", handler.ObtenerReglas)
+	router.GET("/issuers/:ruc/rules/:nombre", handler.ObtenerRegla)
+	router.PUT("/issuers/:ruc/rules/:nombre", handler.ActualizarRegla)
+	router.DELETE("/issuers/:ruc/rules/:nombre", handler.EliminarRegla)
+	return router
+}
+
+func TestCrearRegla(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"válida", `{"nombre": "monto-maximo", "expresion": "montoTotal <= 50000", "campo": "montoTotal", "mensaje": "Excede el monto máximo"}`, http.StatusCreated},
+		{"sin expresión", `{"nombre": "monto-maximo"}`, http.StatusBadRequest},
+		{"JSON inválido", `{"nombre": `, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockBusinessRuleService{}
+			router := setupBusinessRuleRouter(svc)
+
+			req, _ := http.NewRequest("POST", "/issuers/20123456786/rules", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusCreated && svc.creada.RucEmisor != "20123456786" {
+				t.Errorf("Expected rucEmisor from path, got %q", svc.creada.RucEmisor)
+			}
+		})
+	}
+}
+
+func TestObtenerRegla(t *testing.T) {
+	router := setupBusinessRuleRouter(&mockBusinessRuleService{})
+
+	req, _ := http.NewRequest("GET", "/issuers/20123456786/rules/monto-maximo", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var regla domain.ReglaNegocio
+	if err := json.Unmarshal(w.Body.Bytes(), &regla); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if regla.Expresion != "montoTotal <= 50000" {
+		t.Errorf("Expected expresion montoTotal <= 50000, got %s", regla.Expresion)
+	}
+
+	req, _ = http.NewRequest("GET", "/issuers/20123456786/rules/orden-compra", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestActualizarYEliminarRegla(t *testing.T) {
+	svc := &mockBusinessRuleService{}
+	router := setupBusinessRuleRouter(svc)
+
+	req, _ := http.NewRequest("PUT", "/issuers/20123456786/rules/monto-maximo", strings.NewReader(`{"estado": "INACTIVA"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || svc.nombreEditado != "monto-maximo" {
+		t.Errorf("Expected status %d for monto-maximo, got %d for %q", http.StatusOK, w.Code, svc.nombreEditado)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/issuers/20123456786/rules/monto-maximo", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || svc.nombreBorrado != "monto-maximo" {
+		t.Errorf("Expected status %d for monto-maximo, got %d for %q", http.StatusOK, w.Code, svc.nombreBorrado)
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"ms1-documents/internal/catalog"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler expone los catálogos de SUNAT con los que valida el servicio, para que los clientes
+// armen sus listas de selección con los mismos códigos
+type CatalogHandler struct{}
+
+func NewCatalogHandler() *CatalogHandler {
+	return &CatalogHandler{}
+}
+
+// ListarCatalogos godoc
+// @Summary      Listar catálogos
+// @Description  Devuelve los catálogos de SUNAT disponibles con su versión y cantidad de códigos
+// @Tags         catalogs
+// @Produce      json
+// @Success      200  {array}   catalog.Resumen
+// @Router       /catalogs [get]
+func (h *CatalogHandler) ListarCatalogos(c *gin.Context) {
+	c.JSON(http.StatusOK, catalog.Listar())
+}
+
+// ObtenerCatalogo godoc
+// @Summary      Obtener catálogo
+// @Description  Devuelve los códigos de un catálogo de SUNAT: 01 tipos de documento, 03 unidades de medida,
+// @Description  06 tipos de documento de identidad, 07 afectación del IGV, 09 y 10 motivos de nota de crédito
+// @Description  y débito, 51 tipos de operación y 54 bienes y servicios sujetos a detracción
+// @Tags         catalogs
+// @Produce      json
+// @Param        id   path      string  true  "Número del catálogo (01, 03, ...)"
+// @Success      200  {object}  catalog.Catalogo
+// @Failure      404  {object}  errors.AppError
+// @Router       /catalogs/{id} [get]
+func (h *CatalogHandler) ObtenerCatalogo(c *gin.Context) {
+	catalogo, ok := catalog.Obtener(c.Param("id"))
+	if !ok {
+		utils.RespondWithError(c, errors.ErrorNoEncontrado("Catálogo "+c.Param("id")+" no encontrado"))
+		return
+	}
+
+	c.JSON(http.StatusOK, catalogo)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"ms1-documents/internal/catalog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupCatalogRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewCatalogHandler()
+	router := gin.New()
+	router.GET("/catalogs", handler.ListarCatalogos)
+	router.GET("/catalogs/:id", handler.ObtenerCatalogo)
+	return router
+}
+
+func TestListarCatalogos(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/catalogs", nil)
+	w := httptest.NewRecorder()
+	setupCatalogRouter().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resumenes []catalog.Resumen
+	if err := json.Unmarshal(w.Body.Bytes(), &resumenes); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(resumenes) == 0 || resumenes[0].ID != catalog.TiposDocumento {
+		t.Errorf("Expected catalogs starting with 01, got %+v", resumenes)
+	}
+}
+
+func TestObtenerCatalogo(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		expectedStatus int
+	}{
+		{"Afectación del IGV", "07", http.StatusOK},
+		{"Inexistente", "99", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/catalogs/"+tt.id, nil)
+			w := httptest.NewRecorder()
+			setupCatalogRouter().ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var catalogo catalog.Catalogo
+			if err := json.Unmarshal(w.Body.Bytes(), &catalogo); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if catalogo.Version == "" || catalogo.Entradas[0].Atributos["categoria"] != "GRAVADO" {
+				t.Errorf("Expected versioned catalog 07 with categories, got %+v", catalogo)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"ms1-documents/internal/service"
+	"ms1-documents/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerHandler struct {
+	service service.CustomerService
+}
+
+func NewCustomerHandler(service service.CustomerService) *CustomerHandler {
+	return &CustomerHandler{
+		service: service,
+	}
+}
+
+// BuscarClientes godoc
+// @Summary      Buscar clientes
+// @Description  Autocompletado de receptores: devuelve hasta 20 clientes del emisor cuyo nombre (sin distinguir mayúsculas)
+// @Description  o número de documento empieza con q. La libreta se llena al crear documentos con guardarCliente
+// @Tags         customers
+// @Produce      json
+// @Param        rucEmisor  query     string  true   "RUC del emisor"
+// @Param        q          query     string  false  "Prefijo del nombre o del número de documento"
+// @Success      200        {array}   domain.Cliente
+// @Failure      400        {object}  errors.AppError
+// @Failure      500        {object}  errors.AppError
+// @Router       /customers [get]
+func (h *CustomerHandler) BuscarClientes(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	clientes, err := h.service.BuscarClientes(contexto, c.Query("rucEmisor"), c.Query("q"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingCustomers) {
+		return
+	}
+
+	c.JSON(http.StatusOK, clientes)
+}
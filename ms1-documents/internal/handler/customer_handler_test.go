@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockCustomerService struct {
+	consulta string
+}
+
+func (m *mockCustomerService) BuscarClientes(ctx context.Context, rucEmisor, consulta string) ([]domain.Cliente, error) {
+	if rucEmisor == "" {
+		return nil, errors.ErrorValidacion("rucEmisor es obligatorio")
+	}
+	m.consulta = consulta
+	return []domain.Cliente{{RucEmisor: rucEmisor, TipoDocumento: "6", NumeroDocumento: "20987654326", Nombre: "CLIENTE DEMO S.A."}}, nil
+}
+
+func TestBuscarClientes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &mockCustomerService{}
+	router := gin.New()
+	router.GET("/customers", NewCustomerHandler(svc).BuscarClientes)
+
+	req, _ := http.NewRequest("GET", "/customers?rucEmisor=20123456786&q=clie", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if svc.consulta != "clie" {
+		t.Errorf("Expected query clie, got %q", svc.consulta)
+	}
+
+	var clientes []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &clientes); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(clientes) != 1 || clientes[0]["nombre"] != "CLIENTE DEMO S.A." {
+		t.Errorf("Expected one customer, got %v", clientes)
+	}
+
+	if _, ok := clientes[0]["nombreBusqueda"]; ok {
+		t.Error("Expected nombreBusqueda not to be exposed")
+	}
+
+	req, _ = http.NewRequest("GET", "/customers?q=clie", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"ms1-documents/internal/service"
+	"ms1-documents/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DailySummaryHandler struct {
+	service service.DailySummaryService
+}
+
+func NewDailySummaryHandler(service service.DailySummaryService) *DailySummaryHandler {
+	return &DailySummaryHandler{
+		service: service,
+	}
+}
+
+// GenerarResumenDiarioRequest indica la fecha de emisión a informar y, opcionalmente, el emisor
+type GenerarResumenDiarioRequest struct {
+	Fecha     string `json:"fecha" example:"2026-02-12"`
+	RucEmisor string `json:"rucEmisor" example:"20123456786"`
+}
+
+// GenerarResumenes godoc
+// @Summary      Generar resúmenes diarios
+// @Description  Genera un resumen diario (RC) por emisor con las boletas y las notas que las modifican emitidas en la fecha indicada
+// @Description  (por defecto ayer, hora de Perú) que son nuevas, cambiaron o se anularon desde el último resumen de esa fecha.
+// @Description  Los emisores sin nada pendiente no generan resumen.
+// @Tags         daily-summaries
+// @Accept       json
+// @Produce      json
+// @Param        request  body      GenerarResumenDiarioRequest  false  "Fecha y emisor"
+// @Success      200      {array}   domain.ResumenDiario
+// @Failure      400      {object}  errors.AppError
+// @Failure      500      {object}  errors.AppError
+// @Router       /daily-summaries [post]
+func (h *DailySummaryHandler) GenerarResumenes(c *gin.Context) {
+	var solicitud GenerarResumenDiarioRequest
+	if c.Request.ContentLength != 0 && utils.ValidarJSON(c, &solicitud) {
+		return
+	}
+	if solicitud.Fecha == "" {
+		solicitud.Fecha = service.FechaResumenPorDefecto()
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	resumenes, err := h.service.GenerarResumenes(contexto, solicitud.Fecha, solicitud.RucEmisor)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorGeneratingDailySummary) {
+		return
+	}
+
+	c.JSON(http.StatusOK, resumenes)
+}
+
+// ListarResumenes godoc
+// @Summary      Listar resúmenes diarios
+// @Description  Obtiene los resúmenes diarios generados, opcionalmente filtrados por emisor y fecha de emisión informada
+// @Tags         daily-summaries
+// @Accept       json
+// @Produce      json
+// @Param        rucEmisor  query     string  false  "RUC del emisor"
+// @Param        fecha      query     string  false  "Fecha de emisión informada (AAAA-MM-DD)"
+// @Success      200        {array}   domain.ResumenDiario
+// @Failure      400        {object}  errors.AppError
+// @Failure      500        {object}  errors.AppError
+// @Router       /daily-summaries [get]
+func (h *DailySummaryHandler) ListarResumenes(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	resumenes, err := h.service.ListarResumenes(contexto, c.Query("rucEmisor"), c.Query("fecha"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingDailySummary) {
+		return
+	}
+
+	c.JSON(http.StatusOK, resumenes)
+}
+
+// ObtenerResumen godoc
+// @Summary      Obtener resumen diario
+// @Description  Obtiene un resumen diario por su número. Como los números se repiten entre emisores, rucEmisor es obligatorio si hay más de uno
+// @Tags         daily-summaries
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true   "Número del resumen (RC-AAAAMMDD-n)"
+// @Param        rucEmisor  query     string  false  "RUC del emisor"
+// @Success      200        {object}  domain.ResumenDiario
+// @Failure      400        {object}  errors.AppError
+// @Failure      404        {object}  errors.AppError
+// @Failure      500        {object}  errors.AppError
+// @Router       /daily-summaries/{id} [get]
+func (h *DailySummaryHandler) ObtenerResumen(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	resumen, err := h.service.ObtenerResumen(contexto, c.Query("rucEmisor"), c.Param("id"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingDailySummary) {
+		return
+	}
+
+	c.JSON(http.StatusOK, resumen)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockDailySummaryService struct {
+	fecha     string
+	rucEmisor string
+}
+
+func (m *mockDailySummaryService) GenerarResumenes(ctx context.Context, fechaReferencia, rucEmisor string) ([]domain.ResumenDiario, error) {
+	m.fecha = fechaReferencia
+	m.rucEmisor = rucEmisor
+	return []domain.ResumenDiario{{ID: "RC-20261017-1", RucEmisor: "20123456786", FechaReferencia: fechaReferencia}}, nil
+}
+
+func (m *mockDailySummaryService) ObtenerResumen(ctx context.Context, rucEmisor, id string) (*domain.ResumenDiario, error) {
+	if id != "RC-20261017-1" {
+		return nil, errors.ErrorNoEncontrado("Resumen diario " + id + " no encontrado")
+	}
+	return &domain.ResumenDiario{ID: id, RucEmisor: "20123456786"}, nil
+}
+
+func (m *mockDailySummaryService) ListarResumenes(ctx context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error) {
+	return []domain.ResumenDiario{}, nil
+}
+
+func setupDailySummaryRouter(svc service.DailySummaryService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewDailySummaryHandler(svc)
+	router := gin.New()
+	router.POST("/daily-summaries", handler.GenerarResumenes)
+	router.GET("/daily-summaries", handler.ListarResumenes)
+	router.GET("/daily-summaries/:id", handler.ObtenerResumen)
+	return router
+}
+
+func TestGenerarResumenes(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expectedFecha string
+		expectedRuc   string
+	}{
+		{
+			name:          "fecha y emisor",
+			body:          `{"fecha": "2026-02-12", "rucEmisor": "20123456786"}`,
+			expectedFecha: "2026-02-12",
+			expectedRuc:   "20123456786",
+		},
+		{
+			name:          "sin cuerpo usa el día anterior",
+			body:          "",
+			expectedFecha: service.FechaResumenPorDefecto(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockDailySummaryService{}
+			router := setupDailySummaryRouter(svc)
+
+			req, _ := http.NewRequest("POST", "/daily-summaries", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+
+			if svc.fecha != tt.expectedFecha || svc.rucEmisor != tt.expectedRuc {
+				t.Errorf("Expected fecha %s and ruc %q, got %s and %q", tt.expectedFecha, tt.expectedRuc, svc.fecha, svc.rucEmisor)
+			}
+
+			var resumenes []domain.ResumenDiario
+			json.Unmarshal(w.Body.Bytes(), &resumenes)
+			if len(resumenes) != 1 || resumenes[0].ID != "RC-20261017-1" {
+				t.Errorf("Expected generated summary, got %+v", resumenes)
+			}
+		})
+	}
+}
+
+func TestObtenerResumen(t *testing.T) {
+	router := setupDailySummaryRouter(&mockDailySummaryService{})
+
+	req, _ := http.NewRequest("GET", "/daily-summaries/RC-20261017-1?rucEmisor=20123456786", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	req, _ = http.NewRequest("GET", "/daily-summaries/RC-20261017-9", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"ms1-documents/internal/domain"
+	"ms1-documents/internal/printable"
 	"ms1-documents/internal/service"
+	"ms1-documents/internal/ubl"
 	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,9 +27,10 @@ func NewDocumentHandler(service service.DocumentService) *DocumentHandler {
 
 // CrearDocumento godoc
 // @Summary      Crear nuevo documento
-// @Description  Crea un nuevo documento fiscal con sus items y validación
+// @Description  Crea un nuevo documento fiscal con sus items y validación. Acepta JSON o un Invoice, CreditNote o DebitNote de UBL 2.1 con Content-Type application/xml
 // @Tags         documents
 // @Accept       json
+// @Accept       xml
 // @Produce      json
 // @Param        document  body      domain.Document  true  "Datos del documento"
 // @Success      201       {object}  domain.Document
@@ -33,6 +38,11 @@ func NewDocumentHandler(service service.DocumentService) *DocumentHandler {
 // @Failure      500       {object}  errors.AppError
 // @Router       /documents [post]
 func (h *DocumentHandler) CrearDocumento(c *gin.Context) {
+	if esXML(c) {
+		h.crearDocumentoDesdeUBL(c)
+		return
+	}
+
 	var nuevoDocumento domain.Document
 	if utils.ValidarJSON(c, &nuevoDocumento) {
 		return
@@ -49,6 +59,122 @@ func (h *DocumentHandler) CrearDocumento(c *gin.Context) {
 	c.JSON(http.StatusCreated, nuevoDocumento)
 }
 
+// crearDocumentoDesdeUBL importa el XML y lo crea por el mismo camino que el JSON.
+// Los errores de validación se acompañan de la ruta del elemento UBL al que se refieren.
+func (h *DocumentHandler) crearDocumentoDesdeUBL(c *gin.Context) {
+	nuevoDocumento := importarUBL(c)
+	if nuevoDocumento == nil {
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.CrearDocumento(contexto, nuevoDocumento)
+	if appErr, ok := err.(*errors.AppError); ok && appErr.Code == http.StatusBadRequest {
+		err = erroresConRutaXML(nuevoDocumento.TipoDocumento, appErr)
+	}
+	if utils.ManejarErrorServicio(c, err, utils.ErrorCreatingDocument) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, nuevoDocumento)
+}
+
+// importarUBL lee el documento del XML del cuerpo; si no se puede leer o supera utils.MaxUBLBodySize
+// responde 400 y devuelve nil
+func importarUBL(c *gin.Context) *domain.Document {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, utils.MaxUBLBodySize)
+	contenido, err := c.GetRawData()
+	if _, excedido := err.(*http.MaxBytesError); excedido {
+		utils.RespondWithError(c, errors.ErrorValidacion(utils.ErrorXMLTooLarge))
+		return nil
+	}
+	if err != nil {
+		utils.RespondWithError(c, errors.ErrorValidacion(utils.ErrorInvalidXML))
+		return nil
+	}
+
+	documento, err := ubl.Importar(contenido)
+	if err != nil {
+		utils.RespondWithError(c, errors.ErrorValidacion(fmt.Sprintf("%s: %s", utils.ErrorInvalidXML, err.Error())))
+		return nil
+	}
+	return documento
+}
+
+// erroresConRutaXML antepone a cada mensaje el elemento UBL del campo que lo originó; path sigue siendo
+// el JSON pointer del documento importado. Un error sin campos se devuelve igual.
+func erroresConRutaXML(tipoDocumento string, appErr *errors.AppError) *errors.AppError {
+	if len(appErr.Errors) == 0 {
+		return appErr
+	}
+	return errors.ErrorValidacionCampos(conRutaXML(tipoDocumento, appErr.Errors))
+}
+
+func conRutaXML(tipoDocumento string, violaciones []errors.FieldError) []errors.FieldError {
+	if violaciones == nil {
+		return nil
+	}
+
+	errores := make([]errors.FieldError, len(violaciones))
+	for indice, violacion := range violaciones {
+		violacion.Message = fmt.Sprintf("%s: %s", ubl.RutaXML(tipoDocumento, violacion.Path), violacion.Message)
+		errores[indice] = violacion
+	}
+	return errores
+}
+
+func esXML(c *gin.Context) bool {
+	tipoContenido := c.ContentType()
+	return tipoContenido == "application/xml" || tipoContenido == "text/xml"
+}
+
+// ValidarDocumento godoc
+// @Summary      Validar documento sin crearlo
+// @Description  Aplica las verificaciones de POST /documents (emisor, catálogo de productos, formato, aritmética, reglas de
+// @Description  negocio del emisor, documento de referencia e idDocumento repetido) sin guardar, numerar ni publicar el documento.
+// @Description  Responde 200 con el resultado de cada verificación aunque el documento no sea válido. Acepta JSON o UBL 2.1
+// @Description  como POST /documents; en el XML cada mensaje empieza con la ruta del elemento
+// @Tags         documents
+// @Accept       json
+// @Accept       xml
+// @Produce      json
+// @Param        document  body      domain.Document  true  "Documento a validar"
+// @Success      200       {object}  domain.ReporteValidacion
+// @Failure      400       {object}  errors.AppError
+// @Failure      500       {object}  errors.AppError
+// @Router       /documents/validate [post]
+func (h *DocumentHandler) ValidarDocumento(c *gin.Context) {
+	var documento *domain.Document
+	if esXML(c) {
+		if documento = importarUBL(c); documento == nil {
+			return
+		}
+	} else {
+		documento = &domain.Document{}
+		if utils.ValidarJSON(c, documento) {
+			return
+		}
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	reporte, err := h.service.ValidarDocumento(contexto, documento)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorValidatingDocument) {
+		return
+	}
+
+	if esXML(c) {
+		reporte.Errores = conRutaXML(documento.TipoDocumento, reporte.Errores)
+		for indice := range reporte.Verificaciones {
+			reporte.Verificaciones[indice].Errores = conRutaXML(documento.TipoDocumento, reporte.Verificaciones[indice].Errores)
+		}
+	}
+	c.JSON(http.StatusOK, reporte)
+}
+
 // ObtenerDocumentos godoc
 // @Summary      Listar todos los documentos
 // @Description  Obtiene la lista completa de documentos fiscales
@@ -77,6 +203,7 @@ func (h *DocumentHandler) ObtenerDocumentos(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string           true  "ID del documento"
+// @Param        rucEmisor  query  string  false  "RUC del emisor; obligatorio si varios emisores usan el mismo ID"
 // @Success      200  {object}  domain.Document
 // @Failure      404  {object}  errors.AppError
 // @Failure      500  {object}  errors.AppError
@@ -86,7 +213,7 @@ func (h *DocumentHandler) ObtenerDocumento(c *gin.Context) {
 	contexto, cancel := utils.CrearContextoConTimeout(c)
 	defer cancel()
 
-	documento, err := h.service.ObtenerDocumentoPorID(contexto, idDocumento)
+	documento, err := h.service.ObtenerDocumentoPorID(contexto, c.Query("rucEmisor"), idDocumento)
 	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingDocument) {
 		return
 	}
@@ -94,9 +221,88 @@ func (h *DocumentHandler) ObtenerDocumento(c *gin.Context) {
 	c.JSON(http.StatusOK, documento)
 }
 
+// ObtenerDocumentoUBL godoc
+// @Summary      Exportar documento en UBL 2.1
+// @Description  Devuelve el documento como Invoice, CreditNote o DebitNote de UBL 2.1 con la firma almacenada
+// @Tags         documents
+// @Produce      xml
+// @Param        id   path      string  true  "ID del documento"
+// @Param        rucEmisor  query  string  false  "RUC del emisor; obligatorio si varios emisores usan el mismo ID"
+// @Success      200  {string}  string  "XML UBL 2.1"
+// @Failure      404  {object}  errors.AppError
+// @Failure      500  {object}  errors.AppError
+// @Router       /documents/{id}/ubl [get]
+func (h *DocumentHandler) ObtenerDocumentoUBL(c *gin.Context) {
+	idDocumento := c.Param("id")
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	documento, err := h.service.ObtenerDocumentoPorID(contexto, c.Query("rucEmisor"), idDocumento)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingDocument) {
+		return
+	}
+
+	contenido, err := ubl.Generar(documento)
+	if err != nil {
+		utils.RespondWithError(c, errors.ErrorInterno(utils.ErrorGeneratingUBL))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", contenido)
+}
+
+// ObtenerDocumentoPDF godoc
+// @Summary      Representación impresa en PDF
+// @Description  Devuelve el comprobante en PDF con emisor, receptor, items, totales, monto en letras y el código QR de SUNAT
+// @Tags         documents
+// @Produce      application/pdf
+// @Param        id   path      string  true  "ID del documento"
+// @Param        rucEmisor  query  string  false  "RUC del emisor; obligatorio si varios emisores usan el mismo ID"
+// @Success      200  {file}    file    "PDF de la representación impresa"
+// @Failure      404  {object}  errors.AppError
+// @Failure      500  {object}  errors.AppError
+// @Router       /documents/{id}/pdf [get]
+func (h *DocumentHandler) ObtenerDocumentoPDF(c *gin.Context) {
+	h.responderRepresentacion(c, printable.GenerarPDF, "application/pdf")
+}
+
+// ObtenerDocumentoHTML godoc
+// @Summary      Representación impresa en HTML
+// @Description  Devuelve el comprobante como página HTML con el código QR de SUNAT en SVG
+// @Tags         documents
+// @Produce      html
+// @Param        id   path      string  true  "ID del documento"
+// @Param        rucEmisor  query  string  false  "RUC del emisor; obligatorio si varios emisores usan el mismo ID"
+// @Success      200  {string}  string  "HTML de la representación impresa"
+// @Failure      404  {object}  errors.AppError
+// @Failure      500  {object}  errors.AppError
+// @Router       /documents/{id}/html [get]
+func (h *DocumentHandler) ObtenerDocumentoHTML(c *gin.Context) {
+	h.responderRepresentacion(c, printable.GenerarHTML, "text/html; charset=utf-8")
+}
+
+func (h *DocumentHandler) responderRepresentacion(c *gin.Context, generar func(*domain.Document) ([]byte, error), tipoContenido string) {
+	idDocumento := c.Param("id")
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	documento, err := h.service.ObtenerDocumentoPorID(contexto, c.Query("rucEmisor"), idDocumento)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingDocument) {
+		return
+	}
+
+	contenido, err := generar(documento)
+	if err != nil {
+		utils.RespondWithError(c, errors.ErrorInterno(utils.ErrorGeneratingPrintable))
+		return
+	}
+
+	c.Data(http.StatusOK, tipoContenido, contenido)
+}
+
 // ActualizarDocumento godoc
 // @Summary      Actualizar documento
-// @Description  Actualiza los datos de un documento existente
+// @Description  Actualiza un borrador que aún no se publicó. Los documentos emitidos se corrigen con una nota de crédito o débito
 // @Tags         documents
 // @Accept       json
 // @Produce      json
@@ -105,6 +311,7 @@ func (h *DocumentHandler) ObtenerDocumento(c *gin.Context) {
 // @Success      200       {object}  domain.Document
 // @Failure      400       {object}  errors.AppError
 // @Failure      404       {object}  errors.AppError
+// @Failure      409       {object}  errors.AppError
 // @Failure      500       {object}  errors.AppError
 // @Router       /documents/{id} [put]
 func (h *DocumentHandler) ActualizarDocumento(c *gin.Context) {
@@ -126,14 +333,16 @@ func (h *DocumentHandler) ActualizarDocumento(c *gin.Context) {
 }
 
 // EliminarDocumento godoc
-// @Summary      Eliminar documento
-// @Description  Elimina permanentemente un documento del sistema
+// @Summary      Eliminar borrador
+// @Description  Elimina un borrador que nunca se publicó y libera su correlativo. Los documentos emitidos se anulan con POST /documents/{id}/void
 // @Tags         documents
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string             true  "ID del documento"
+// @Param        rucEmisor  query  string  false  "RUC del emisor; obligatorio si varios emisores usan el mismo ID"
 // @Success      200  {object}  map[string]string
 // @Failure      404  {object}  errors.AppError
+// @Failure      409  {object}  errors.AppError
 // @Failure      500  {object}  errors.AppError
 // @Router       /documents/{id} [delete]
 func (h *DocumentHandler) EliminarDocumento(c *gin.Context) {
@@ -141,7 +350,7 @@ func (h *DocumentHandler) EliminarDocumento(c *gin.Context) {
 	contexto, cancel := utils.CrearContextoConTimeout(c)
 	defer cancel()
 
-	err := h.service.EliminarDocumento(contexto, id)
+	err := h.service.EliminarDocumento(contexto, c.Query("rucEmisor"), id)
 	if utils.ManejarErrorServicio(c, err, utils.ErrorDeletingDocument) {
 		return
 	}
@@ -149,6 +358,123 @@ func (h *DocumentHandler) EliminarDocumento(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": utils.SuccessDocumentDeleted})
 }
 
+// AnularDocumentoRequest representa la solicitud de anulación
+type AnularDocumentoRequest struct {
+	Motivo string `json:"motivo" binding:"required" example:"Error en el RUC del receptor"`
+}
+
+// AnularDocumentoResponse devuelve el documento anulado y la comunicación de baja que lo incluye
+type AnularDocumentoResponse struct {
+	Message          string                   `json:"message"`
+	Documento        *domain.Document         `json:"documento"`
+	ComunicacionBaja *domain.ComunicacionBaja `json:"comunicacionBaja,omitempty"`
+}
+
+// AnularDocumento godoc
+// @Summary      Anular documento
+// @Description  Da de baja un documento emitido dentro de los 7 días siguientes a su emisión. Las facturas y sus notas se agrupan en la comunicación de baja (RA) del día; las boletas se informan en el resumen diario
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "ID del documento"
+// @Param        rucEmisor  query  string  false  "RUC del emisor; obligatorio si varios emisores usan el mismo ID"
+// @Param        request  body      AnularDocumentoRequest  true  "Motivo de la baja"
+// @Success      200      {object}  AnularDocumentoResponse
+// @Failure      400      {object}  errors.AppError
+// @Failure      404      {object}  errors.AppError
+// @Failure      409      {object}  errors.AppError
+// @Failure      500      {object}  errors.AppError
+// @Router       /documents/{id}/void [post]
+func (h *DocumentHandler) AnularDocumento(c *gin.Context) {
+	var solicitud AnularDocumentoRequest
+	if utils.ValidarJSON(c, &solicitud) {
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	documento, comunicacion, err := h.service.AnularDocumento(contexto, c.Query("rucEmisor"), c.Param("id"), solicitud.Motivo)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorVoidingDocument) {
+		return
+	}
+
+	c.JSON(http.StatusOK, AnularDocumentoResponse{
+		Message:          utils.SuccessDocumentVoided,
+		Documento:        documento,
+		ComunicacionBaja: comunicacion,
+	})
+}
+
+// ListarComunicacionesBaja godoc
+// @Summary      Listar comunicaciones de baja
+// @Description  Obtiene las comunicaciones de baja (RA) generadas, opcionalmente filtradas por emisor y fecha de generación
+// @Tags         void-summaries
+// @Accept       json
+// @Produce      json
+// @Param        rucEmisor  query     string  false  "RUC del emisor"
+// @Param        fecha      query     string  false  "Fecha de generación (AAAA-MM-DD)"
+// @Success      200        {array}   domain.ComunicacionBaja
+// @Failure      500        {object}  errors.AppError
+// @Router       /void-summaries [get]
+func (h *DocumentHandler) ListarComunicacionesBaja(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	comunicaciones, err := h.service.ListarComunicacionesBaja(contexto, c.Query("rucEmisor"), c.Query("fecha"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingVoidSummary) {
+		return
+	}
+
+	c.JSON(http.StatusOK, comunicaciones)
+}
+
+// ListarDocumentosVencidos godoc
+// @Summary      Listar documentos con cuotas vencidas
+// @Description  Obtiene los documentos al crédito emitidos y no anulados con cuotas cuya fecha de vencimiento es anterior a la fecha indicada (hoy por defecto)
+// @Tags         documents
+// @Accept       json
+// @Produce      json
+// @Param        rucEmisor  query     string  false  "RUC del emisor"
+// @Param        fecha      query     string  false  "Fecha de corte (AAAA-MM-DD)"
+// @Success      200        {array}   domain.DocumentoVencido
+// @Failure      400        {object}  errors.AppError
+// @Failure      500        {object}  errors.AppError
+// @Router       /documents/overdue [get]
+func (h *DocumentHandler) ListarDocumentosVencidos(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	vencidos, err := h.service.ListarDocumentosVencidos(contexto, c.Query("rucEmisor"), c.Query("fecha"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingOverdue) {
+		return
+	}
+
+	c.JSON(http.StatusOK, vencidos)
+}
+
+// ListarSeries godoc
+// @Summary      Listar series y correlativos
+// @Description  Obtiene las series de numeración con el último correlativo asignado y los números liberados que quedaron como huecos, opcionalmente filtradas por emisor
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        rucEmisor  query     string  false  "RUC del emisor"
+// @Success      200        {array}   domain.SerieCorrelativo
+// @Failure      500        {object}  errors.AppError
+// @Router       /series [get]
+func (h *DocumentHandler) ListarSeries(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	series, err := h.service.ListarSeries(contexto, c.Query("rucEmisor"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingSeries) {
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
 // VerifyDocumentRequest representa la solicitud de verificación
 type VerifyDocumentRequest struct {
 	Documento domain.Document `json:"documento" binding:"required"`
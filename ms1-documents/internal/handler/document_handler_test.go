@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"ms1-documents/internal/domain"
 	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -15,11 +17,15 @@ import (
 
 type mockService struct {
 	createDocumentFunc  func(ctx context.Context, doc *domain.Document) error
+	validateFunc        func(ctx context.Context, doc *domain.Document) (*domain.ReporteValidacion, error)
 	getAllDocumentsFunc func(ctx context.Context) ([]domain.Document, error)
-	getDocumentByIDFunc func(ctx context.Context, id string) (*domain.Document, error)
+	getDocumentByIDFunc func(ctx context.Context, rucEmisor, id string) (*domain.Document, error)
 	updateDocumentFunc  func(ctx context.Context, id string, doc *domain.Document) error
-	deleteDocumentFunc  func(ctx context.Context, id string) error
+	deleteDocumentFunc  func(ctx context.Context, rucEmisor, id string) error
 	verifyDocumentFunc  func(ctx context.Context, documento *domain.Document, firma string) (bool, error)
+	listSeriesFunc      func(ctx context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error)
+	voidDocumentFunc    func(ctx context.Context, rucEmisor, id, motivo string) (*domain.Document, *domain.ComunicacionBaja, error)
+	listOverdueFunc     func(ctx context.Context, rucEmisor, fecha string) ([]domain.DocumentoVencido, error)
 }
 
 func (m *mockService) CrearDocumento(ctx context.Context, doc *domain.Document) error {
@@ -29,6 +35,13 @@ func (m *mockService) CrearDocumento(ctx context.Context, doc *domain.Document)
 	return nil
 }
 
+func (m *mockService) ValidarDocumento(ctx context.Context, doc *domain.Document) (*domain.ReporteValidacion, error) {
+	if m.validateFunc != nil {
+		return m.validateFunc(ctx, doc)
+	}
+	return domain.NuevoReporteValidacion(doc), nil
+}
+
 func (m *mockService) ObtenerTodosDocumentos(ctx context.Context) ([]domain.Document, error) {
 	if m.getAllDocumentsFunc != nil {
 		return m.getAllDocumentsFunc(ctx)
@@ -36,9 +49,9 @@ func (m *mockService) ObtenerTodosDocumentos(ctx context.Context) ([]domain.Docu
 	return []domain.Document{}, nil
 }
 
-func (m *mockService) ObtenerDocumentoPorID(ctx context.Context, id string) (*domain.Document, error) {
+func (m *mockService) ObtenerDocumentoPorID(ctx context.Context, rucEmisor, id string) (*domain.Document, error) {
 	if m.getDocumentByIDFunc != nil {
-		return m.getDocumentByIDFunc(ctx, id)
+		return m.getDocumentByIDFunc(ctx, rucEmisor, id)
 	}
 	return nil, errors.ErrorNoEncontrado("not found")
 }
@@ -50,13 +63,20 @@ func (m *mockService) ActualizarDocumento(ctx context.Context, id string, doc *d
 	return nil
 }
 
-func (m *mockService) EliminarDocumento(ctx context.Context, id string) error {
+func (m *mockService) EliminarDocumento(ctx context.Context, rucEmisor, id string) error {
 	if m.deleteDocumentFunc != nil {
-		return m.deleteDocumentFunc(ctx, id)
+		return m.deleteDocumentFunc(ctx, rucEmisor, id)
 	}
 	return nil
 }
 
+func (m *mockService) ListarSeries(ctx context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error) {
+	if m.listSeriesFunc != nil {
+		return m.listSeriesFunc(ctx, rucEmisor)
+	}
+	return []domain.SerieCorrelativo{}, nil
+}
+
 func (m *mockService) VerificarDocumento(ctx context.Context, documento *domain.Document, firma string) (bool, error) {
 	if m.verifyDocumentFunc != nil {
 		return m.verifyDocumentFunc(ctx, documento, firma)
@@ -64,16 +84,42 @@ func (m *mockService) VerificarDocumento(ctx context.Context, documento *domain.
 	return false, errors.ErrorNoEncontrado("not found")
 }
 
+func (m *mockService) AnularDocumento(ctx context.Context, rucEmisor, id, motivo string) (*domain.Document, *domain.ComunicacionBaja, error) {
+	if m.voidDocumentFunc != nil {
+		return m.voidDocumentFunc(ctx, rucEmisor, id, motivo)
+	}
+	return nil, nil, nil
+}
+
+func (m *mockService) ListarComunicacionesBaja(ctx context.Context, rucEmisor, fechaGeneracion string) ([]domain.ComunicacionBaja, error) {
+	return []domain.ComunicacionBaja{}, nil
+}
+
+func (m *mockService) ListarDocumentosVencidos(ctx context.Context, rucEmisor, fecha string) ([]domain.DocumentoVencido, error) {
+	if m.listOverdueFunc != nil {
+		return m.listOverdueFunc(ctx, rucEmisor, fecha)
+	}
+	return []domain.DocumentoVencido{}, nil
+}
+
 func setupRouter(handler *DocumentHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
 	router.POST("/documents", handler.CrearDocumento)
 	router.GET("/documents", handler.ObtenerDocumentos)
+	router.GET("/documents/overdue", handler.ListarDocumentosVencidos)
 	router.GET("/documents/:id", handler.ObtenerDocumento)
+	router.GET("/documents/:id/ubl", handler.ObtenerDocumentoUBL)
+	router.GET("/documents/:id/pdf", handler.ObtenerDocumentoPDF)
+	router.GET("/documents/:id/html", handler.ObtenerDocumentoHTML)
 	router.PUT("/documents/:id", handler.ActualizarDocumento)
 	router.DELETE("/documents/:id", handler.EliminarDocumento)
+	router.POST("/documents/:id/void", handler.AnularDocumento)
 	router.POST("/documents/verify", handler.VerificarDocumento)
+	router.POST("/documents/validate", handler.ValidarDocumento)
+	router.GET("/series", handler.ListarSeries)
+	router.GET("/void-summaries", handler.ListarComunicacionesBaja)
 
 	return router
 }
@@ -89,9 +135,11 @@ func TestCreateDocument_Success(t *testing.T) {
 	router := setupRouter(handler)
 
 	reqBody := map[string]interface{}{
-		"idDocumento":            "FACT-123456789",
-		"rucEmisor":              "20123456789",
-		"rucReceptor":            "20987654321",
+		"idDocumento":            "F001-00000001",
+		"tipoDocumento":          "01",
+		"rucEmisor":              "20123456786",
+		"tipoDocumentoReceptor":  "6",
+		"rucReceptor":            "20987654326",
 		"montoTotalSinImpuestos": 100.0,
 		"igvTotal":               18.0,
 		"montoTotal":             118.0,
@@ -166,14 +214,51 @@ func TestCreateDocument_ValidationError(t *testing.T) {
 	}
 }
 
+func TestCreateDocument_ValidationErrors(t *testing.T) {
+	svc := &mockService{
+		createDocumentFunc: func(ctx context.Context, doc *domain.Document) error {
+			return errors.ErrorValidacionCampos([]errors.FieldError{
+				{Path: "/rucEmisor", Code: "DIGITO_VERIFICADOR_INVALIDO", Message: "rucEmisor tiene un dígito verificador inválido"},
+				{Path: "/items/1/precioTotal", Code: "NO_COINCIDE", Message: "precioTotal del item 1 no coincide con precioUnitario × cantidad"},
+			})
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	body, _ := json.Marshal(map[string]interface{}{"idDocumento": "F001-00000001"})
+	req, _ := http.NewRequest("POST", "/documents", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var response struct {
+		Errors []errors.FieldError `json:"errors"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if len(response.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %s", w.Body.String())
+	}
+
+	if response.Errors[1].Path != "/items/1/precioTotal" || response.Errors[1].Code != "NO_COINCIDE" {
+		t.Errorf("Expected /items/1/precioTotal with NO_COINCIDE, got %+v", response.Errors[1])
+	}
+}
+
 func TestGetDocuments_Success(t *testing.T) {
 	expectedDocs := []domain.Document{
 		{
-			IDDocumento: "FACT-123456789",
+			IDDocumento: "F001-00000001",
 			UUID:        "uuid-1",
 		},
 		{
-			IDDocumento: "FACT-987654321",
+			IDDocumento: "F001-00000002",
 			UUID:        "uuid-2",
 		},
 	}
@@ -222,13 +307,14 @@ func TestGetDocuments_Error(t *testing.T) {
 
 func TestGetDocument_Success(t *testing.T) {
 	expectedDoc := &domain.Document{
-		IDDocumento: "FACT-123456789",
+		IDDocumento: "F001-00000001",
 		UUID:        "uuid-1",
+		MontoTotal:  money.MustParse("1180"),
 	}
 
 	svc := &mockService{
-		getDocumentByIDFunc: func(ctx context.Context, id string) (*domain.Document, error) {
-			if id == "FACT-123456789" {
+		getDocumentByIDFunc: func(ctx context.Context, rucEmisor, id string) (*domain.Document, error) {
+			if rucEmisor == "20123456789" && id == "F001-00000001" {
 				return expectedDoc, nil
 			}
 			return nil, errors.ErrorNoEncontrado("not found")
@@ -237,7 +323,7 @@ func TestGetDocument_Success(t *testing.T) {
 	handler := NewDocumentHandler(svc)
 	router := setupRouter(handler)
 
-	req, _ := http.NewRequest("GET", "/documents/FACT-123456789", nil)
+	req, _ := http.NewRequest("GET", "/documents/F001-00000001?rucEmisor=20123456789", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
@@ -251,11 +337,47 @@ func TestGetDocument_Success(t *testing.T) {
 	if response.IDDocumento != expectedDoc.IDDocumento {
 		t.Errorf("Expected ID %s, got %s", expectedDoc.IDDocumento, response.IDDocumento)
 	}
+
+	if response.MontoEnLetras != "MIL CIENTO OCHENTA CON 00/100 SOLES" {
+		t.Errorf("Expected montoEnLetras MIL CIENTO OCHENTA CON 00/100 SOLES, got %q", response.MontoEnLetras)
+	}
+
+	if response.Moneda != domain.MonedaPEN || !response.MontoTotalSoles.EsCero() {
+		t.Errorf("Expected PEN without montoTotalSoles, got %s %s", response.Moneda, response.MontoTotalSoles)
+	}
+}
+
+func TestGetDocument_MonedaExtranjera(t *testing.T) {
+	svc := &mockService{
+		getDocumentByIDFunc: func(ctx context.Context, rucEmisor, id string) (*domain.Document, error) {
+			return &domain.Document{
+				IDDocumento: "F001-00000001",
+				Moneda:      domain.MonedaUSD,
+				TipoCambio:  money.MustParse("3.712"),
+				MontoTotal:  money.MustParse("1180"),
+			}, nil
+		},
+	}
+	router := setupRouter(NewDocumentHandler(svc))
+
+	req, _ := http.NewRequest("GET", "/documents/F001-00000001", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response domain.Document
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if !response.MontoTotalSoles.Igual(money.MustParse("4380.16")) {
+		t.Errorf("Expected montoTotalSoles 4380.16, got %s", response.MontoTotalSoles)
+	}
+	if response.MontoEnLetras != "MIL CIENTO OCHENTA CON 00/100 DOLARES AMERICANOS" {
+		t.Errorf("Expected amount in words in dollars, got %q", response.MontoEnLetras)
+	}
 }
 
 func TestGetDocument_NotFound(t *testing.T) {
 	svc := &mockService{
-		getDocumentByIDFunc: func(ctx context.Context, id string) (*domain.Document, error) {
+		getDocumentByIDFunc: func(ctx context.Context, rucEmisor, id string) (*domain.Document, error) {
 			return nil, errors.ErrorNoEncontrado("not found")
 		},
 	}
@@ -281,9 +403,11 @@ func TestUpdateDocument_Success(t *testing.T) {
 	router := setupRouter(handler)
 
 	reqBody := map[string]interface{}{
-		"idDocumento":            "FACT-123456789",
-		"rucEmisor":              "20123456789",
-		"rucReceptor":            "20987654321",
+		"idDocumento":            "F001-00000001",
+		"tipoDocumento":          "01",
+		"rucEmisor":              "20123456786",
+		"tipoDocumentoReceptor":  "6",
+		"rucReceptor":            "20987654326",
 		"montoTotalSinImpuestos": 200.0,
 		"igvTotal":               36.0,
 		"montoTotal":             236.0,
@@ -299,7 +423,7 @@ func TestUpdateDocument_Success(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(reqBody)
-	req, _ := http.NewRequest("PUT", "/documents/FACT-123456789", bytes.NewBuffer(body))
+	req, _ := http.NewRequest("PUT", "/documents/F001-00000001", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -315,7 +439,7 @@ func TestUpdateDocument_InvalidJSON(t *testing.T) {
 	handler := NewDocumentHandler(svc)
 	router := setupRouter(handler)
 
-	req, _ := http.NewRequest("PUT", "/documents/FACT-123456789", bytes.NewBuffer([]byte("invalid")))
+	req, _ := http.NewRequest("PUT", "/documents/F001-00000001", bytes.NewBuffer([]byte("invalid")))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -336,9 +460,11 @@ func TestUpdateDocument_NotFound(t *testing.T) {
 	router := setupRouter(handler)
 
 	reqBody := map[string]interface{}{
-		"idDocumento":            "FACT-123456789",
-		"rucEmisor":              "20123456789",
-		"rucReceptor":            "20987654321",
+		"idDocumento":            "F001-00000001",
+		"tipoDocumento":          "01",
+		"rucEmisor":              "20123456786",
+		"tipoDocumentoReceptor":  "6",
+		"rucReceptor":            "20987654326",
 		"montoTotalSinImpuestos": 200.0,
 		"igvTotal":               36.0,
 		"montoTotal":             236.0,
@@ -367,14 +493,14 @@ func TestUpdateDocument_NotFound(t *testing.T) {
 
 func TestDeleteDocument_Success(t *testing.T) {
 	svc := &mockService{
-		deleteDocumentFunc: func(ctx context.Context, id string) error {
+		deleteDocumentFunc: func(ctx context.Context, rucEmisor, id string) error {
 			return nil
 		},
 	}
 	handler := NewDocumentHandler(svc)
 	router := setupRouter(handler)
 
-	req, _ := http.NewRequest("DELETE", "/documents/FACT-123456789", nil)
+	req, _ := http.NewRequest("DELETE", "/documents/F001-00000001", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
@@ -390,6 +516,87 @@ func TestDeleteDocument_Success(t *testing.T) {
 	}
 }
 
+func TestVoidDocument_Success(t *testing.T) {
+	svc := &mockService{
+		voidDocumentFunc: func(ctx context.Context, rucEmisor, id, motivo string) (*domain.Document, *domain.ComunicacionBaja, error) {
+			if motivo != "Error en el RUC del receptor" {
+				t.Errorf("Expected motivo to be passed, got: %s", motivo)
+			}
+			documento := &domain.Document{IDDocumento: id, Estado: domain.EstadoAnulado}
+			comunicacion := &domain.ComunicacionBaja{
+				ID:    "RA-20261017-1",
+				Items: []domain.ItemBaja{{IDDocumento: id, TipoDocumento: domain.TipoFactura, Motivo: motivo}},
+			}
+			return documento, comunicacion, nil
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	body, _ := json.Marshal(map[string]string{"motivo": "Error en el RUC del receptor"})
+	req, _ := http.NewRequest("POST", "/documents/F001-00000001/void", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response AnularDocumentoResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if response.Documento == nil || response.Documento.Estado != domain.EstadoAnulado {
+		t.Errorf("Expected voided document, got: %+v", response.Documento)
+	}
+
+	if response.ComunicacionBaja == nil || response.ComunicacionBaja.ID != "RA-20261017-1" {
+		t.Errorf("Expected comunicación de baja, got: %+v", response.ComunicacionBaja)
+	}
+}
+
+func TestVoidDocument_MissingMotivo(t *testing.T) {
+	handler := NewDocumentHandler(&mockService{})
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("POST", "/documents/F001-00000001/void", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestListOverdueDocuments_Success(t *testing.T) {
+	svc := &mockService{
+		listOverdueFunc: func(ctx context.Context, rucEmisor, fecha string) ([]domain.DocumentoVencido, error) {
+			if rucEmisor != "20123456786" || fecha != "2026-03-20" {
+				t.Errorf("Expected query parameters to be passed, got %s %s", rucEmisor, fecha)
+			}
+			return []domain.DocumentoVencido{{IDDocumento: "F001-00000001", DiasVencido: 6}}, nil
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("GET", "/documents/overdue?rucEmisor=20123456786&fecha=2026-03-20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response []domain.DocumentoVencido
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if len(response) != 1 || response[0].IDDocumento != "F001-00000001" || response[0].DiasVencido != 6 {
+		t.Errorf("Expected one overdue document, got: %+v", response)
+	}
+}
+
 func TestVerifyDocument_Success_ValidUUID(t *testing.T) {
 	svc := &mockService{
 		verifyDocumentFunc: func(ctx context.Context, documento *domain.Document, firma string) (bool, error) {
@@ -401,9 +608,11 @@ func TestVerifyDocument_Success_ValidUUID(t *testing.T) {
 
 	reqBody := map[string]interface{}{
 		"documento": map[string]interface{}{
-			"idDocumento":            "FACT-123456789",
-			"rucEmisor":              "20123456789",
-			"rucReceptor":            "20987654321",
+			"idDocumento":            "F001-00000001",
+			"tipoDocumento":          "01",
+			"rucEmisor":              "20123456786",
+			"tipoDocumentoReceptor":  "6",
+			"rucReceptor":            "20987654326",
 			"montoTotalSinImpuestos": 100.0,
 			"igvTotal":               18.0,
 			"montoTotal":             118.0,
@@ -457,9 +666,11 @@ func TestVerifyDocument_Success_InvalidUUID(t *testing.T) {
 
 	reqBody := map[string]interface{}{
 		"documento": map[string]interface{}{
-			"idDocumento":            "FACT-123456789",
-			"rucEmisor":              "20123456789",
-			"rucReceptor":            "20987654321",
+			"idDocumento":            "F001-00000001",
+			"tipoDocumento":          "01",
+			"rucEmisor":              "20123456786",
+			"tipoDocumentoReceptor":  "6",
+			"rucReceptor":            "20987654326",
 			"montoTotalSinImpuestos": 100.0,
 			"igvTotal":               18.0,
 			"montoTotal":             118.0,
@@ -530,8 +741,10 @@ func TestVerifyDocument_NotFound(t *testing.T) {
 	reqBody := map[string]interface{}{
 		"documento": map[string]interface{}{
 			"idDocumento":            "NONEXISTENT",
-			"rucEmisor":              "20123456789",
-			"rucReceptor":            "20987654321",
+			"tipoDocumento":          "01",
+			"rucEmisor":              "20123456786",
+			"tipoDocumentoReceptor":  "6",
+			"rucReceptor":            "20987654326",
 			"montoTotalSinImpuestos": 100.0,
 			"igvTotal":               18.0,
 			"montoTotal":             118.0,
@@ -559,3 +772,290 @@ func TestVerifyDocument_NotFound(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
 	}
 }
+
+func TestListSeries_FiltraPorEmisor(t *testing.T) {
+	var rucRecibido string
+	svc := &mockService{
+		listSeriesFunc: func(ctx context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error) {
+			rucRecibido = rucEmisor
+			return []domain.SerieCorrelativo{{RucEmisor: rucEmisor, Serie: "F001", UltimoCorrelativo: 25, Liberados: []int64{7}}}, nil
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("GET", "/series?rucEmisor=20123456786", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if rucRecibido != "20123456786" {
+		t.Errorf("Expected rucEmisor filter 20123456786, got %q", rucRecibido)
+	}
+
+	var response []domain.SerieCorrelativo
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if len(response) != 1 || response[0].UltimoCorrelativo != 25 {
+		t.Errorf("Expected series F001 at 25, got %+v", response)
+	}
+
+	if len(response) == 1 && (len(response[0].Liberados) != 1 || response[0].Liberados[0] != 7) {
+		t.Errorf("Expected liberados [7], got %v", response[0].Liberados)
+	}
+}
+
+func TestGetDocumentUBL_Success(t *testing.T) {
+	svc := &mockService{
+		getDocumentByIDFunc: func(ctx context.Context, rucEmisor, id string) (*domain.Document, error) {
+			return &domain.Document{IDDocumento: id, TipoDocumento: domain.TipoFactura, RucEmisor: "20123456786"}, nil
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("GET", "/documents/F001-00000001/ubl", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/xml") {
+		t.Errorf("Expected XML content type, got %s", w.Header().Get("Content-Type"))
+	}
+
+	if !strings.Contains(w.Body.String(), "<cbc:ID>F001-00000001</cbc:ID>") {
+		t.Errorf("Expected UBL invoice for F001-00000001, got %s", w.Body.String())
+	}
+}
+
+func TestGetDocumentUBL_NotFound(t *testing.T) {
+	handler := NewDocumentHandler(&mockService{})
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("GET", "/documents/F001-99999999/ubl", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetDocumentPrintable(t *testing.T) {
+	testCases := []struct {
+		ruta        string
+		contentType string
+		prefijo     string
+	}{
+		{"/documents/F001-00000001/pdf", "application/pdf", "%PDF-1.4"},
+		{"/documents/F001-00000001/html", "text/html", "<!DOCTYPE html>"},
+	}
+
+	svc := &mockService{
+		getDocumentByIDFunc: func(ctx context.Context, rucEmisor, id string) (*domain.Document, error) {
+			return &domain.Document{IDDocumento: id, TipoDocumento: domain.TipoFactura, RucEmisor: "20123456786", TipoDocumentoReceptor: "6", RucReceptor: "20987654326"}, nil
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	for _, tc := range testCases {
+		t.Run(tc.ruta, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.ruta, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			if !strings.HasPrefix(w.Header().Get("Content-Type"), tc.contentType) {
+				t.Errorf("Expected content type %s, got %s", tc.contentType, w.Header().Get("Content-Type"))
+			}
+
+			if !strings.HasPrefix(w.Body.String(), tc.prefijo) {
+				t.Errorf("Expected body to start with %s", tc.prefijo)
+			}
+		})
+	}
+}
+
+func TestGetDocumentPrintable_NotFound(t *testing.T) {
+	handler := NewDocumentHandler(&mockService{})
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("GET", "/documents/F001-99999999/pdf", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+const facturaUBL = `<?xml version="1.0" encoding="UTF-8"?>
+<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+  xmlns:cac="urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+  xmlns:cbc="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">
+  <cbc:ID>F001-00000001</cbc:ID>
+  <cbc:IssueDate>2026-02-12</cbc:IssueDate>
+  <cbc:InvoiceTypeCode listID="0101">01</cbc:InvoiceTypeCode>
+  <cac:AccountingSupplierParty><cac:Party><cac:PartyIdentification><cbc:ID schemeID="6">20123456786</cbc:ID></cac:PartyIdentification></cac:Party></cac:AccountingSupplierParty>
+  <cac:AccountingCustomerParty><cac:Party><cac:PartyIdentification><cbc:ID schemeID="6">20987654326</cbc:ID></cac:PartyIdentification></cac:Party></cac:AccountingCustomerParty>
+  <cac:TaxTotal><cbc:TaxAmount currencyID="PEN">18.00</cbc:TaxAmount>
+    <cac:TaxSubtotal><cbc:TaxableAmount currencyID="PEN">100.00</cbc:TaxableAmount><cbc:TaxAmount currencyID="PEN">18.00</cbc:TaxAmount><cac:TaxCategory><cac:TaxScheme><cbc:ID>1000</cbc:ID></cac:TaxScheme></cac:TaxCategory></cac:TaxSubtotal>
+  </cac:TaxTotal>
+  <cac:LegalMonetaryTotal><cbc:LineExtensionAmount currencyID="PEN">100.00</cbc:LineExtensionAmount><cbc:PayableAmount currencyID="PEN">118.00</cbc:PayableAmount></cac:LegalMonetaryTotal>
+  <cac:InvoiceLine><cbc:ID>1</cbc:ID><cbc:InvoicedQuantity unitCode="NIU">2</cbc:InvoicedQuantity><cbc:LineExtensionAmount currencyID="PEN">100.00</cbc:LineExtensionAmount>
+    <cac:TaxTotal><cbc:TaxAmount currencyID="PEN">18.00</cbc:TaxAmount><cac:TaxSubtotal><cbc:TaxableAmount currencyID="PEN">100.00</cbc:TaxableAmount><cbc:TaxAmount currencyID="PEN">18.00</cbc:TaxAmount><cac:TaxCategory><cbc:Percent>18</cbc:Percent><cbc:TaxExemptionReasonCode>10</cbc:TaxExemptionReasonCode><cac:TaxScheme><cbc:ID>1000</cbc:ID></cac:TaxScheme></cac:TaxCategory></cac:TaxSubtotal></cac:TaxTotal>
+    <cac:Item><cbc:Description>Producto A</cbc:Description></cac:Item><cac:Price><cbc:PriceAmount currencyID="PEN">50.00</cbc:PriceAmount></cac:Price>
+  </cac:InvoiceLine>
+</Invoice>`
+
+func TestCreateDocument_UBL(t *testing.T) {
+	var recibido *domain.Document
+	svc := &mockService{
+		createDocumentFunc: func(ctx context.Context, doc *domain.Document) error {
+			recibido = doc
+			return nil
+		},
+	}
+	handler := NewDocumentHandler(svc)
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("POST", "/documents", strings.NewReader(facturaUBL))
+	req.Header.Set("Content-Type", "application/xml")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	if recibido == nil || recibido.IDDocumento != "F001-00000001" || recibido.MontoTotal.String() != "118.00" || len(recibido.Items) != 1 {
+		t.Errorf("Expected imported invoice F001-00000001 for 118.00, got %+v", recibido)
+	}
+}
+
+func TestCreateDocument_UBLErrores(t *testing.T) {
+	testCases := []struct {
+		name      string
+		contenido string
+		errorSvc  error
+		ruta      string
+	}{
+		{"XML mal formado", "<Invoice>", nil, "XML UBL invalido"},
+		{"XML demasiado grande", facturaUBL + strings.Repeat(" ", 1<<20), nil, "supera el tamaño máximo"},
+		{"Importe inválido", strings.Replace(facturaUBL, ">118.00<", ">ciento dieciocho<", 1), nil, "/Invoice/cac:LegalMonetaryTotal/cbc:PayableAmount"},
+		{"Error de validación", facturaUBL, errors.ErrorValidacionCampos([]errors.FieldError{
+			{Path: "/items/0/precioTotal", Code: "NO_COINCIDE", Message: "precioTotal del item 0 no coincide con precioUnitario × cantidad"},
+		}), "/Invoice/cac:InvoiceLine[1]/cbc:LineExtensionAmount: precioTotal del item 0"},
+		{"Ruta independiente del mensaje", facturaUBL, errors.ErrorValidacionCampos([]errors.FieldError{
+			{Path: "/rucEmisor", Code: "DIGITO_VERIFICADOR_INVALIDO", Message: "precioTotal del item 0 mal redactado"},
+		}), "/Invoice/cac:AccountingSupplierParty/cac:Party/cac:PartyIdentification/cbc:ID: precioTotal del item 0"},
+		{"Error sin campos", facturaUBL, errors.ErrorValidacion("precioTotal del item 0 no coincide"), `"message":"precioTotal del item 0 no coincide"`},
+		{"Varios errores de validación", facturaUBL, errors.ErrorValidacionCampos([]errors.FieldError{
+			{Path: "/rucEmisor", Code: "DIGITO_VERIFICADOR_INVALIDO", Message: "rucEmisor tiene un dígito verificador inválido"},
+			{Path: "/items/0/precioTotal", Code: "NO_COINCIDE", Message: "precioTotal del item 0 no coincide con precioUnitario × cantidad"},
+		}), `"path":"/items/0/precioTotal"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &mockService{
+				createDocumentFunc: func(ctx context.Context, doc *domain.Document) error {
+					return tc.errorSvc
+				},
+			}
+			handler := NewDocumentHandler(svc)
+			router := setupRouter(handler)
+
+			req, _ := http.NewRequest("POST", "/documents", strings.NewReader(tc.contenido))
+			req.Header.Set("Content-Type", "application/xml")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			if !strings.Contains(w.Body.String(), tc.ruta) {
+				t.Errorf("Expected message to contain %s, got %s", tc.ruta, w.Body.String())
+			}
+		})
+	}
+}
+
+func reporteConDescuadre(doc *domain.Document) (*domain.ReporteValidacion, error) {
+	reporte := domain.NuevoReporteValidacion(doc)
+	reporte.Agregar(domain.Verificacion{
+		Nombre:    "aritmetica",
+		Etapa:     domain.EtapaAritmetica,
+		Resultado: domain.ResultadoIncumplida,
+		Errores: []errors.FieldError{
+			{Path: "/items/0/precioTotal", Code: "NO_COINCIDE", Message: "precioTotal del item 0 no coincide con precioUnitario × cantidad"},
+		},
+	})
+	return reporte, nil
+}
+
+func TestValidateDocument(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tipoContenido  string
+		body           string
+		expectedStatus int
+		mensaje        string
+	}{
+		{"JSON", "application/json", `{"idDocumento": "F001-00000001", "tipoDocumento": "01"}`, http.StatusOK, "precioTotal del item 0"},
+		{"UBL", "application/xml", facturaUBL, http.StatusOK, "/Invoice/cac:InvoiceLine[1]/cbc:LineExtensionAmount: precioTotal del item 0"},
+		{"JSON inválido", "application/json", `{"idDocumento": `, http.StatusBadRequest, ""},
+		{"XML mal formado", "application/xml", "<Invoice>", http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &mockService{
+				validateFunc: func(ctx context.Context, doc *domain.Document) (*domain.ReporteValidacion, error) {
+					return reporteConDescuadre(doc)
+				},
+				createDocumentFunc: func(ctx context.Context, doc *domain.Document) error {
+					t.Error("Expected the document not to be created")
+					return nil
+				},
+			}
+			router := setupRouter(NewDocumentHandler(svc))
+
+			req, _ := http.NewRequest("POST", "/documents/validate", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.tipoContenido)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, w.Code, w.Body.String())
+			}
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var reporte domain.ReporteValidacion
+			if err := json.Unmarshal(w.Body.Bytes(), &reporte); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if reporte.Valido || len(reporte.Errores) != 1 || !strings.HasPrefix(reporte.Errores[0].Message, tc.mensaje) {
+				t.Errorf("Expected invalid report with message %q, got %+v", tc.mensaje, reporte.Errores)
+			}
+
+			if verificacion := reporte.Verificaciones[0]; verificacion.Resultado != domain.ResultadoIncumplida || !strings.HasPrefix(verificacion.Errores[0].Message, tc.mensaje) {
+				t.Errorf("Expected failed check with message %q, got %+v", tc.mensaje, verificacion)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"ms1-documents/internal/exchange"
+	"ms1-documents/internal/repository"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateHandler struct {
+	tiposCambio repository.ExchangeRateRepository
+}
+
+func NewExchangeRateHandler(tiposCambio repository.ExchangeRateRepository) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		tiposCambio: tiposCambio,
+	}
+}
+
+// RegistrarTiposCambio godoc
+// @Summary      Registrar tipos de cambio
+// @Description  Agrega o reemplaza tipos de cambio venta a soles publicados por SUNAT. Acepta una lista JSON
+// @Description  o un CSV (Content-Type text/csv) con cabecera fecha,moneda,tipoCambio. Si alguna fila es inválida no se registra ninguna.
+// @Tags         exchange-rates
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        tiposCambio  body      []exchange.TipoCambioDiario  true  "Tipos de cambio"
+// @Success      200          {object}  map[string]interface{}
+// @Failure      400          {object}  errors.AppError
+// @Failure      500          {object}  errors.AppError
+// @Router       /admin/exchange-rates [post]
+func (h *ExchangeRateHandler) RegistrarTiposCambio(c *gin.Context) {
+	var tiposCambio []exchange.TipoCambioDiario
+	if c.ContentType() == "text/csv" {
+		var err error
+		tiposCambio, err = exchange.LeerCSV(c.Request.Body)
+		if err != nil {
+			utils.RespondWithError(c, errors.ErrorValidacion(fmt.Sprintf("%s: %s", utils.ErrorInvalidCSV, err.Error())))
+			return
+		}
+	} else if utils.ValidarJSON(c, &tiposCambio) {
+		return
+	}
+
+	tiposCambio, err := exchange.Normalizar(tiposCambio)
+	if err != nil {
+		utils.RespondWithError(c, errors.ErrorValidacion(err.Error()))
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err = h.tiposCambio.Registrar(contexto, tiposCambio)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorRegisteringExchangeRates) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": utils.SuccessExchangeRatesRegistered, "registrados": len(tiposCambio)})
+}
+
+// ListarTiposCambio godoc
+// @Summary      Listar tipos de cambio
+// @Description  Obtiene los tipos de cambio registrados ordenados por moneda y fecha
+// @Tags         exchange-rates
+// @Accept       json
+// @Produce      json
+// @Param        moneda  query     string  false  "Moneda (USD o EUR)"
+// @Success      200     {array}   exchange.TipoCambioDiario
+// @Failure      500     {object}  errors.AppError
+// @Router       /admin/exchange-rates [get]
+func (h *ExchangeRateHandler) ListarTiposCambio(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	tiposCambio, err := h.tiposCambio.Listar(contexto, strings.ToUpper(c.Query("moneda")))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingExchangeRates) {
+		return
+	}
+
+	c.JSON(http.StatusOK, tiposCambio)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"ms1-documents/internal/exchange"
+	"ms1-documents/pkg/money"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupExchangeRateRouter(t *testing.T) (*gin.Engine, *exchange.TablaTiposCambio) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	tabla, err := exchange.NewTablaTiposCambio(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewExchangeRateHandler(tabla)
+	router := gin.New()
+	router.POST("/admin/exchange-rates", handler.RegistrarTiposCambio)
+	router.GET("/admin/exchange-rates", handler.ListarTiposCambio)
+	return router, tabla
+}
+
+func TestRegistrarTiposCambio(t *testing.T) {
+	testCases := []struct {
+		name          string
+		contentType   string
+		body          string
+		expectedCode  int
+		expectedRates int
+	}{
+		{"JSON", "application/json", `[{"fecha":"2026-02-12","moneda":"USD","tipoCambio":3.712},{"fecha":"2026-02-12","moneda":"EUR","tipoCambio":"4.021"}]`, http.StatusOK, 2},
+		{"CSV", "text/csv", "fecha,moneda,tipoCambio\n2026-02-12,USD,3.712\n", http.StatusOK, 1},
+		{"JSON inválido", "application/json", `{"fecha":`, http.StatusBadRequest, 0},
+		{"CSV sin cabecera", "text/csv", "2026-02-12,USD,3.712\n", http.StatusBadRequest, 0},
+		{"Moneda no admitida", "application/json", `[{"fecha":"2026-02-12","moneda":"USD","tipoCambio":3.712},{"fecha":"2026-02-12","moneda":"GBP","tipoCambio":4.6}]`, http.StatusBadRequest, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, tabla := setupExchangeRateRouter(t)
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/exchange-rates", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expectedCode {
+				t.Errorf("Expected status %d, got %d: %s", tc.expectedCode, w.Code, w.Body.String())
+			}
+			if registrados, _ := tabla.Listar(context.Background(), ""); len(registrados) != tc.expectedRates {
+				t.Errorf("Expected %d registered rates, got %d", tc.expectedRates, len(registrados))
+			}
+		})
+	}
+}
+
+func TestListarTiposCambio(t *testing.T) {
+	router, tabla := setupExchangeRateRouter(t)
+	if err := tabla.Registrar(context.Background(), []exchange.TipoCambioDiario{
+		{Fecha: "2026-02-12", Moneda: "USD", TipoCambio: money.MustParse("3.712")},
+		{Fecha: "2026-02-12", Moneda: "EUR", TipoCambio: money.MustParse("4.021")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/exchange-rates?moneda=usd", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response []map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if len(response) != 1 || response[0]["moneda"] != "USD" || response[0]["tipoCambio"] != 3.712 {
+		t.Errorf("Expected the USD rate only, got %s", w.Body.String())
+	}
+}
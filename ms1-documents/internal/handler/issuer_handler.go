@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IssuerHandler struct {
+	service service.IssuerService
+}
+
+func NewIssuerHandler(service service.IssuerService) *IssuerHandler {
+	return &IssuerHandler{
+		service: service,
+	}
+}
+
+// CrearEmisor godoc
+// @Summary      Registrar emisor
+// @Description  Registra una empresa emisora con sus datos SUNAT y su configuración de emisión (series, moneda, régimen de IGV y logo)
+// @Tags         issuers
+// @Accept       json
+// @Produce      json
+// @Param        issuer  body      domain.Emisor  true  "Datos del emisor"
+// @Success      201     {object}  domain.Emisor
+// @Failure      400     {object}  errors.AppError
+// @Failure      409     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers [post]
+func (h *IssuerHandler) CrearEmisor(c *gin.Context) {
+	var nuevoEmisor domain.Emisor
+	if utils.ValidarJSON(c, &nuevoEmisor) {
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.CrearEmisor(contexto, &nuevoEmisor)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorCreatingIssuer) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, nuevoEmisor)
+}
+
+// ObtenerEmisores godoc
+// @Summary      Listar emisores
+// @Description  Devuelve todos los emisores registrados ordenados por RUC
+// @Tags         issuers
+// @Produce      json
+// @Success      200  {array}   domain.Emisor
+// @Failure      500  {object}  errors.AppError
+// @Router       /issuers [get]
+func (h *IssuerHandler) ObtenerEmisores(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	emisores, err := h.service.ObtenerEmisores(contexto)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingIssuers) {
+		return
+	}
+
+	c.JSON(http.StatusOK, emisores)
+}
+
+// ObtenerEmisor godoc
+// @Summary      Obtener emisor
+// @Description  Devuelve un emisor por su RUC
+// @Tags         issuers
+// @Produce      json
+// @Param        ruc  path      string  true  "RUC del emisor"
+// @Success      200  {object}  domain.Emisor
+// @Failure      404  {object}  errors.AppError
+// @Failure      500  {object}  errors.AppError
+// @Router       /issuers/{ruc} [get]
+func (h *IssuerHandler) ObtenerEmisor(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	emisor, err := h.service.ObtenerEmisor(contexto, c.Param("ruc"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingIssuer) {
+		return
+	}
+
+	c.JSON(http.StatusOK, emisor)
+}
+
+// ActualizarEmisor godoc
+// @Summary      Actualizar emisor
+// @Description  Reemplaza los datos y la configuración de un emisor. Los documentos ya emitidos conservan los datos vigentes al crearlos
+// @Tags         issuers
+// @Accept       json
+// @Produce      json
+// @Param        ruc     path      string         true  "RUC del emisor"
+// @Param        issuer  body      domain.Emisor  true  "Datos actualizados"
+// @Success      200     {object}  domain.Emisor
+// @Failure      400     {object}  errors.AppError
+// @Failure      404     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers/{ruc} [put]
+func (h *IssuerHandler) ActualizarEmisor(c *gin.Context) {
+	var emisorActualizado domain.Emisor
+	if utils.ValidarJSON(c, &emisorActualizado) {
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeoutPersonalizado(c, utils.UpdateOperationTimeout)
+	defer cancel()
+
+	err := h.service.ActualizarEmisor(contexto, c.Param("ruc"), &emisorActualizado)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorUpdatingIssuer) {
+		return
+	}
+
+	c.JSON(http.StatusOK, emisorActualizado)
+}
+
+// EliminarEmisor godoc
+// @Summary      Eliminar emisor
+// @Description  Elimina un emisor del registro. Para suspender la emisión sin perder sus datos, actualice su estado a INACTIVO
+// @Tags         issuers
+// @Produce      json
+// @Param        ruc  path      string  true  "RUC del emisor"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  errors.AppError
+// @Failure      500  {object}  errors.AppError
+// @Router       /issuers/{ruc} [delete]
+func (h *IssuerHandler) EliminarEmisor(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.EliminarEmisor(contexto, c.Param("ruc"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorDeletingIssuer) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": utils.SuccessIssuerDeleted})
+}
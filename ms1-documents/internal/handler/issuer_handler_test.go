@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockIssuerService struct {
+	rucEditado string
+	rucBorrado string
+}
+
+func (m *mockIssuerService) CrearEmisor(ctx context.Context, emisor *domain.Emisor) error {
+	if emisor.RazonSocial == "" {
+		return errors.ErrorValidacion("razonSocial es obligatoria")
+	}
+	emisor.Estado = domain.EstadoEmisorActivo
+	return nil
+}
+
+func (m *mockIssuerService) ObtenerEmisores(ctx context.Context) ([]domain.Emisor, error) {
+	return []domain.Emisor{{RUC: "20123456786"}}, nil
+}
+
+func (m *mockIssuerService) ObtenerEmisor(ctx context.Context, ruc string) (*domain.Emisor, error) {
+	if ruc != "20123456786" {
+		return nil, errors.ErrorNoEncontrado("Emisor con RUC " + ruc + " no encontrado")
+	}
+	return &domain.Emisor{RUC: ruc, RazonSocial: "EMPRESA DEMO S.A.C."}, nil
+}
+
+func (m *mockIssuerService) ActualizarEmisor(ctx context.Context, ruc string, emisor *domain.Emisor) error {
+	m.rucEditado = ruc
+	return nil
+}
+
+func (m *mockIssuerService) EliminarEmisor(ctx context.Context, ruc string) error {
+	m.rucBorrado = ruc
+	return nil
+}
+
+func setupIssuerRouter(svc service.IssuerService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewIssuerHandler(svc)
+	router := gin.New()
+	router.POST("/issuers", handler.CrearEmisor)
+	router.GET("/issuers", handler.ObtenerEmisores)
+	router.GET("/issuers/:ruc", handler.ObtenerEmisor)
+	router.PUT("/issuers/:ruc", handler.ActualizarEmisor)
+	router.DELETE("/issuers/:ruc", handler.EliminarEmisor)
+	return router
+}
+
+func TestCrearEmisor(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"válido", `{"ruc": "20123456786", "razonSocial": "EMPRESA DEMO S.A.C."}`, http.StatusCreated},
+		{"sin razón social", `{"ruc": "20123456786"}`, http.StatusBadRequest},
+		{"JSON inválido", `{"ruc": `, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupIssuerRouter(&mockIssuerService{})
+
+			req, _ := http.NewRequest("POST", "/issuers", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestObtenerEmisor(t *testing.T) {
+	router := setupIssuerRouter(&mockIssuerService{})
+
+	req, _ := http.NewRequest("GET", "/issuers/20123456786", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var emisor domain.Emisor
+	if err := json.Unmarshal(w.Body.Bytes(), &emisor); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if emisor.RazonSocial != "EMPRESA DEMO S.A.C." {
+		t.Errorf("Expected razonSocial EMPRESA DEMO S.A.C., got %s", emisor.RazonSocial)
+	}
+
+	req, _ = http.NewRequest("GET", "/issuers/20987654326", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestActualizarYEliminarEmisor(t *testing.T) {
+	svc := &mockIssuerService{}
+	router := setupIssuerRouter(svc)
+
+	req, _ := http.NewRequest("PUT", "/issuers/20123456786", strings.NewReader(`{"razonSocial": "EMPRESA DEMO S.A.C."}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || svc.rucEditado != "20123456786" {
+		t.Errorf("Expected status %d for 20123456786, got %d for %q", http.StatusOK, w.Code, svc.rucEditado)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/issuers/20123456786", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || svc.rucBorrado != "20123456786" {
+		t.Errorf("Expected status %d for 20123456786, got %d for %q", http.StatusOK, w.Code, svc.rucBorrado)
+	}
+}
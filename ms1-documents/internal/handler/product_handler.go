@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductHandler struct {
+	service service.ProductService
+}
+
+func NewProductHandler(service service.ProductService) *ProductHandler {
+	return &ProductHandler{
+		service: service,
+	}
+}
+
+// CrearProducto godoc
+// @Summary      Registrar producto
+// @Description  Agrega un producto o servicio al catálogo del emisor con su código interno, código SUNAT (UNSPSC),
+// @Description  unidad de medida del catálogo 03, precio por defecto y tipo de afectación del IGV
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        ruc      path      string           true  "RUC del emisor"
+// @Param        product  body      domain.Producto  true  "Datos del producto"
+// @Success      201      {object}  domain.Producto
+// @Failure      400      {object}  errors.AppError
+// @Failure      409      {object}  errors.AppError
+// @Failure      500      {object}  errors.AppError
+// @Router       /issuers/{ruc}/products [post]
+func (h *ProductHandler) CrearProducto(c *gin.Context) {
+	var nuevoProducto domain.Producto
+	if utils.ValidarJSON(c, &nuevoProducto) {
+		return
+	}
+	nuevoProducto.RucEmisor = c.Param("ruc")
+
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.CrearProducto(contexto, &nuevoProducto)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorCreatingProduct) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, nuevoProducto)
+}
+
+// ObtenerProductos godoc
+// @Summary      Listar productos
+// @Description  Devuelve el catálogo de productos del emisor ordenado por código
+// @Tags         products
+// @Produce      json
+// @Param        ruc  path      string  true  "RUC del emisor"
+// @Success      200  {array}   domain.Producto
+// @Failure      500  {object}  errors.AppError
+// @Router       /issuers/{ruc}/products [get]
+func (h *ProductHandler) ObtenerProductos(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	productos, err := h.service.ObtenerProductos(contexto, c.Param("ruc"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingProducts) {
+		return
+	}
+
+	c.JSON(http.StatusOK, productos)
+}
+
+// ObtenerProducto godoc
+// @Summary      Obtener producto
+// @Description  Devuelve un producto del catálogo del emisor por su código interno
+// @Tags         products
+// @Produce      json
+// @Param        ruc     path      string  true  "RUC del emisor"
+// @Param        codigo  path      string  true  "Código interno del producto"
+// @Success      200     {object}  domain.Producto
+// @Failure      404     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers/{ruc}/products/{codigo} [get]
+func (h *ProductHandler) ObtenerProducto(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	producto, err := h.service.ObtenerProducto(contexto, c.Param("ruc"), c.Param("codigo"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorFetchingProduct) {
+		return
+	}
+
+	c.JSON(http.StatusOK, producto)
+}
+
+// ActualizarProducto godoc
+// @Summary      Actualizar producto
+// @Description  Reemplaza los datos de un producto del catálogo. Los documentos ya creados conservan los datos con que se emitieron
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        ruc      path      string           true  "RUC del emisor"
+// @Param        codigo   path      string           true  "Código interno del producto"
+// @Param        product  body      domain.Producto  true  "Datos actualizados"
+// @Success      200      {object}  domain.Producto
+// @Failure      400      {object}  errors.AppError
+// @Failure      404      {object}  errors.AppError
+// @Failure      500      {object}  errors.AppError
+// @Router       /issuers/{ruc}/products/{codigo} [put]
+func (h *ProductHandler) ActualizarProducto(c *gin.Context) {
+	var productoActualizado domain.Producto
+	if utils.ValidarJSON(c, &productoActualizado) {
+		return
+	}
+
+	contexto, cancel := utils.CrearContextoConTimeoutPersonalizado(c, utils.UpdateOperationTimeout)
+	defer cancel()
+
+	err := h.service.ActualizarProducto(contexto, c.Param("ruc"), c.Param("codigo"), &productoActualizado)
+	if utils.ManejarErrorServicio(c, err, utils.ErrorUpdatingProduct) {
+		return
+	}
+
+	c.JSON(http.StatusOK, productoActualizado)
+}
+
+// EliminarProducto godoc
+// @Summary      Eliminar producto
+// @Description  Quita un producto del catálogo del emisor
+// @Tags         products
+// @Produce      json
+// @Param        ruc     path      string  true  "RUC del emisor"
+// @Param        codigo  path      string  true  "Código interno del producto"
+// @Success      200     {object}  map[string]string
+// @Failure      404     {object}  errors.AppError
+// @Failure      500     {object}  errors.AppError
+// @Router       /issuers/{ruc}/products/{codigo} [delete]
+func (h *ProductHandler) EliminarProducto(c *gin.Context) {
+	contexto, cancel := utils.CrearContextoConTimeout(c)
+	defer cancel()
+
+	err := h.service.EliminarProducto(contexto, c.Param("ruc"), c.Param("codigo"))
+	if utils.ManejarErrorServicio(c, err, utils.ErrorDeletingProduct) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": utils.SuccessProductDeleted})
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/service"
+	"ms1-documents/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockProductService struct {
+	creado        *domain.Producto
+	codigoEditado string
+	codigoBorrado string
+}
+
+func (m *mockProductService) CrearProducto(ctx context.Context, producto *domain.Producto) error {
+	if producto.Descripcion == "" {
+		return errors.ErrorValidacion("descripcion es obligatoria")
+	}
+	m.creado = producto
+	return nil
+}
+
+func (m *mockProductService) ObtenerProductos(ctx context.Context, rucEmisor string) ([]domain.Producto, error) {
+	return []domain.Producto{{RucEmisor: rucEmisor, Codigo: "P-001"}}, nil
+}
+
+func (m *mockProductService) ObtenerProducto(ctx context.Context, rucEmisor, codigo string) (*domain.Producto, error) {
+	if codigo != "P-001" {
+		return nil, errors.ErrorNoEncontrado("Producto " + codigo + " no encontrado en el catálogo del emisor " + rucEmisor)
+	}
+	return &domain.Producto{RucEmisor: rucEmisor, Codigo: codigo, UnidadMedida: "KGM"}, nil
+}
+
+func (m *mockProductService) ActualizarProducto(ctx context.Context, rucEmisor, codigo string, producto *domain.Producto) error {
+	m.codigoEditado = codigo
+	return nil
+}
+
+func (m *mockProductService) EliminarProducto(ctx context.Context, rucEmisor, codigo string) error {
+	m.codigoBorrado = codigo
+	return nil
+}
+
+func setupProductRouter(svc service.ProductService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewProductHandler(svc)
+	router := gin.New()
+	router.POST("/issuers/:ruc/products", handler.CrearProducto)
+	router.GET("/issuers/:ruc/products", handler.ObtenerProductos)
+	router.GET("/issuers/:ruc/products/:codigo", handler.ObtenerProducto)
+	router.PUT("/issuers/:ruc/products/:codigo", handler.ActualizarProducto)
+	router.DELETE("/issuers/:ruc/products/:codigo", handler.EliminarProducto)
+	return router
+}
+
+func TestCrearProducto(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"válido", `{"codigo": "P-001", "descripcion": "Arroz extra", "unidadMedida": "KGM", "precioUnitario": 4.20}`, http.StatusCreated},
+		{"sin descripción", `{"codigo": "P-001"}`, http.StatusBadRequest},
+		{"JSON inválido", `{"codigo": `, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockProductService{}
+			router := setupProductRouter(svc)
+
+			req, _ := http.NewRequest("POST", "/issuers/20123456786/products", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusCreated && svc.creado.RucEmisor != "20123456786" {
+				t.Errorf("Expected rucEmisor from path, got %q", svc.creado.RucEmisor)
+			}
+		})
+	}
+}
+
+func TestObtenerProducto(t *testing.T) {
+	router := setupProductRouter(&mockProductService{})
+
+	req, _ := http.NewRequest("GET", "/issuers/20123456786/products/P-001", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var producto domain.Producto
+	if err := json.Unmarshal(w.Body.Bytes(), &producto); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if producto.UnidadMedida != "KGM" {
+		t.Errorf("Expected unidadMedida KGM, got %s", producto.UnidadMedida)
+	}
+
+	req, _ = http.NewRequest("GET", "/issuers/20123456786/products/P-999", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestActualizarYEliminarProducto(t *testing.T) {
+	svc := &mockProductService{}
+	router := setupProductRouter(svc)
+
+	req, _ := http.NewRequest("PUT", "/issuers/20123456786/products/P-001", strings.NewReader(`{"descripcion": "Arroz extra"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || svc.codigoEditado != "P-001" {
+		t.Errorf("Expected status %d for P-001, got %d for %q", http.StatusOK, w.Code, svc.codigoEditado)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/issuers/20123456786/products/P-001", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || svc.codigoBorrado != "P-001" {
+		t.Errorf("Expected status %d for P-001, got %d for %q", http.StatusOK, w.Code, svc.codigoBorrado)
+	}
+}
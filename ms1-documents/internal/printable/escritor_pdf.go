@@ -0,0 +1,135 @@
+package printable
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Dimensiones de una página A4 en puntos
+const (
+	anchoPagina = 595.28
+	altoPagina  = 841.89
+)
+
+// escritorPDF produce un PDF 1.4 mínimo con las fuentes estándar Helvetica, que todo lector trae
+// incorporadas. Las coordenadas que recibe se miden desde la esquina superior izquierda.
+type escritorPDF struct {
+	paginas []*bytes.Buffer
+	actual  *bytes.Buffer
+}
+
+func (e *escritorPDF) nuevaPagina() {
+	e.actual = &bytes.Buffer{}
+	e.paginas = append(e.paginas, e.actual)
+}
+
+func (e *escritorPDF) texto(x, y, tamano float64, negrita bool, contenido string) {
+	fuente := "F1"
+	if negrita {
+		fuente = "F2"
+	}
+	fmt.Fprintf(e.actual, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", fuente, tamano, x, altoPagina-y, cadenaPDF(contenido))
+}
+
+// textoDerecha alinea el texto para que termine en x
+func (e *escritorPDF) textoDerecha(x, y, tamano float64, negrita bool, contenido string) {
+	e.texto(x-anchoTexto(contenido, tamano), y, tamano, negrita, contenido)
+}
+
+func (e *escritorPDF) textoCentrado(x, y, tamano float64, negrita bool, contenido string) {
+	e.texto(x-anchoTexto(contenido, tamano)/2, y, tamano, negrita, contenido)
+}
+
+func (e *escritorPDF) rectangulo(x, y, ancho, alto float64, relleno bool) {
+	operador := "S"
+	if relleno {
+		operador = "f"
+	}
+	fmt.Fprintf(e.actual, "%.2f %.2f %.2f %.2f re %s\n", x, altoPagina-y-alto, ancho, alto, operador)
+}
+
+func (e *escritorPDF) linea(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(e.actual, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, altoPagina-y1, x2, altoPagina-y2)
+}
+
+// bytes serializa el catálogo, las páginas, las fuentes y la tabla de referencias cruzadas
+func (e *escritorPDF) bytes() []byte {
+	var salida bytes.Buffer
+	var desplazamientos []int
+	objeto := func(contenido string) {
+		desplazamientos = append(desplazamientos, salida.Len())
+		fmt.Fprintf(&salida, "%d 0 obj\n%s\nendobj\n", len(desplazamientos), contenido)
+	}
+
+	salida.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// objetos fijos: 1 catálogo, 2 árbol de páginas, 3 y 4 fuentes; luego página y contenido por cada página
+	hijos := make([]string, len(e.paginas))
+	for i := range e.paginas {
+		hijos[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objeto("<< /Type /Catalog /Pages 2 0 R >>")
+	objeto(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(hijos, " "), len(e.paginas)))
+	objeto("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	objeto("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, pagina := range e.paginas {
+		objeto(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", anchoPagina, altoPagina, 6+2*i))
+		objeto(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", pagina.Len(), pagina.String()))
+	}
+
+	inicioXref := salida.Len()
+	fmt.Fprintf(&salida, "xref\n0 %d\n0000000000 65535 f \n", len(desplazamientos)+1)
+	for _, desplazamiento := range desplazamientos {
+		fmt.Fprintf(&salida, "%010d 00000 n \n", desplazamiento)
+	}
+	fmt.Fprintf(&salida, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(desplazamientos)+1, inicioXref)
+	return salida.Bytes()
+}
+
+// cadenaPDF escapa el texto y lo pasa a WinAnsi; los caracteres fuera de Latin-1 salvo el euro se reemplazan por "?"
+func cadenaPDF(texto string) string {
+	var resultado strings.Builder
+	for _, r := range texto {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			resultado.WriteByte('\\')
+			resultado.WriteRune(r)
+		case r < 0x20:
+			resultado.WriteByte(' ')
+		case r < 0x80:
+			resultado.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&resultado, "\\%03o", r)
+		case r == '€':
+			// WinAnsiEncoding ubica el euro en 0x80, fuera del rango Latin-1
+			resultado.WriteString("\\200")
+		default:
+			resultado.WriteByte('?')
+		}
+	}
+	return resultado.String()
+}
+
+// Anchos en milésimas de em de Helvetica para importes y mayúsculas, que bastan para alinear montos
+// y centrar títulos; Helvetica-Bold difiere en pocas letras y el resto se aproxima con el ancho de un dígito
+var anchosHelvetica = map[rune]float64{
+	' ': 278, '.': 278, ',': 278, '/': 278, ':': 278, '-': 333, '(': 333, ')': 333, '%': 889, '°': 400, '€': 556, '$': 556,
+	'0': 556, '1': 556, '2': 556, '3': 556, '4': 556, '5': 556, '6': 556, '7': 556, '8': 556, '9': 556,
+	'A': 667, 'B': 667, 'C': 722, 'D': 722, 'E': 667, 'F': 611, 'G': 778, 'H': 722, 'I': 278, 'J': 500,
+	'K': 667, 'L': 556, 'M': 833, 'N': 722, 'O': 778, 'P': 667, 'Q': 778, 'R': 722, 'S': 667, 'T': 611,
+	'U': 722, 'V': 667, 'W': 944, 'X': 667, 'Y': 667, 'Z': 611, 'Á': 667, 'É': 667, 'Í': 278, 'Ó': 778, 'Ú': 722, 'Ñ': 722,
+}
+
+func anchoTexto(texto string, tamano float64) float64 {
+	total := 0.0
+	for _, r := range texto {
+		ancho, ok := anchosHelvetica[r]
+		if !ok {
+			ancho = 556
+		}
+		total += ancho
+	}
+	return total * tamano / 1000
+}
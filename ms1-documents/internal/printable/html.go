@@ -0,0 +1,41 @@
+package printable
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/qr"
+)
+
+// TamanoQRHTML es el ancho en píxeles con el que se incrusta el código QR
+const TamanoQRHTML = 140
+
+//go:embed plantillas/documento.html
+var plantillaDocumento string
+
+var plantillaHTML = template.Must(template.New("documento").Parse(plantillaDocumento))
+
+// GenerarHTML devuelve la representación impresa como una página HTML autocontenida, con el QR en SVG
+func GenerarHTML(doc *domain.Document) ([]byte, error) {
+	representacion, err := NuevaRepresentacion(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	codigo, err := qr.Codificar([]byte(representacion.CargaQR), NivelCorreccionQR)
+	if err != nil {
+		return nil, err
+	}
+
+	datos := struct {
+		*Representacion
+		QR template.HTML
+	}{representacion, template.HTML(codigo.SVG(TamanoQRHTML))}
+
+	var contenido bytes.Buffer
+	if err := plantillaHTML.Execute(&contenido, datos); err != nil {
+		return nil, err
+	}
+	return contenido.Bytes(), nil
+}
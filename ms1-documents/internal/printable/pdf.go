@@ -0,0 +1,157 @@
+package printable
+
+import (
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/qr"
+	"strconv"
+)
+
+const (
+	margen         = 40.0
+	altoFila       = 14.0
+	tamanoTexto    = 9.0
+	ladoQRPDF      = 96.0
+	maxDescripcion = 62
+)
+
+// Columnas de la tabla de items; las numéricas se alinean a la derecha en la posición indicada
+const (
+	columnaNumero      = margen
+	columnaCantidad    = margen + 60
+	columnaUnidad      = margen + 66
+	columnaDescripcion = margen + 100
+	columnaPrecio      = anchoPagina - margen - 90
+	columnaValorVenta  = anchoPagina - margen
+)
+
+// GenerarPDF devuelve la representación impresa en A4; los items que no entran pasan a páginas siguientes
+func GenerarPDF(doc *domain.Document) ([]byte, error) {
+	representacion, err := NuevaRepresentacion(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	codigo, err := qr.Codificar([]byte(representacion.CargaQR), NivelCorreccionQR)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := &escritorPDF{}
+	pdf.nuevaPagina()
+	y := encabezado(pdf, representacion)
+	y = cabeceraTabla(pdf, y)
+
+	for _, linea := range representacion.Lineas {
+		if y+altoFila > altoPagina-margen {
+			pdf.nuevaPagina()
+			y = cabeceraTabla(pdf, margen)
+		}
+		pdf.texto(columnaNumero, y, tamanoTexto, false, strconv.Itoa(linea.Numero))
+		pdf.textoDerecha(columnaCantidad, y, tamanoTexto, false, linea.Cantidad)
+		pdf.texto(columnaUnidad, y, tamanoTexto, false, linea.Unidad)
+		pdf.texto(columnaDescripcion, y, tamanoTexto, false, recortar(linea.Descripcion, maxDescripcion))
+		pdf.textoDerecha(columnaPrecio, y, tamanoTexto, false, linea.PrecioUnitario)
+		pdf.textoDerecha(columnaValorVenta, y, tamanoTexto, false, linea.ValorVenta)
+		y += altoFila
+	}
+	pdf.linea(margen, y-altoFila+4, anchoPagina-margen, y-altoFila+4)
+
+	altoPie := max(float64(len(representacion.Totales))*altoFila, ladoQRPDF) + 70
+	if y+altoPie > altoPagina-margen {
+		pdf.nuevaPagina()
+		y = margen
+	}
+	pie(pdf, representacion, codigo, y+10)
+
+	return pdf.bytes(), nil
+}
+
+// encabezado dibuja los datos del emisor y receptor a la izquierda y el recuadro con el número a la derecha
+func encabezado(pdf *escritorPDF, r *Representacion) float64 {
+	const anchoRecuadro = 220.0
+	xRecuadro := anchoPagina - margen - anchoRecuadro
+	centro := xRecuadro + anchoRecuadro/2
+
+	pdf.rectangulo(xRecuadro, margen, anchoRecuadro, 70, false)
+	pdf.textoCentrado(centro, margen+20, 11, true, "R.U.C. N° "+r.RucEmisor)
+	pdf.textoCentrado(centro, margen+40, 11, true, r.Titulo)
+	pdf.textoCentrado(centro, margen+60, 11, true, r.IDDocumento)
+
+	y := margen + 10
+	pdf.texto(margen, y, 11, true, r.NombreEmisor)
+	filas := []string{"RUC: " + r.RucEmisor}
+	if r.Direccion != "" {
+		filas = append(filas, r.Direccion)
+	}
+	cliente := "Cliente: " + r.TipoReceptor + " " + r.NumeroReceptor
+	if r.NombreReceptor != "" {
+		cliente += " - " + r.NombreReceptor
+	}
+	filas = append(filas,
+		"Fecha de emisión: "+r.FechaEmision,
+		"Moneda: "+r.Moneda,
+		cliente,
+	)
+	if r.Referencia != "" {
+		filas = append(filas, "Documento que modifica: "+r.Referencia)
+	}
+	if r.Motivo != "" {
+		filas = append(filas, "Motivo: "+r.Motivo)
+	}
+	if r.FormaPago != "" {
+		filas = append(filas, "Forma de pago: "+r.FormaPago)
+	}
+	filas = append(filas, r.Cuotas...)
+	for _, fila := range filas {
+		y += altoFila
+		pdf.texto(margen, y, tamanoTexto, false, fila)
+	}
+	return max(y, margen+70) + 30
+}
+
+func cabeceraTabla(pdf *escritorPDF, y float64) float64 {
+	pdf.texto(columnaNumero, y, tamanoTexto, true, "#")
+	pdf.textoDerecha(columnaCantidad, y, tamanoTexto, true, "Cant.")
+	pdf.texto(columnaUnidad, y, tamanoTexto, true, "Unid.")
+	pdf.texto(columnaDescripcion, y, tamanoTexto, true, "Descripción")
+	pdf.textoDerecha(columnaPrecio, y, tamanoTexto, true, "V. Unitario")
+	pdf.textoDerecha(columnaValorVenta, y, tamanoTexto, true, "Valor Venta")
+	pdf.linea(margen, y+4, anchoPagina-margen, y+4)
+	return y + altoFila + 4
+}
+
+// pie dibuja el QR a la izquierda, los totales a la derecha y debajo el monto en letras y el valor resumen
+func pie(pdf *escritorPDF, r *Representacion, codigo *qr.Codigo, y float64) {
+	modulo := ladoQRPDF / float64(codigo.Tamano())
+	for fila := 0; fila < codigo.Tamano(); fila++ {
+		for columna := 0; columna < codigo.Tamano(); columna++ {
+			if codigo.Modulo(columna, fila) {
+				pdf.rectangulo(margen+float64(columna)*modulo, y+float64(fila)*modulo, modulo, modulo, true)
+			}
+		}
+	}
+
+	yTotales := y + 10
+	for i, total := range r.Totales {
+		negrita := i == len(r.Totales)-1
+		pdf.textoDerecha(columnaPrecio, yTotales, tamanoTexto, negrita, total.Etiqueta)
+		pdf.textoDerecha(columnaValorVenta, yTotales, tamanoTexto, negrita, total.Valor)
+		yTotales += altoFila
+	}
+
+	y = max(y+ladoQRPDF, yTotales) + 20
+	pdf.texto(margen, y, tamanoTexto, true, "SON: "+r.MontoEnLetras)
+	y += 2 * altoFila
+	pdf.texto(margen, y, 8, false, "Representación impresa de la "+r.Titulo)
+	if r.ValorResumen != "" {
+		pdf.texto(margen, y+12, 8, false, "Valor resumen: "+r.ValorResumen)
+	}
+}
+
+func recortar(texto string, longitud int) string {
+	runas := []rune(texto)
+	if len(runas) <= longitud {
+		return texto
+	}
+	return string(runas[:longitud-3]) + "..."
+}
@@ -0,0 +1,234 @@
+package printable
+
+import (
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/letras"
+	"ms1-documents/pkg/money"
+	"ms1-documents/pkg/qr"
+	"strings"
+	"time"
+)
+
+// simbolosMoneda son los prefijos con los que se imprimen los importes según la moneda del documento
+var simbolosMoneda = map[string]string{
+	domain.MonedaPEN: "S/",
+	domain.MonedaUSD: "US$",
+	domain.MonedaEUR: "€",
+}
+
+// NivelCorreccionQR es el nivel que exige SUNAT para el código QR de la representación impresa
+const NivelCorreccionQR = qr.NivelQ
+
+var titulosPorTipo = map[string]string{
+	domain.TipoFactura:     "FACTURA ELECTRÓNICA",
+	domain.TipoBoleta:      "BOLETA DE VENTA ELECTRÓNICA",
+	domain.TipoNotaCredito: "NOTA DE CRÉDITO ELECTRÓNICA",
+	domain.TipoNotaDebito:  "NOTA DE DÉBITO ELECTRÓNICA",
+}
+
+var nombresIdentidad = map[string]string{
+	domain.IdentidadSinDocumento:      "SIN DOCUMENTO",
+	domain.IdentidadDNI:               "DNI",
+	domain.IdentidadCarnetExtranjeria: "CARNET DE EXTRANJERÍA",
+	domain.IdentidadRUC:               "RUC",
+	domain.IdentidadPasaporte:         "PASAPORTE",
+}
+
+// Representacion reúne los datos que muestran las versiones HTML y PDF de un comprobante
+type Representacion struct {
+	Titulo         string
+	IDDocumento    string
+	RucEmisor      string
+	NombreEmisor   string
+	Direccion      string
+	TipoReceptor   string
+	NumeroReceptor string
+	NombreReceptor string
+	FechaEmision   string
+	Moneda         string
+	Referencia     string
+	Motivo         string
+	FormaPago      string
+	Cuotas         []string
+	Lineas         []LineaImpresa
+	Totales        []FilaTotal
+	MontoEnLetras  string
+	CargaQR        string
+	ValorResumen   string
+}
+
+type LineaImpresa struct {
+	Numero         int
+	Cantidad       string
+	Unidad         string
+	Descripcion    string
+	PrecioUnitario string
+	ValorVenta     string
+}
+
+type FilaTotal struct {
+	Etiqueta string
+	Valor    string
+}
+
+// NuevaRepresentacion arma la representación impresa a partir del documento almacenado
+func NuevaRepresentacion(doc *domain.Document) (*Representacion, error) {
+	titulo, ok := titulosPorTipo[doc.TipoDocumento]
+	if !ok {
+		return nil, fmt.Errorf("tipo de documento %q no tiene representación impresa", doc.TipoDocumento)
+	}
+
+	montoEnLetras, err := letras.MontoEnLetras(doc.MontoTotal, doc.CodigoMoneda())
+	if err != nil {
+		return nil, err
+	}
+
+	representacion := &Representacion{
+		Titulo:         titulo,
+		IDDocumento:    doc.IDDocumento,
+		RucEmisor:      doc.RucEmisor,
+		TipoReceptor:   nombreIdentidad(doc.TipoDocumentoReceptor),
+		NumeroReceptor: doc.RucReceptor,
+		FechaEmision:   fechaEmision(doc),
+		Moneda:         descripcionMoneda(doc),
+		Totales:        totales(doc),
+		MontoEnLetras:  montoEnLetras,
+		CargaQR:        CargaQR(doc),
+		ValorResumen:   ValorResumen(doc),
+	}
+	if doc.Receptor != nil {
+		representacion.NombreReceptor = doc.Receptor.Nombre
+	}
+	if doc.Emisor != nil {
+		representacion.NombreEmisor = doc.Emisor.RazonSocial
+		representacion.Direccion = doc.Emisor.Direccion
+	}
+	if doc.Referencia != nil {
+		representacion.Referencia = doc.Referencia.IDDocumento
+		if doc.Referencia.CodigoMotivo != "" {
+			representacion.Motivo = strings.TrimSuffix(doc.Referencia.CodigoMotivo+" - "+doc.Referencia.Sustento, " - ")
+		}
+	}
+
+	if doc.TipoDocumento == domain.TipoFactura || doc.FormaPago != nil {
+		representacion.FormaPago, representacion.Cuotas = formaPago(doc)
+	}
+
+	for indice, item := range doc.Items {
+		representacion.Lineas = append(representacion.Lineas, LineaImpresa{
+			Numero:         indice + 1,
+			Cantidad:       item.Cantidad.StringCantidad(),
+			Unidad:         item.CodigoUnidadMedida(),
+			Descripcion:    item.Descripcion,
+			PrecioUnitario: item.PrecioUnitario.String(),
+			ValorVenta:     importe(item.PrecioTotal),
+		})
+	}
+	return representacion, nil
+}
+
+// CargaQR arma el contenido del código QR definido por SUNAT:
+// RUC|TIPO|SERIE|NUMERO|IGV|TOTAL|FECHA|TIPO DOC. ADQUIRENTE|NUM. DOC. ADQUIRENTE|VALOR RESUMEN|
+func CargaQR(doc *domain.Document) string {
+	serie, numero, _ := strings.Cut(doc.IDDocumento, "-")
+	campos := []string{
+		doc.RucEmisor,
+		doc.TipoDocumento,
+		serie,
+		numero,
+		importe(doc.IgvTotal),
+		importe(doc.MontoTotal),
+		fechaEmision(doc),
+		doc.TipoDocumentoReceptor,
+		doc.RucReceptor,
+		ValorResumen(doc),
+	}
+	return strings.Join(campos, "|") + "|"
+}
+
+// ValorResumen es el DigestValue que MS2 calculó al firmar, el mismo que lleva la firma del XML UBL.
+// Queda vacío si el documento aún no se firmó o se firmó antes de que MS2 guardara el resumen.
+func ValorResumen(doc *domain.Document) string {
+	if doc.Validacion == nil {
+		return ""
+	}
+	return doc.Validacion.DigestValue
+}
+
+func nombreIdentidad(tipo string) string {
+	if nombre, ok := nombresIdentidad[tipo]; ok {
+		return nombre
+	}
+	return tipo
+}
+
+func fechaEmision(doc *domain.Document) string {
+	if fecha, err := time.Parse(time.RFC3339, doc.FechaEmision); err == nil {
+		return fecha.Format("2006-01-02")
+	}
+	return doc.FechaEmision
+}
+
+// descripcionMoneda agrega el tipo de cambio cuando el documento no está en soles
+func descripcionMoneda(doc *domain.Document) string {
+	if doc.CodigoMoneda() == domain.MonedaPEN {
+		return domain.MonedaPEN
+	}
+	return fmt.Sprintf("%s (tipo de cambio %s)", doc.CodigoMoneda(), doc.TipoCambio)
+}
+
+// formaPago describe la forma de pago y, en ventas al crédito, el neto pendiente y el cronograma de cuotas
+func formaPago(doc *domain.Document) (string, []string) {
+	if doc.CodigoFormaPago() == domain.FormaPagoContado {
+		return "Contado", nil
+	}
+
+	simbolo := simbolosMoneda[doc.CodigoMoneda()]
+	descripcion := fmt.Sprintf("Crédito - Monto neto pendiente de pago: %s %s", simbolo, importe(doc.FormaPago.MontoPendiente))
+	cuotas := make([]string, 0, len(doc.FormaPago.Cuotas))
+	for indice, cuota := range doc.FormaPago.Cuotas {
+		cuotas = append(cuotas, fmt.Sprintf("Cuota %d: %s %s, vence %s", indice+1, simbolo, importe(cuota.Monto), cuota.FechaVencimiento))
+	}
+	return descripcion, cuotas
+}
+
+// totales lista los importes del pie; los opcionales solo aparecen si son distintos de cero
+func totales(doc *domain.Document) []FilaTotal {
+	filas := []struct {
+		etiqueta    string
+		valor       money.Money
+		obligatorio bool
+	}{
+		{"Op. Gravadas", doc.TotalGravado, true},
+		{"Op. Exoneradas", doc.TotalExonerado, false},
+		{"Op. Inafectas", doc.TotalInafecto, false},
+		{"Op. Gratuitas", doc.TotalGratuito, false},
+		{"Descuentos", doc.TotalDescuentos, false},
+		{"Cargos", doc.TotalCargos, false},
+		{"ISC", doc.IscTotal, false},
+		{"IGV " + porcentaje(doc.TasaIgv), doc.IgvTotal, true},
+		{"ICBPER", doc.IcbperTotal, false},
+		{"Importe Total", doc.MontoTotal, true},
+	}
+
+	simbolo := simbolosMoneda[doc.CodigoMoneda()]
+	resultado := make([]FilaTotal, 0, len(filas))
+	for _, fila := range filas {
+		if fila.obligatorio || !fila.valor.EsCero() {
+			resultado = append(resultado, FilaTotal{Etiqueta: fila.etiqueta, Valor: simbolo + " " + importe(fila.valor)})
+		}
+	}
+	return resultado
+}
+
+func porcentaje(tasa money.Money) string {
+	if tasa.EsCero() {
+		return ""
+	}
+	return "(" + tasa.MultiplicarEntero(100).Redondear(money.DecimalesMonto, money.RedondeoMitadArriba).String() + "%)"
+}
+
+func importe(valor money.Money) string {
+	return valor.StringFijo(money.DecimalesMonto)
+}
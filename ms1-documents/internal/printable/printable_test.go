@@ -0,0 +1,339 @@
+package printable
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"ms1-documents/pkg/qr"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func facturaDePrueba() *domain.Document {
+	return &domain.Document{
+		IDDocumento:            "F001-00000001",
+		Serie:                  "F001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		FechaEmision:           "2026-02-12T10:00:00-05:00",
+		TotalGravado:           money.MustParse("1000"),
+		MontoTotalSinImpuestos: money.MustParse("1000"),
+		TasaIgv:                money.MustParse("0.18"),
+		IgvTotal:               money.MustParse("180"),
+		MontoTotal:             money.MustParse("1180"),
+		Items: []domain.Item{
+			{Descripcion: "Producto <A> & (B)", PrecioUnitario: money.MustParse("100"), Cantidad: money.NewFromInt(10), PrecioTotal: money.MustParse("1000"), IgvTotal: money.MustParse("180"), TipoAfectacionIgv: domain.AfectacionGravadoOneroso},
+		},
+		Validacion: &domain.Validacion{Firma: "abc123def456", DigestValue: "q1ZbYx3CqjbUuzZ0cHXCDRbTtu6TYsjBwiyxPM1gLdU=", Estado: "VALIDO"},
+	}
+}
+
+func TestCargaQR(t *testing.T) {
+	carga := CargaQR(facturaDePrueba())
+
+	esperado := "20123456786|01|F001|00000001|180.00|1180.00|2026-02-12|6|20987654326|q1ZbYx3CqjbUuzZ0cHXCDRbTtu6TYsjBwiyxPM1gLdU=|"
+	if carga != esperado {
+		t.Errorf("Expected %s, got %s", esperado, carga)
+	}
+}
+
+func TestCargaQR_SinFirma(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Validacion = nil
+
+	if carga := CargaQR(doc); !strings.HasSuffix(carga, "|20987654326||") {
+		t.Errorf("Expected empty summary value, got %s", carga)
+	}
+}
+
+func TestCargaQR_FirmadoSinResumen(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Validacion.DigestValue = ""
+
+	if carga := CargaQR(doc); !strings.HasSuffix(carga, "|20987654326||") {
+		t.Errorf("Expected empty summary value for a document signed without digest, got %s", carga)
+	}
+}
+
+func TestNuevaRepresentacion_Totales(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.TotalDescuentos = money.MustParse("50")
+
+	representacion, err := NuevaRepresentacion(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var etiquetas []string
+	for _, fila := range representacion.Totales {
+		etiquetas = append(etiquetas, fila.Etiqueta)
+	}
+	esperado := "Op. Gravadas,Descuentos,IGV (18.00%),Importe Total"
+	if strings.Join(etiquetas, ",") != esperado {
+		t.Errorf("Expected rows %s, got %s", esperado, strings.Join(etiquetas, ","))
+	}
+
+	if representacion.MontoEnLetras != "MIL CIENTO OCHENTA CON 00/100 SOLES" {
+		t.Errorf("Expected amount in words, got %s", representacion.MontoEnLetras)
+	}
+	if representacion.Titulo != "FACTURA ELECTRÓNICA" || representacion.TipoReceptor != "RUC" {
+		t.Errorf("Expected invoice title and RUC receiver, got %s / %s", representacion.Titulo, representacion.TipoReceptor)
+	}
+}
+
+func TestNuevaRepresentacion_CantidadYUnidad(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Items[0].Cantidad = money.MustParse("2.5")
+	doc.Items[0].UnidadMedida = "KGM"
+
+	representacion, err := NuevaRepresentacion(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	linea := representacion.Lineas[0]
+	if linea.Cantidad != "2.5" || linea.Unidad != "KGM" {
+		t.Errorf("Expected 2.5 KGM, got %s %s", linea.Cantidad, linea.Unidad)
+	}
+}
+
+func TestNuevaRepresentacion_MonedaExtranjera(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Moneda = domain.MonedaUSD
+	doc.TipoCambio = money.MustParse("3.712")
+
+	representacion, err := NuevaRepresentacion(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if representacion.Moneda != "USD (tipo de cambio 3.712)" {
+		t.Errorf("Expected currency with exchange rate, got %s", representacion.Moneda)
+	}
+	if total := representacion.Totales[len(representacion.Totales)-1].Valor; total != "US$ 1180.00" {
+		t.Errorf("Expected US$ 1180.00, got %s", total)
+	}
+	if representacion.MontoEnLetras != "MIL CIENTO OCHENTA CON 00/100 DOLARES AMERICANOS" {
+		t.Errorf("Expected amount in words in dollars, got %s", representacion.MontoEnLetras)
+	}
+}
+
+func TestNuevaRepresentacion_NotaCredito(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.IDDocumento = "F001-00000002"
+	doc.TipoDocumento = domain.TipoNotaCredito
+	doc.Referencia = &domain.DocumentoReferencia{IDDocumento: "F001-00000001", TipoDocumento: domain.TipoFactura, CodigoMotivo: "06", Sustento: "Devolución total"}
+
+	contenido, err := GenerarHTML(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, fragmento := range []string{"NOTA DE CRÉDITO ELECTRÓNICA", "Documento que modifica: F001-00000001", "Motivo: 06 - Devolución total"} {
+		if !strings.Contains(string(contenido), fragmento) {
+			t.Errorf("Expected HTML to contain %q", fragmento)
+		}
+	}
+}
+
+func TestNuevaRepresentacion_FormaPago(t *testing.T) {
+	doc := facturaDePrueba()
+
+	representacion, err := NuevaRepresentacion(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if representacion.FormaPago != "Contado" || len(representacion.Cuotas) != 0 {
+		t.Errorf("Expected cash payment by default, got %q %v", representacion.FormaPago, representacion.Cuotas)
+	}
+
+	doc.FormaPago = &domain.FormaPago{
+		Tipo:           domain.FormaPagoCredito,
+		MontoPendiente: doc.MontoTotal,
+		Cuotas:         []domain.Cuota{{Monto: doc.MontoTotal, FechaVencimiento: "2026-03-14"}},
+	}
+	contenido, err := GenerarHTML(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, fragmento := range []string{"Forma de pago: Crédito - Monto neto pendiente de pago: S/ " + importe(doc.MontoTotal), "Cuota 1: S/ " + importe(doc.MontoTotal) + ", vence 2026-03-14"} {
+		if !strings.Contains(string(contenido), fragmento) {
+			t.Errorf("Expected HTML to contain %q", fragmento)
+		}
+	}
+}
+
+func TestNuevaRepresentacion_Emisor(t *testing.T) {
+	sinEmisor, err := NuevaRepresentacion(facturaDePrueba())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if sinEmisor.NombreEmisor != "" {
+		t.Errorf("Expected empty issuer name without snapshot, got %q", sinEmisor.NombreEmisor)
+	}
+
+	doc := facturaDePrueba()
+	doc.Emisor = &domain.DatosEmisor{RazonSocial: "Comercial Andina S.A.C.", Direccion: "Av. Arequipa 123"}
+	conEmisor, err := NuevaRepresentacion(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if conEmisor.NombreEmisor != "Comercial Andina S.A.C." || conEmisor.Direccion != "Av. Arequipa 123" {
+		t.Errorf("Expected issuer data from the snapshot, got %q, %q", conEmisor.NombreEmisor, conEmisor.Direccion)
+	}
+}
+
+func TestNuevaRepresentacion_TipoInvalido(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.TipoDocumento = "99"
+
+	if _, err := NuevaRepresentacion(doc); err == nil {
+		t.Error("Expected error for unknown document type")
+	}
+}
+
+func TestGenerarHTML(t *testing.T) {
+	contenido, err := GenerarHTML(facturaDePrueba())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	html := string(contenido)
+	for _, fragmento := range []string{
+		"FACTURA ELECTRÓNICA",
+		"F001-00000001",
+		"Producto &lt;A&gt; &amp; (B)",
+		"SON: MIL CIENTO OCHENTA CON 00/100 SOLES",
+		"S/ 1180.00",
+		"<svg xmlns=",
+		"Valor resumen: ",
+	} {
+		if !strings.Contains(html, fragmento) {
+			t.Errorf("Expected HTML to contain %q", fragmento)
+		}
+	}
+}
+
+func TestGenerarPDF_Estructura(t *testing.T) {
+	contenido, err := GenerarPDF(facturaDePrueba())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !bytes.HasPrefix(contenido, []byte("%PDF-1.4")) || !bytes.HasSuffix(contenido, []byte("%%EOF\n")) {
+		t.Fatal("Expected PDF header and trailer")
+	}
+	verificarReferenciasCruzadas(t, contenido)
+
+	for _, fragmento := range []string{"(F001-00000001)", "(Producto <A> & \\(B\\))", "(SON: MIL CIENTO OCHENTA CON 00/100 SOLES)"} {
+		if !bytes.Contains(contenido, []byte(fragmento)) {
+			t.Errorf("Expected PDF to contain %q", fragmento)
+		}
+	}
+}
+
+func TestGenerarPDF_VariasPaginas(t *testing.T) {
+	doc := facturaDePrueba()
+	for i := 0; i < 120; i++ {
+		doc.Items = append(doc.Items, doc.Items[0])
+	}
+
+	contenido, err := GenerarPDF(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	paginas := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(contenido)
+	if paginas == nil || string(paginas[1]) != "3" {
+		t.Errorf("Expected 3 pages, got %s", paginas)
+	}
+	verificarReferenciasCruzadas(t, contenido)
+}
+
+// TestGenerarPDF_LectorExterno abre el PDF con un lector independiente y comprueba el texto
+// y que los módulos del QR dibujados coinciden con los del símbolo
+func TestGenerarPDF_LectorExterno(t *testing.T) {
+	doc := facturaDePrueba()
+	contenido, err := GenerarPDF(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lector, err := pdf.NewReader(bytes.NewReader(contenido), int64(len(contenido)))
+	if err != nil {
+		t.Fatalf("Expected the PDF to be readable, got: %v", err)
+	}
+	if lector.NumPage() != 1 {
+		t.Fatalf("Expected 1 page, got %d", lector.NumPage())
+	}
+
+	pagina := lector.Page(1).Content()
+	var texto strings.Builder
+	for _, fragmento := range pagina.Text {
+		texto.WriteString(fragmento.S)
+	}
+	for _, esperado := range []string{"FACTURA ELECTRÓNICA", "F001-00000001", "Fecha de emisión: 2026-02-12", "Producto <A> & (B)", "S/ 1180.00", "SON: MIL CIENTO OCHENTA CON 00/100 SOLES"} {
+		if !strings.Contains(texto.String(), esperado) {
+			t.Errorf("Expected page text to contain %q", esperado)
+		}
+	}
+
+	codigo, err := qr.Codificar([]byte(CargaQR(doc)), NivelCorreccionQR)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	oscuros := 0
+	for fila := 0; fila < codigo.Tamano(); fila++ {
+		for columna := 0; columna < codigo.Tamano(); columna++ {
+			if codigo.Modulo(columna, fila) {
+				oscuros++
+			}
+		}
+	}
+
+	modulo := ladoQRPDF / float64(codigo.Tamano())
+	dibujados := 0
+	for _, rectangulo := range pagina.Rect {
+		if math.Abs(rectangulo.Max.X-rectangulo.Min.X-modulo) < 0.01 && math.Abs(rectangulo.Max.Y-rectangulo.Min.Y-modulo) < 0.01 {
+			dibujados++
+		}
+	}
+	if dibujados != oscuros {
+		t.Errorf("Expected %d QR modules, got %d", oscuros, dibujados)
+	}
+}
+
+func TestCadenaPDF(t *testing.T) {
+	if texto := cadenaPDF("Emisión (1) \\ 2 → €"); texto != `Emisi\363n \(1\) \\ 2 ? \200` {
+		t.Errorf("Expected escaped WinAnsi text, got %s", texto)
+	}
+}
+
+// verificarReferenciasCruzadas comprueba que cada entrada de la tabla xref apunta al inicio de su objeto
+func verificarReferenciasCruzadas(t *testing.T, contenido []byte) {
+	t.Helper()
+	inicio := bytes.LastIndex(contenido, []byte("startxref\n"))
+	desplazamientoXref, err := strconv.Atoi(strings.Fields(string(contenido[inicio+len("startxref\n"):]))[0])
+	if err != nil || !bytes.HasPrefix(contenido[desplazamientoXref:], []byte("xref\n")) {
+		t.Fatalf("Expected startxref to point to the xref table")
+	}
+
+	lineas := strings.Split(string(contenido[desplazamientoXref:]), "\n")
+	var cantidad int
+	fmt.Sscanf(lineas[1], "0 %d", &cantidad)
+	for objeto := 1; objeto < cantidad; objeto++ {
+		desplazamiento, _ := strconv.Atoi(strings.Fields(lineas[2+objeto])[0])
+		if !bytes.HasPrefix(contenido[desplazamiento:], []byte(fmt.Sprintf("%d 0 obj", objeto))) {
+			t.Errorf("Expected object %d at offset %d", objeto, desplazamiento)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type businessRuleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewBusinessRuleRepository(db *config.Database) BusinessRuleRepository {
+	return &businessRuleRepository{
+		collection: db.DB.Collection(utils.BusinessRulesCollection),
+	}
+}
+
+func reglaNoEncontrada(rucEmisor, nombre string) error {
+	return errors.ErrorNoEncontrado(fmt.Sprintf("Regla %s no encontrada para el emisor %s", nombre, rucEmisor))
+}
+
+func (r *businessRuleRepository) Crear(contexto context.Context, regla *domain.ReglaNegocio) error {
+	_, err := r.collection.InsertOne(contexto, regla)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.ErrorConflicto(fmt.Sprintf("La regla %s ya existe para el emisor %s", regla.Nombre, regla.RucEmisor))
+		}
+		return errors.ErrorInterno("Error al registrar la regla en la base de datos")
+	}
+	return nil
+}
+
+func (r *businessRuleRepository) BuscarPorEmisor(contexto context.Context, rucEmisor string) ([]domain.ReglaNegocio, error) {
+	cursor, err := r.collection.Find(contexto, bson.M{"rucEmisor": rucEmisor}, options.Find().SetSort(bson.D{{Key: "nombre", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener reglas de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var reglas []domain.ReglaNegocio
+	if err = cursor.All(contexto, &reglas); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar reglas")
+	}
+
+	if reglas == nil {
+		reglas = []domain.ReglaNegocio{}
+	}
+
+	return reglas, nil
+}
+
+func (r *businessRuleRepository) BuscarPorNombre(contexto context.Context, rucEmisor, nombre string) (*domain.ReglaNegocio, error) {
+	var regla domain.ReglaNegocio
+	err := r.collection.FindOne(contexto, bson.M{"rucEmisor": rucEmisor, "nombre": nombre}).Decode(&regla)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, reglaNoEncontrada(rucEmisor, nombre)
+		}
+		return nil, errors.ErrorInterno("Error al buscar regla en la base de datos")
+	}
+	return &regla, nil
+}
+
+func (r *businessRuleRepository) Actualizar(contexto context.Context, rucEmisor, nombre string, regla *domain.ReglaNegocio) error {
+	result, err := r.collection.ReplaceOne(contexto, bson.M{"rucEmisor": rucEmisor, "nombre": nombre}, regla)
+	if err != nil {
+		return errors.ErrorInterno("Error al actualizar regla en la base de datos")
+	}
+
+	if result.MatchedCount == 0 {
+		return reglaNoEncontrada(rucEmisor, nombre)
+	}
+
+	return nil
+}
+
+func (r *businessRuleRepository) Eliminar(contexto context.Context, rucEmisor, nombre string) error {
+	result, err := r.collection.DeleteOne(contexto, bson.M{"rucEmisor": rucEmisor, "nombre": nombre})
+	if err != nil {
+		return errors.ErrorInterno("Error al eliminar regla de la base de datos")
+	}
+
+	if result.DeletedCount == 0 {
+		return reglaNoEncontrada(rucEmisor, nombre)
+	}
+
+	return nil
+}
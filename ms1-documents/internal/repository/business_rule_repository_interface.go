@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type BusinessRuleRepository interface {
+	Crear(contexto context.Context, regla *domain.ReglaNegocio) error
+	BuscarPorEmisor(contexto context.Context, rucEmisor string) ([]domain.ReglaNegocio, error)
+	BuscarPorNombre(contexto context.Context, rucEmisor, nombre string) (*domain.ReglaNegocio, error)
+	Actualizar(contexto context.Context, rucEmisor, nombre string, regla *domain.ReglaNegocio) error
+	Eliminar(contexto context.Context, rucEmisor, nombre string) error
+}
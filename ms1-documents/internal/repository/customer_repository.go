@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type customerRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCustomerRepository(db *config.Database) CustomerRepository {
+	return &customerRepository{
+		collection: db.DB.Collection(utils.CustomersCollection),
+	}
+}
+
+// Guardar crea o actualiza el cliente del emisor. La dirección y el correo vacíos no borran los que ya
+// estaban registrados.
+func (r *customerRepository) Guardar(contexto context.Context, cliente *domain.Cliente) error {
+	campos := bson.M{
+		"nombre":             cliente.Nombre,
+		"nombreBusqueda":     cliente.NombreBusqueda,
+		"fechaActualizacion": cliente.FechaActualizacion,
+	}
+	if cliente.Direccion != "" {
+		campos["direccion"] = cliente.Direccion
+	}
+	if cliente.Email != "" {
+		campos["email"] = cliente.Email
+	}
+
+	_, err := r.collection.UpdateOne(
+		contexto,
+		bson.M{"rucEmisor": cliente.RucEmisor, "tipoDocumento": cliente.TipoDocumento, "numeroDocumento": cliente.NumeroDocumento},
+		bson.M{"$set": campos},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al guardar cliente en la base de datos")
+	}
+	return nil
+}
+
+// Buscar devuelve los clientes del emisor cuyo nombre o número de documento empieza con el prefijo,
+// ordenados por nombre. Sin prefijo devuelve los primeros por nombre.
+func (r *customerRepository) Buscar(contexto context.Context, rucEmisor, prefijo string, limite int64) ([]domain.Cliente, error) {
+	filtro := bson.M{"rucEmisor": rucEmisor}
+	if prefijo != "" {
+		filtro["$or"] = bson.A{
+			bson.M{"nombreBusqueda": bson.M{"$regex": "^" + regexp.QuoteMeta(domain.NormalizarBusqueda(prefijo))}},
+			bson.M{"numeroDocumento": bson.M{"$regex": "^" + regexp.QuoteMeta(prefijo)}},
+		}
+	}
+
+	opciones := options.Find().SetSort(bson.D{{Key: "nombreBusqueda", Value: 1}}).SetLimit(limite)
+	cursor, err := r.collection.Find(contexto, filtro, opciones)
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al buscar clientes en la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var clientes []domain.Cliente
+	if err = cursor.All(contexto, &clientes); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar clientes")
+	}
+
+	if clientes == nil {
+		clientes = []domain.Cliente{}
+	}
+
+	return clientes, nil
+}
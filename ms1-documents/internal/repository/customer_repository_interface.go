@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type CustomerRepository interface {
+	Guardar(contexto context.Context, cliente *domain.Cliente) error
+	Buscar(contexto context.Context, rucEmisor, prefijo string, limite int64) ([]domain.Cliente, error)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type dailySummaryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDailySummaryRepository(db *config.Database) DailySummaryRepository {
+	return &dailySummaryRepository{
+		collection: db.DB.Collection(utils.DailySummariesCollection),
+	}
+}
+
+// Guardar asigna al resumen el siguiente número RC del día de generación y lo inserta. Si otra solicitud
+// toma el mismo número, el índice único lo rechaza y se reintenta con el siguiente. Si la que se adelantó
+// guardó un resumen de la misma fechaReferencia con su secuencia, se responde conflicto para armarlo de nuevo.
+func (r *dailySummaryRepository) Guardar(contexto context.Context, resumen *domain.ResumenDiario) error {
+	for intento := 0; intento < maxIntentosNumeracion; intento++ {
+		existentes, err := r.collection.CountDocuments(contexto, bson.M{"rucEmisor": resumen.RucEmisor, "fechaGeneracion": resumen.FechaGeneracion})
+		if err != nil {
+			return errors.ErrorInterno("Error al registrar el resumen diario")
+		}
+
+		resumen.ID = identificadorDiario("RC", resumen.FechaGeneracion, existentes+1)
+		_, err = r.collection.InsertOne(contexto, resumen)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return errors.ErrorInterno("Error al registrar el resumen diario")
+		}
+
+		ocupada, err := r.collection.CountDocuments(contexto, bson.M{
+			"rucEmisor":       resumen.RucEmisor,
+			"fechaReferencia": resumen.FechaReferencia,
+			"secuencia":       resumen.Secuencia,
+		})
+		if err != nil {
+			return errors.ErrorInterno("Error al registrar el resumen diario")
+		}
+		if ocupada > 0 {
+			return errors.ErrorConflicto(fmt.Sprintf("Otro resumen diario del %s se generó al mismo tiempo; reintente", resumen.FechaReferencia))
+		}
+	}
+
+	return errors.ErrorConflicto("No se pudo numerar el resumen diario por solicitudes concurrentes; reintente")
+}
+
+// BuscarPorID busca un resumen por su número. Los números se repiten entre emisores, así que sin rucEmisor
+// solo responde si hay un único resumen con ese número.
+func (r *dailySummaryRepository) BuscarPorID(contexto context.Context, rucEmisor, id string) (*domain.ResumenDiario, error) {
+	filtro := bson.M{"id": id}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+
+	cursor, err := r.collection.Find(contexto, filtro, options.Find().SetLimit(2))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al buscar resumen diario en la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var resumenes []domain.ResumenDiario
+	if err = cursor.All(contexto, &resumenes); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar resumen diario")
+	}
+
+	switch len(resumenes) {
+	case 0:
+		return nil, errors.ErrorNoEncontrado(fmt.Sprintf("Resumen diario %s no encontrado", id))
+	case 1:
+		return &resumenes[0], nil
+	default:
+		return nil, errors.ErrorValidacion(fmt.Sprintf("Varios emisores tienen el resumen %s; indique rucEmisor", id))
+	}
+}
+
+// ListarResumenes devuelve los resúmenes en el orden en que se generaron
+func (r *dailySummaryRepository) ListarResumenes(contexto context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error) {
+	filtro := bson.M{}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+	if fechaReferencia != "" {
+		filtro["fechaReferencia"] = fechaReferencia
+	}
+
+	cursor, err := r.collection.Find(contexto, filtro, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener resúmenes diarios de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var resumenes []domain.ResumenDiario
+	if err = cursor.All(contexto, &resumenes); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar resúmenes diarios")
+	}
+
+	if resumenes == nil {
+		resumenes = []domain.ResumenDiario{}
+	}
+
+	return resumenes, nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type DailySummaryRepository interface {
+	Guardar(contexto context.Context, resumen *domain.ResumenDiario) error
+	BuscarPorID(contexto context.Context, rucEmisor, id string) (*domain.ResumenDiario, error)
+	ListarResumenes(contexto context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error)
+}
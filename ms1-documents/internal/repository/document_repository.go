@@ -2,13 +2,17 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"ms1-documents/internal/config"
 	"ms1-documents/internal/domain"
 	"ms1-documents/internal/utils"
 	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type documentRepository struct {
@@ -51,22 +55,121 @@ func (r *documentRepository) BuscarTodos(contexto context.Context) ([]domain.Doc
 	return documentos, nil
 }
 
-func (r *documentRepository) BuscarPorID(contexto context.Context, id string) (*domain.Document, error) {
-	var documento domain.Document
-	err := r.db.Collection.FindOne(contexto, utils.DocumentIDFilter(id)).Decode(&documento)
+// BuscarPorID busca un documento por su número. Los números se repiten entre emisores, así que sin
+// rucEmisor solo responde si hay un único documento con ese número.
+func (r *documentRepository) BuscarPorID(contexto context.Context, rucEmisor, id string) (*domain.Document, error) {
+	filtro := bson.M{"idDocumento": id}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+
+	cursor, err := r.db.Collection.Find(contexto, filtro, options.Find().SetLimit(2))
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, utils.DocumentNotFoundError(id)
-		}
 		return nil, errors.ErrorInterno("Error al buscar documento en la base de datos")
 	}
-	return &documento, nil
+	defer cursor.Close(contexto)
+
+	var documentos []domain.Document
+	if err = cursor.All(contexto, &documentos); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar documento")
+	}
+
+	switch len(documentos) {
+	case 0:
+		return nil, utils.DocumentNotFoundError(id)
+	case 1:
+		return &documentos[0], nil
+	default:
+		return nil, errors.ErrorValidacion(fmt.Sprintf("Varios emisores tienen el documento %s; indique rucEmisor", id))
+	}
 }
 
-func (r *documentRepository) Actualizar(contexto context.Context, id string, documento *domain.Document) error {
+// BuscarNotasPorReferencia devuelve las notas de crédito y débito del emisor que modifican el comprobante
+func (r *documentRepository) BuscarNotasPorReferencia(contexto context.Context, rucEmisor, idDocumento string) ([]domain.Document, error) {
+	cursor, err := r.db.Collection.Find(contexto, bson.M{"rucEmisor": rucEmisor, "referencia.idDocumento": idDocumento})
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al buscar notas del documento en la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var notas []domain.Document
+	if err = cursor.All(contexto, &notas); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar notas del documento")
+	}
+	return notas, nil
+}
+
+// BuscarBoletasPorFechaEmision devuelve las boletas y las notas que las modifican ya publicadas emitidas el día fecha
+// (AAAA-MM-DD, hora de Perú). Los documentos guardados sin fechaEmisionLocal se buscan un día antes y después
+// por su fechaEmision, que conserva el desfase del cliente; quien llama los filtra con DiaEmision.
+// Sin rucEmisor incluye a todos los emisores.
+func (r *documentRepository) BuscarBoletasPorFechaEmision(contexto context.Context, rucEmisor, fecha string) ([]domain.Document, error) {
+	dia, err := time.Parse("2006-01-02", fecha)
+	if err != nil {
+		return nil, errors.ErrorValidacion("fecha debe tener el formato AAAA-MM-DD")
+	}
+	filtro := bson.M{
+		"estado": bson.M{"$ne": domain.EstadoBorrador},
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"tipoDocumento": domain.TipoBoleta},
+				bson.M{"referencia.tipoDocumento": domain.TipoBoleta},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"fechaEmisionLocal": fecha},
+				bson.M{
+					"fechaEmisionLocal": bson.M{"$exists": false},
+					"fechaEmision":      bson.M{"$gte": dia.AddDate(0, 0, -1).Format("2006-01-02"), "$lt": dia.AddDate(0, 0, 2).Format("2006-01-02")},
+				},
+			}},
+		},
+	}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+
+	cursor, err := r.db.Collection.Find(contexto, filtro, options.Find().SetSort(bson.D{{Key: "idDocumento", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al buscar boletas en la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var boletas []domain.Document
+	if err = cursor.All(contexto, &boletas); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar boletas")
+	}
+	return boletas, nil
+}
+
+// BuscarConCuotasVencidas devuelve los documentos al crédito publicados y no anulados con alguna cuota que
+// venció antes de fecha (AAAA-MM-DD). Sin rucEmisor incluye a todos los emisores.
+func (r *documentRepository) BuscarConCuotasVencidas(contexto context.Context, rucEmisor, fecha string) ([]domain.Document, error) {
+	filtro := bson.M{
+		"formaPago.tipo":                    domain.FormaPagoCredito,
+		"formaPago.cuotas.fechaVencimiento": bson.M{"$lt": fecha},
+		"estado":                            bson.M{"$nin": []string{domain.EstadoBorrador, domain.EstadoAnulado}},
+	}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+
+	cursor, err := r.db.Collection.Find(contexto, filtro, options.Find().SetSort(bson.D{{Key: "idDocumento", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al buscar documentos vencidos en la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var documentos []domain.Document
+	if err = cursor.All(contexto, &documentos); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar documentos vencidos")
+	}
+	return documentos, nil
+}
+
+func (r *documentRepository) Actualizar(contexto context.Context, rucEmisor, id string, documento *domain.Document) error {
 	result, err := r.db.Collection.UpdateOne(
 		contexto,
-		utils.DocumentIDFilter(id),
+		utils.DocumentIDFilter(rucEmisor, id),
 		bson.M{"$set": documento},
 	)
 
@@ -84,8 +187,8 @@ func (r *documentRepository) Actualizar(contexto context.Context, id string, doc
 	return nil
 }
 
-func (r *documentRepository) Eliminar(contexto context.Context, id string) error {
-	result, err := r.db.Collection.DeleteOne(contexto, utils.DocumentIDFilter(id))
+func (r *documentRepository) Eliminar(contexto context.Context, rucEmisor, id string) error {
+	result, err := r.db.Collection.DeleteOne(contexto, utils.DocumentIDFilter(rucEmisor, id))
 	if err != nil {
 		return errors.ErrorInterno("Error al eliminar documento de la base de datos")
 	}
@@ -96,3 +199,78 @@ func (r *documentRepository) Eliminar(contexto context.Context, id string) error
 
 	return nil
 }
+
+// MarcarEmitido registra que el borrador ya se publicó a MS2
+func (r *documentRepository) MarcarEmitido(contexto context.Context, rucEmisor, id string) error {
+	_, err := r.db.Collection.UpdateOne(
+		contexto,
+		bson.M{"rucEmisor": rucEmisor, "idDocumento": id, "estado": domain.EstadoBorrador},
+		bson.M{"$set": bson.M{"estado": domain.EstadoEmitido}},
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al actualizar el estado del documento")
+	}
+	return nil
+}
+
+// Anular pasa a ANULADO un documento emitido. La condición sobre el estado evita anular dos veces
+// el mismo documento con solicitudes concurrentes.
+func (r *documentRepository) Anular(contexto context.Context, rucEmisor, id string, anulacion domain.Anulacion) error {
+	result, err := r.db.Collection.UpdateOne(
+		contexto,
+		bson.M{"rucEmisor": rucEmisor, "idDocumento": id, "estado": bson.M{"$nin": []string{domain.EstadoBorrador, domain.EstadoAnulado}}},
+		bson.M{"$set": bson.M{"estado": domain.EstadoAnulado, "anulacion": anulacion}},
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al anular documento en la base de datos")
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.ErrorConflicto(fmt.Sprintf("El documento %s no está emitido o ya fue anulado", id))
+	}
+	return nil
+}
+
+// RevertirAnulacion deja el documento emitido cuando no se pudo registrar su comunicación de baja
+func (r *documentRepository) RevertirAnulacion(contexto context.Context, rucEmisor, id string) error {
+	_, err := r.db.Collection.UpdateOne(
+		contexto,
+		bson.M{"rucEmisor": rucEmisor, "idDocumento": id, "estado": domain.EstadoAnulado},
+		bson.M{"$set": bson.M{"estado": domain.EstadoEmitido}, "$unset": bson.M{"anulacion": ""}},
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al revertir la anulación del documento")
+	}
+	return nil
+}
+
+// RegistrarCredito suma monto al crédito acumulado del comprobante solo si no supera su montoTotal, en una
+// única operación para que dos notas simultáneas no excedan el tope. Los comprobantes anteriores al campo
+// parten de acreditadoAnterior, el total de sus notas. rechazado, el crédito de notas que MS2 marcó inválidas,
+// sigue acumulado pero no cuenta para el tope. Devuelve false si el crédito no cabe.
+func (r *documentRepository) RegistrarCredito(contexto context.Context, rucEmisor, id string, monto, acreditadoAnterior, rechazado money.Money) (bool, error) {
+	acreditado := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$totalAcreditado", acreditadoAnterior}}, monto}}
+	vigente := bson.M{"$subtract": bson.A{acreditado, rechazado}}
+	result, err := r.db.Collection.UpdateOne(
+		contexto,
+		bson.M{"rucEmisor": rucEmisor, "idDocumento": id, "$expr": bson.M{"$lte": bson.A{vigente, "$montoTotal"}}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"totalAcreditado": acreditado}}}},
+	)
+	if err != nil {
+		return false, errors.ErrorInterno("Error al registrar el crédito del documento")
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// LiberarCredito descuenta del crédito acumulado el importe de una nota que no llegó a guardarse o dejó de contar
+func (r *documentRepository) LiberarCredito(contexto context.Context, rucEmisor, id string, monto money.Money) error {
+	_, err := r.db.Collection.UpdateOne(
+		contexto,
+		bson.M{"rucEmisor": rucEmisor, "idDocumento": id, "totalAcreditado": bson.M{"$exists": true}},
+		bson.M{"$inc": bson.M{"totalAcreditado": money.Cero().Restar(monto)}},
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al liberar el crédito del documento")
+	}
+	return nil
+}
@@ -3,12 +3,21 @@ package repository
 import (
 	"context"
 	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
 )
 
 type DocumentRepository interface {
 	Crear(contexto context.Context, documento *domain.Document) error
 	BuscarTodos(contexto context.Context) ([]domain.Document, error)
-	BuscarPorID(contexto context.Context, id string) (*domain.Document, error)
-	Actualizar(contexto context.Context, id string, documento *domain.Document) error
-	Eliminar(contexto context.Context, id string) error
+	BuscarPorID(contexto context.Context, rucEmisor, id string) (*domain.Document, error)
+	BuscarNotasPorReferencia(contexto context.Context, rucEmisor, idDocumento string) ([]domain.Document, error)
+	BuscarBoletasPorFechaEmision(contexto context.Context, rucEmisor, fecha string) ([]domain.Document, error)
+	BuscarConCuotasVencidas(contexto context.Context, rucEmisor, fecha string) ([]domain.Document, error)
+	Actualizar(contexto context.Context, rucEmisor, id string, documento *domain.Document) error
+	Eliminar(contexto context.Context, rucEmisor, id string) error
+	MarcarEmitido(contexto context.Context, rucEmisor, id string) error
+	Anular(contexto context.Context, rucEmisor, id string, anulacion domain.Anulacion) error
+	RevertirAnulacion(contexto context.Context, rucEmisor, id string) error
+	RegistrarCredito(contexto context.Context, rucEmisor, id string, monto, acreditadoAnterior, rechazado money.Money) (bool, error)
+	LiberarCredito(contexto context.Context, rucEmisor, id string, monto money.Money) error
 }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/exchange"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type exchangeRateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewExchangeRateRepository(db *config.Database) ExchangeRateRepository {
+	return &exchangeRateRepository{
+		collection: db.DB.Collection(utils.ExchangeRatesCollection),
+	}
+}
+
+// Registrar agrega o reemplaza las cotizaciones por moneda y fecha en una sola escritura; si alguna
+// es inválida no se guarda ninguna
+func (r *exchangeRateRepository) Registrar(contexto context.Context, tiposCambio []exchange.TipoCambioDiario) error {
+	normalizados, err := exchange.Normalizar(tiposCambio)
+	if err != nil {
+		return errors.ErrorValidacion(err.Error())
+	}
+	if len(normalizados) == 0 {
+		return nil
+	}
+
+	escrituras := make([]mongo.WriteModel, 0, len(normalizados))
+	for _, tipoCambio := range normalizados {
+		escrituras = append(escrituras, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"moneda": tipoCambio.Moneda, "fecha": tipoCambio.Fecha}).
+			SetReplacement(tipoCambio).
+			SetUpsert(true))
+	}
+
+	if _, err := r.collection.BulkWrite(contexto, escrituras); err != nil {
+		return errors.ErrorInterno("Error al registrar tipos de cambio en la base de datos")
+	}
+	return nil
+}
+
+// TipoCambio busca la última cotización publicada dentro de la ventana de vigencia de la fecha
+func (r *exchangeRateRepository) TipoCambio(contexto context.Context, moneda string, fecha time.Time) (money.Money, error) {
+	desde, hasta := exchange.VentanaVigencia(fecha)
+	var tipoCambio exchange.TipoCambioDiario
+	err := r.collection.FindOne(
+		contexto,
+		bson.M{"moneda": moneda, "fecha": bson.M{"$gte": desde, "$lte": hasta}},
+		options.FindOne().SetSort(bson.D{{Key: "fecha", Value: -1}}),
+	).Decode(&tipoCambio)
+	if err == mongo.ErrNoDocuments {
+		return money.Money{}, exchange.ErrorSinTipoCambio(moneda, hasta)
+	}
+	if err != nil {
+		return money.Money{}, errors.ErrorInterno("Error al consultar el tipo de cambio en la base de datos")
+	}
+	return tipoCambio.TipoCambio, nil
+}
+
+func (r *exchangeRateRepository) Listar(contexto context.Context, moneda string) ([]exchange.TipoCambioDiario, error) {
+	filtro := bson.M{}
+	if moneda != "" {
+		filtro["moneda"] = moneda
+	}
+
+	cursor, err := r.collection.Find(contexto, filtro, options.Find().SetSort(bson.D{{Key: "moneda", Value: 1}, {Key: "fecha", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener tipos de cambio de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var tiposCambio []exchange.TipoCambioDiario
+	if err = cursor.All(contexto, &tiposCambio); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar tipos de cambio")
+	}
+
+	if tiposCambio == nil {
+		tiposCambio = []exchange.TipoCambioDiario{}
+	}
+
+	return tiposCambio, nil
+}
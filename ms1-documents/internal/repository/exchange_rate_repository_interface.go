@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/exchange"
+	"ms1-documents/pkg/money"
+	"time"
+)
+
+// ExchangeRateRepository guarda los tipos de cambio para que sobrevivan a los reinicios y todas las
+// réplicas resuelvan la misma cotización. También es el exchange.ProveedorTipoCambio del validador.
+type ExchangeRateRepository interface {
+	Registrar(contexto context.Context, tiposCambio []exchange.TipoCambioDiario) error
+	TipoCambio(contexto context.Context, moneda string, fecha time.Time) (money.Money, error)
+	Listar(contexto context.Context, moneda string) ([]exchange.TipoCambioDiario, error)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type issuerRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIssuerRepository(db *config.Database) IssuerRepository {
+	return &issuerRepository{
+		collection: db.DB.Collection(utils.IssuersCollection),
+	}
+}
+
+func emisorNoEncontrado(ruc string) error {
+	return errors.ErrorNoEncontrado(fmt.Sprintf("Emisor con RUC %s no encontrado", ruc))
+}
+
+func (r *issuerRepository) Crear(contexto context.Context, emisor *domain.Emisor) error {
+	_, err := r.collection.InsertOne(contexto, emisor)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.ErrorConflicto(fmt.Sprintf("El emisor con RUC %s ya está registrado", emisor.RUC))
+		}
+		return errors.ErrorInterno("Error al registrar el emisor en la base de datos")
+	}
+	return nil
+}
+
+func (r *issuerRepository) BuscarTodos(contexto context.Context) ([]domain.Emisor, error) {
+	cursor, err := r.collection.Find(contexto, bson.M{}, options.Find().SetSort(bson.D{{Key: "ruc", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener emisores de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var emisores []domain.Emisor
+	if err = cursor.All(contexto, &emisores); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar emisores")
+	}
+
+	if emisores == nil {
+		emisores = []domain.Emisor{}
+	}
+
+	return emisores, nil
+}
+
+func (r *issuerRepository) BuscarPorRUC(contexto context.Context, ruc string) (*domain.Emisor, error) {
+	var emisor domain.Emisor
+	err := r.collection.FindOne(contexto, bson.M{"ruc": ruc}).Decode(&emisor)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, emisorNoEncontrado(ruc)
+		}
+		return nil, errors.ErrorInterno("Error al buscar emisor en la base de datos")
+	}
+	return &emisor, nil
+}
+
+func (r *issuerRepository) Actualizar(contexto context.Context, ruc string, emisor *domain.Emisor) error {
+	result, err := r.collection.ReplaceOne(contexto, bson.M{"ruc": ruc}, emisor)
+	if err != nil {
+		return errors.ErrorInterno("Error al actualizar emisor en la base de datos")
+	}
+
+	if result.MatchedCount == 0 {
+		return emisorNoEncontrado(ruc)
+	}
+
+	return nil
+}
+
+func (r *issuerRepository) Eliminar(contexto context.Context, ruc string) error {
+	result, err := r.collection.DeleteOne(contexto, bson.M{"ruc": ruc})
+	if err != nil {
+		return errors.ErrorInterno("Error al eliminar emisor de la base de datos")
+	}
+
+	if result.DeletedCount == 0 {
+		return emisorNoEncontrado(ruc)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type IssuerRepository interface {
+	Crear(contexto context.Context, emisor *domain.Emisor) error
+	BuscarTodos(contexto context.Context) ([]domain.Emisor, error)
+	BuscarPorRUC(contexto context.Context, ruc string) (*domain.Emisor, error)
+	Actualizar(contexto context.Context, ruc string, emisor *domain.Emisor) error
+	Eliminar(contexto context.Context, ruc string) error
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type productRepository struct {
+	collection *mongo.Collection
+}
+
+func NewProductRepository(db *config.Database) ProductRepository {
+	return &productRepository{
+		collection: db.DB.Collection(utils.ProductsCollection),
+	}
+}
+
+func productoNoEncontrado(rucEmisor, codigo string) error {
+	return errors.ErrorNoEncontrado(fmt.Sprintf("Producto %s no encontrado en el catálogo del emisor %s", codigo, rucEmisor))
+}
+
+func (r *productRepository) Crear(contexto context.Context, producto *domain.Producto) error {
+	_, err := r.collection.InsertOne(contexto, producto)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.ErrorConflicto(fmt.Sprintf("El producto %s ya existe en el catálogo del emisor %s", producto.Codigo, producto.RucEmisor))
+		}
+		return errors.ErrorInterno("Error al registrar el producto en la base de datos")
+	}
+	return nil
+}
+
+func (r *productRepository) BuscarPorEmisor(contexto context.Context, rucEmisor string) ([]domain.Producto, error) {
+	cursor, err := r.collection.Find(contexto, bson.M{"rucEmisor": rucEmisor}, options.Find().SetSort(bson.D{{Key: "codigo", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener productos de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var productos []domain.Producto
+	if err = cursor.All(contexto, &productos); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar productos")
+	}
+
+	if productos == nil {
+		productos = []domain.Producto{}
+	}
+
+	return productos, nil
+}
+
+func (r *productRepository) BuscarPorCodigo(contexto context.Context, rucEmisor, codigo string) (*domain.Producto, error) {
+	var producto domain.Producto
+	err := r.collection.FindOne(contexto, bson.M{"rucEmisor": rucEmisor, "codigo": codigo}).Decode(&producto)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, productoNoEncontrado(rucEmisor, codigo)
+		}
+		return nil, errors.ErrorInterno("Error al buscar producto en la base de datos")
+	}
+	return &producto, nil
+}
+
+func (r *productRepository) Actualizar(contexto context.Context, rucEmisor, codigo string, producto *domain.Producto) error {
+	result, err := r.collection.ReplaceOne(contexto, bson.M{"rucEmisor": rucEmisor, "codigo": codigo}, producto)
+	if err != nil {
+		return errors.ErrorInterno("Error al actualizar producto en la base de datos")
+	}
+
+	if result.MatchedCount == 0 {
+		return productoNoEncontrado(rucEmisor, codigo)
+	}
+
+	return nil
+}
+
+func (r *productRepository) Eliminar(contexto context.Context, rucEmisor, codigo string) error {
+	result, err := r.collection.DeleteOne(contexto, bson.M{"rucEmisor": rucEmisor, "codigo": codigo})
+	if err != nil {
+		return errors.ErrorInterno("Error al eliminar producto de la base de datos")
+	}
+
+	if result.DeletedCount == 0 {
+		return productoNoEncontrado(rucEmisor, codigo)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type ProductRepository interface {
+	Crear(contexto context.Context, producto *domain.Producto) error
+	BuscarPorEmisor(contexto context.Context, rucEmisor string) ([]domain.Producto, error)
+	BuscarPorCodigo(contexto context.Context, rucEmisor, codigo string) (*domain.Producto, error)
+	Actualizar(contexto context.Context, rucEmisor, codigo string, producto *domain.Producto) error
+	Eliminar(contexto context.Context, rucEmisor, codigo string) error
+}
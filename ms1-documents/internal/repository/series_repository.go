@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type seriesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSeriesRepository(db *config.Database) SeriesRepository {
+	return &seriesRepository{
+		collection: db.DB.Collection(utils.SeriesCollection),
+	}
+}
+
+func idSerie(rucEmisor, serie string) string {
+	return rucEmisor + "-" + serie
+}
+
+// ReservarCorrelativo incrementa de forma atómica el contador de la serie y entrega el número.
+// Nunca reasigna números liberados: saldrían con fecha posterior a otros mayores de la serie.
+func (r *seriesRepository) ReservarCorrelativo(contexto context.Context, rucEmisor, serie string) (int64, error) {
+	var contador domain.SerieCorrelativo
+	err := r.collection.FindOneAndUpdate(
+		contexto,
+		bson.M{"_id": idSerie(rucEmisor, serie)},
+		bson.M{
+			"$inc":         bson.M{"ultimoCorrelativo": 1},
+			"$setOnInsert": bson.M{"rucEmisor": rucEmisor, "serie": serie},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&contador)
+	if err != nil {
+		return 0, errors.ErrorInterno("Error al reservar correlativo")
+	}
+
+	if contador.UltimoCorrelativo > domain.CorrelativoMaximo {
+		if err := r.LiberarCorrelativo(contexto, rucEmisor, serie, contador.UltimoCorrelativo); err != nil && config.Logger != nil {
+			config.Logger.Error("No se pudo devolver el contador de una serie agotada",
+				zap.String("rucEmisor", rucEmisor),
+				zap.String("serie", serie),
+				zap.Int64("correlativo", contador.UltimoCorrelativo),
+				zap.Error(err),
+			)
+		}
+		return 0, errors.ErrorConflicto(fmt.Sprintf("La serie %s agotó sus correlativos", serie))
+	}
+
+	return contador.UltimoCorrelativo, nil
+}
+
+// LiberarCorrelativo devuelve un número reservado que no llegó a usarse. Solo si sigue siendo el
+// último se retrocede el contador; si no, queda anotado en liberados y la serie conserva el hueco.
+func (r *seriesRepository) LiberarCorrelativo(contexto context.Context, rucEmisor, serie string, correlativo int64) error {
+	resultado, err := r.collection.UpdateOne(
+		contexto,
+		bson.M{"_id": idSerie(rucEmisor, serie), "ultimoCorrelativo": correlativo},
+		bson.M{"$inc": bson.M{"ultimoCorrelativo": -1}},
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al liberar correlativo")
+	}
+	if resultado.MatchedCount > 0 {
+		return nil
+	}
+
+	_, err = r.collection.UpdateOne(
+		contexto,
+		bson.M{"_id": idSerie(rucEmisor, serie)},
+		bson.M{"$push": bson.M{"liberados": bson.M{"$each": []int64{correlativo}, "$sort": 1}}},
+	)
+	if err != nil {
+		return errors.ErrorInterno("Error al liberar correlativo")
+	}
+	return nil
+}
+
+// RegistrarCorrelativo acepta un número enviado por el cliente si no salta la numeración y avanza
+// el contador cuando es el siguiente. Devuelve si el número se tomó así, para liberarlo si la
+// creación falla; uno menor que el contador se acepta sin tomarlo y la inserción decide si ya está
+// en uso.
+func (r *seriesRepository) RegistrarCorrelativo(contexto context.Context, rucEmisor, serie string, correlativo int64) (bool, error) {
+	if correlativo < 1 || correlativo > domain.CorrelativoMaximo {
+		return false, errors.ErrorValidacion(fmt.Sprintf("El correlativo de la serie %s debe estar entre 1 y %d", serie, domain.CorrelativoMaximo))
+	}
+
+	_, err := r.collection.UpdateOne(
+		contexto,
+		bson.M{"_id": idSerie(rucEmisor, serie)},
+		bson.M{"$setOnInsert": bson.M{"rucEmisor": rucEmisor, "serie": serie, "ultimoCorrelativo": int64(0)}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, errors.ErrorInterno("Error al registrar correlativo")
+	}
+
+	resultado, err := r.collection.UpdateOne(
+		contexto,
+		bson.M{"_id": idSerie(rucEmisor, serie), "ultimoCorrelativo": correlativo - 1},
+		bson.M{"$inc": bson.M{"ultimoCorrelativo": 1}},
+	)
+	if err != nil {
+		return false, errors.ErrorInterno("Error al registrar correlativo")
+	}
+	if resultado.MatchedCount > 0 {
+		return true, nil
+	}
+
+	var contador domain.SerieCorrelativo
+	if err := r.collection.FindOne(contexto, bson.M{"_id": idSerie(rucEmisor, serie)}).Decode(&contador); err != nil {
+		return false, errors.ErrorInterno("Error al registrar correlativo")
+	}
+	if correlativo > contador.SiguienteCorrelativo() {
+		return false, errors.ErrorValidacion(fmt.Sprintf("El correlativo %d salta la numeración de la serie %s; el siguiente es %d", correlativo, serie, contador.SiguienteCorrelativo()))
+	}
+	return false, nil
+}
+
+func (r *seriesRepository) ListarSeries(contexto context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error) {
+	filtro := bson.M{}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+
+	cursor, err := r.collection.Find(contexto, filtro, options.Find().SetSort(bson.D{{Key: "rucEmisor", Value: 1}, {Key: "serie", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener series de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var series []domain.SerieCorrelativo
+	if err = cursor.All(contexto, &series); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar series")
+	}
+
+	if series == nil {
+		series = []domain.SerieCorrelativo{}
+	}
+
+	return series, nil
+}
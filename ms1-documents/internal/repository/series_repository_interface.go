@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type SeriesRepository interface {
+	ReservarCorrelativo(contexto context.Context, rucEmisor, serie string) (int64, error)
+	LiberarCorrelativo(contexto context.Context, rucEmisor, serie string, correlativo int64) error
+	RegistrarCorrelativo(contexto context.Context, rucEmisor, serie string, correlativo int64) (bool, error)
+	ListarSeries(contexto context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/utils"
+	"ms1-documents/pkg/errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxIntentosNumeracion limita los reintentos cuando otra solicitud numera un resumen del mismo día al mismo tiempo
+const maxIntentosNumeracion = 5
+
+// identificadorDiario arma el número de los resúmenes de SUNAT: prefijo, fecha de generación sin guiones y secuencia del día
+func identificadorDiario(prefijo, fechaGeneracion string, secuencia int64) string {
+	return fmt.Sprintf("%s-%s-%d", prefijo, strings.ReplaceAll(fechaGeneracion, "-", ""), secuencia)
+}
+
+type voidSummaryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewVoidSummaryRepository(db *config.Database) VoidSummaryRepository {
+	return &voidSummaryRepository{
+		collection: db.DB.Collection(utils.VoidSummariesCollection),
+	}
+}
+
+// AgregarItem suma el documento a la comunicación del emisor para el día y la fecha de emisión, o crea
+// la siguiente RA del día si aún no existe. Los índices únicos resuelven las creaciones concurrentes:
+// quien pierde reintenta y agrega el item a la comunicación creada por el otro.
+func (r *voidSummaryRepository) AgregarItem(contexto context.Context, rucEmisor, fechaGeneracion, fechaReferencia string, item domain.ItemBaja) (*domain.ComunicacionBaja, error) {
+	filtro := bson.M{"rucEmisor": rucEmisor, "fechaGeneracion": fechaGeneracion, "fechaReferencia": fechaReferencia}
+
+	for intento := 0; intento < maxIntentosNumeracion; intento++ {
+		var comunicacion domain.ComunicacionBaja
+		err := r.collection.FindOneAndUpdate(
+			contexto,
+			filtro,
+			bson.M{"$push": bson.M{"items": item}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&comunicacion)
+		if err == nil {
+			return &comunicacion, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, errors.ErrorInterno("Error al registrar la comunicación de baja")
+		}
+
+		existentes, err := r.collection.CountDocuments(contexto, bson.M{"rucEmisor": rucEmisor, "fechaGeneracion": fechaGeneracion})
+		if err != nil {
+			return nil, errors.ErrorInterno("Error al registrar la comunicación de baja")
+		}
+
+		comunicacion = domain.ComunicacionBaja{
+			ID:              identificadorDiario("RA", fechaGeneracion, existentes+1),
+			RucEmisor:       rucEmisor,
+			FechaGeneracion: fechaGeneracion,
+			FechaReferencia: fechaReferencia,
+			Items:           []domain.ItemBaja{item},
+		}
+		_, err = r.collection.InsertOne(contexto, comunicacion)
+		if err == nil {
+			return &comunicacion, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, errors.ErrorInterno("Error al registrar la comunicación de baja")
+		}
+	}
+
+	return nil, errors.ErrorConflicto("No se pudo registrar la comunicación de baja por solicitudes concurrentes; reintente")
+}
+
+func (r *voidSummaryRepository) ListarComunicaciones(contexto context.Context, rucEmisor, fechaGeneracion string) ([]domain.ComunicacionBaja, error) {
+	filtro := bson.M{}
+	if rucEmisor != "" {
+		filtro["rucEmisor"] = rucEmisor
+	}
+	if fechaGeneracion != "" {
+		filtro["fechaGeneracion"] = fechaGeneracion
+	}
+
+	cursor, err := r.collection.Find(contexto, filtro, options.Find().SetSort(bson.D{{Key: "fechaGeneracion", Value: -1}, {Key: "rucEmisor", Value: 1}, {Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, errors.ErrorInterno("Error al obtener comunicaciones de baja de la base de datos")
+	}
+	defer cursor.Close(contexto)
+
+	var comunicaciones []domain.ComunicacionBaja
+	if err = cursor.All(contexto, &comunicaciones); err != nil {
+		return nil, errors.ErrorInterno("Error al decodificar comunicaciones de baja")
+	}
+
+	if comunicaciones == nil {
+		comunicaciones = []domain.ComunicacionBaja{}
+	}
+
+	return comunicaciones, nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type VoidSummaryRepository interface {
+	AgregarItem(contexto context.Context, rucEmisor, fechaGeneracion, fechaReferencia string, item domain.ItemBaja) (*domain.ComunicacionBaja, error)
+	ListarComunicaciones(contexto context.Context, rucEmisor, fechaGeneracion string) ([]domain.ComunicacionBaja, error)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/repository"
+	"ms1-documents/internal/validator"
+	"ms1-documents/pkg/errors"
+	"strings"
+	"time"
+)
+
+type businessRuleService struct {
+	repo      repository.BusinessRuleRepository
+	validator *validator.DocumentValidator
+}
+
+func NewBusinessRuleService(repo repository.BusinessRuleRepository, validator *validator.DocumentValidator) BusinessRuleService {
+	return &businessRuleService{
+		repo:      repo,
+		validator: validator,
+	}
+}
+
+func (s *businessRuleService) CrearRegla(contexto context.Context, regla *domain.ReglaNegocio) error {
+	if err := s.validator.ValidarReglaNegocio(regla); err != nil {
+		return err
+	}
+
+	ahora := time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	regla.FechaCreacion = ahora
+	regla.FechaActualizacion = ahora
+	return s.repo.Crear(contexto, regla)
+}
+
+func (s *businessRuleService) ObtenerReglas(contexto context.Context, rucEmisor string) ([]domain.ReglaNegocio, error) {
+	return s.repo.BuscarPorEmisor(contexto, rucEmisor)
+}
+
+func (s *businessRuleService) ObtenerRegla(contexto context.Context, rucEmisor, nombre string) (*domain.ReglaNegocio, error) {
+	return s.repo.BuscarPorNombre(contexto, rucEmisor, nombre)
+}
+
+func (s *businessRuleService) ActualizarRegla(contexto context.Context, rucEmisor, nombre string, regla *domain.ReglaNegocio) error {
+	if strings.TrimSpace(regla.Nombre) == "" {
+		regla.Nombre = nombre
+	}
+	if strings.ToLower(strings.TrimSpace(regla.Nombre)) != nombre {
+		return errors.ErrorValidacion(fmt.Sprintf("No se puede cambiar el nombre de la regla %s", nombre))
+	}
+	regla.RucEmisor = rucEmisor
+
+	if err := s.validator.ValidarReglaNegocio(regla); err != nil {
+		return err
+	}
+
+	existente, err := s.repo.BuscarPorNombre(contexto, rucEmisor, nombre)
+	if err != nil {
+		return err
+	}
+
+	regla.FechaCreacion = existente.FechaCreacion
+	regla.FechaActualizacion = time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	return s.repo.Actualizar(contexto, rucEmisor, nombre, regla)
+}
+
+func (s *businessRuleService) EliminarRegla(contexto context.Context, rucEmisor, nombre string) error {
+	return s.repo.Eliminar(contexto, rucEmisor, nombre)
+}
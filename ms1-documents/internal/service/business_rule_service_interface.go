@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type BusinessRuleService interface {
+	CrearRegla(contexto context.Context, regla *domain.ReglaNegocio) error
+	ObtenerReglas(contexto context.Context, rucEmisor string) ([]domain.ReglaNegocio, error)
+	ObtenerRegla(contexto context.Context, rucEmisor, nombre string) (*domain.ReglaNegocio, error)
+	ActualizarRegla(contexto context.Context, rucEmisor, nombre string, regla *domain.ReglaNegocio) error
+	EliminarRegla(contexto context.Context, rucEmisor, nombre string) error
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/repository"
+	"ms1-documents/pkg/errors"
+	"strings"
+)
+
+const limiteBusquedaClientes = 20
+
+type customerService struct {
+	repo repository.CustomerRepository
+}
+
+func NewCustomerService(repo repository.CustomerRepository) CustomerService {
+	return &customerService{
+		repo: repo,
+	}
+}
+
+func (s *customerService) BuscarClientes(contexto context.Context, rucEmisor, consulta string) ([]domain.Cliente, error) {
+	rucEmisor = strings.TrimSpace(rucEmisor)
+	if rucEmisor == "" {
+		return nil, errors.ErrorValidacion("rucEmisor es obligatorio")
+	}
+
+	return s.repo.Buscar(contexto, rucEmisor, strings.TrimSpace(consulta), limiteBusquedaClientes)
+}
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type CustomerService interface {
+	BuscarClientes(contexto context.Context, rucEmisor, consulta string) ([]domain.Cliente, error)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/repository"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const maxIntentosResumen = 3
+
+type dailySummaryService struct {
+	documentos repository.DocumentRepository
+	resumenes  repository.DailySummaryRepository
+}
+
+func NewDailySummaryService(documentos repository.DocumentRepository, resumenes repository.DailySummaryRepository) DailySummaryService {
+	return &dailySummaryService{
+		documentos: documentos,
+		resumenes:  resumenes,
+	}
+}
+
+func (s *dailySummaryService) GenerarResumenes(contexto context.Context, fechaReferencia, rucEmisor string) ([]domain.ResumenDiario, error) {
+	_, err := time.Parse(formatoFecha, fechaReferencia)
+	if err != nil {
+		return nil, errors.ErrorValidacion("fecha debe tener el formato AAAA-MM-DD")
+	}
+
+	ahora := time.Now().In(domain.ZonaHorariaPeru)
+	if fechaReferencia > ahora.Format(formatoFecha) {
+		return nil, errors.ErrorValidacion("No se puede generar el resumen diario de una fecha futura")
+	}
+
+	candidatos, err := s.documentos.BuscarBoletasPorFechaEmision(contexto, rucEmisor, fechaReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	porEmisor := map[string][]domain.Document{}
+	for _, documento := range candidatos {
+		if documento.DiaEmision() != fechaReferencia {
+			continue
+		}
+		porEmisor[documento.RucEmisor] = append(porEmisor[documento.RucEmisor], documento)
+	}
+
+	emisores := make([]string, 0, len(porEmisor))
+	for emisor := range porEmisor {
+		emisores = append(emisores, emisor)
+	}
+	sort.Strings(emisores)
+
+	generados := []domain.ResumenDiario{}
+	for _, emisor := range emisores {
+		resumen, err := s.generarResumen(contexto, emisor, fechaReferencia, ahora.Format(formatoFecha), porEmisor[emisor])
+		if err != nil {
+			return nil, err
+		}
+		if resumen != nil {
+			generados = append(generados, *resumen)
+		}
+	}
+
+	return generados, nil
+}
+
+func (s *dailySummaryService) generarResumen(contexto context.Context, rucEmisor, fechaReferencia, fechaGeneracion string, documentos []domain.Document) (*domain.ResumenDiario, error) {
+	var err error
+	for intento := 0; intento < maxIntentosResumen; intento++ {
+		var anteriores []domain.ResumenDiario
+		anteriores, err = s.resumenes.ListarResumenes(contexto, rucEmisor, fechaReferencia)
+		if err != nil {
+			return nil, err
+		}
+
+		resumen := armarResumen(documentos, ultimosInformados(anteriores))
+		if len(resumen.Items) == 0 {
+			return nil, nil
+		}
+
+		resumen.RucEmisor = rucEmisor
+		resumen.FechaGeneracion = fechaGeneracion
+		resumen.FechaReferencia = fechaReferencia
+		resumen.Secuencia = len(anteriores) + 1
+		err = s.resumenes.Guardar(contexto, resumen)
+		if err == nil {
+			return resumen, nil
+		}
+		if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != http.StatusConflict {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (s *dailySummaryService) ObtenerResumen(contexto context.Context, rucEmisor, id string) (*domain.ResumenDiario, error) {
+	return s.resumenes.BuscarPorID(contexto, rucEmisor, id)
+}
+
+func (s *dailySummaryService) ListarResumenes(contexto context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error) {
+	if fechaReferencia != "" {
+		if _, err := time.Parse(formatoFecha, fechaReferencia); err != nil {
+			return nil, errors.ErrorValidacion("fecha debe tener el formato AAAA-MM-DD")
+		}
+	}
+	return s.resumenes.ListarResumenes(contexto, rucEmisor, fechaReferencia)
+}
+
+func ultimosInformados(anteriores []domain.ResumenDiario) map[string]domain.ItemResumen {
+	ultimos := map[string]domain.ItemResumen{}
+	for _, resumen := range anteriores {
+		for _, item := range resumen.Items {
+			ultimos[item.IDDocumento] = item
+		}
+	}
+	return ultimos
+}
+
+func armarResumen(documentos []domain.Document, informados map[string]domain.ItemResumen) *domain.ResumenDiario {
+	resumen := &domain.ResumenDiario{Items: []domain.ItemResumen{}}
+	porEstado := map[string]*domain.TotalEstado{}
+
+	for i := range documentos {
+		documento := &documentos[i]
+		item := itemResumen(documento)
+		anterior, informado := informados[documento.IDDocumento]
+
+		switch {
+		case informado && anterior.Estado == domain.EstadoResumenAnular:
+			continue
+		case documento.CodigoEstado() == domain.EstadoAnulado:
+			item.Estado = domain.EstadoResumenAnular
+		case !informado:
+			item.Estado = domain.EstadoResumenAdicionar
+		case anterior.MismosImportes(item):
+			continue
+		default:
+			item.Estado = domain.EstadoResumenModificar
+		}
+		resumen.Items = append(resumen.Items, item)
+
+		montoTotal := documento.ConvertirASoles(documento.MontoTotal)
+		if documento.TipoDocumento == domain.TipoNotaCredito {
+			montoTotal = money.Cero().Restar(montoTotal)
+		}
+
+		total, existe := porEstado[item.Estado]
+		if !existe {
+			total = &domain.TotalEstado{Estado: item.Estado, Descripcion: domain.DescripcionesEstadoResumen[item.Estado]}
+			porEstado[item.Estado] = total
+		}
+		total.Documentos++
+		total.MontoTotal = total.MontoTotal.Sumar(montoTotal)
+
+		if item.Estado != domain.EstadoResumenAnular {
+			sumarCategorias(&resumen.TotalesPorCategoria, documento)
+		}
+	}
+
+	for _, estado := range []string{domain.EstadoResumenAdicionar, domain.EstadoResumenModificar, domain.EstadoResumenAnular} {
+		if total, existe := porEstado[estado]; existe {
+			resumen.TotalesPorEstado = append(resumen.TotalesPorEstado, *total)
+		}
+	}
+	return resumen
+}
+
+func itemResumen(documento *domain.Document) domain.ItemResumen {
+	item := domain.ItemResumen{
+		IDDocumento:           documento.IDDocumento,
+		TipoDocumento:         documento.TipoDocumento,
+		TipoDocumentoReceptor: documento.TipoDocumentoReceptor,
+		NumeroReceptor:        documento.RucReceptor,
+		Moneda:                documento.CodigoMoneda(),
+		TipoCambio:            documento.TipoCambio,
+		TotalGravado:          documento.TotalGravado,
+		TotalExonerado:        documento.TotalExonerado,
+		TotalInafecto:         documento.TotalInafecto,
+		TotalGratuito:         documento.TotalGratuito,
+		IscTotal:              documento.IscTotal,
+		IgvTotal:              documento.IgvTotal,
+		IcbperTotal:           documento.IcbperTotal,
+		TotalCargos:           documento.TotalCargos,
+		MontoTotal:            documento.MontoTotal,
+	}
+	if documento.Referencia != nil {
+		item.DocumentoReferencia = documento.Referencia.IDDocumento
+	}
+	return item
+}
+
+func sumarCategorias(totales *domain.TotalesCategoria, documento *domain.Document) {
+	sumar := func(acumulado *money.Money, monto money.Money) {
+		monto = documento.ConvertirASoles(monto)
+		if documento.TipoDocumento == domain.TipoNotaCredito {
+			*acumulado = acumulado.Restar(monto)
+			return
+		}
+		*acumulado = acumulado.Sumar(monto)
+	}
+
+	sumar(&totales.TotalGravado, documento.TotalGravado)
+	sumar(&totales.TotalExonerado, documento.TotalExonerado)
+	sumar(&totales.TotalInafecto, documento.TotalInafecto)
+	sumar(&totales.TotalGratuito, documento.TotalGratuito)
+	sumar(&totales.IscTotal, documento.IscTotal)
+	sumar(&totales.IgvTotal, documento.IgvTotal)
+	sumar(&totales.IcbperTotal, documento.IcbperTotal)
+	sumar(&totales.MontoTotal, documento.MontoTotal)
+}
+
+// FechaResumenPorDefecto devuelve el día anterior en hora de Perú
+func FechaResumenPorDefecto() string {
+	return time.Now().In(domain.ZonaHorariaPeru).AddDate(0, 0, -1).Format(formatoFecha)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type DailySummaryService interface {
+	GenerarResumenes(contexto context.Context, fechaReferencia, rucEmisor string) ([]domain.ResumenDiario, error)
+	ObtenerResumen(contexto context.Context, rucEmisor, id string) (*domain.ResumenDiario, error)
+	ListarResumenes(contexto context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error)
+}
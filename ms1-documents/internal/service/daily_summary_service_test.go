@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockDailySummaryRepository struct {
+	saveFunc     func(ctx context.Context, resumen *domain.ResumenDiario) error
+	findByIDFunc func(ctx context.Context, rucEmisor, id string) (*domain.ResumenDiario, error)
+	listFunc     func(ctx context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error)
+}
+
+func (m *mockDailySummaryRepository) Guardar(ctx context.Context, resumen *domain.ResumenDiario) error {
+	if m.saveFunc != nil {
+		return m.saveFunc(ctx, resumen)
+	}
+	return nil
+}
+
+func (m *mockDailySummaryRepository) BuscarPorID(ctx context.Context, rucEmisor, id string) (*domain.ResumenDiario, error) {
+	if m.findByIDFunc != nil {
+		return m.findByIDFunc(ctx, rucEmisor, id)
+	}
+	return nil, errors.ErrorNoEncontrado("not found")
+}
+
+func (m *mockDailySummaryRepository) ListarResumenes(ctx context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, rucEmisor, fechaReferencia)
+	}
+	return []domain.ResumenDiario{}, nil
+}
+
+// guardarResumenes numera cada resumen como lo hace MongoDB y lo agrega a guardados
+func guardarResumenes(guardados *[]domain.ResumenDiario) func(ctx context.Context, resumen *domain.ResumenDiario) error {
+	return func(ctx context.Context, resumen *domain.ResumenDiario) error {
+		resumen.ID = fmt.Sprintf("RC-%s-%d", strings.ReplaceAll(resumen.FechaGeneracion, "-", ""), len(*guardados)+1)
+		*guardados = append(*guardados, *resumen)
+		return nil
+	}
+}
+
+func TestGenerarResumenes_PrimerResumen(t *testing.T) {
+	ayer := time.Now().In(domain.ZonaHorariaPeru).AddDate(0, 0, -1)
+	fecha := ayer.Format("2006-01-02")
+	emitidaAyer := fecha + "T10:00:00-05:00"
+	// 22:00 en Perú, guardada en UTC con fecha del día siguiente
+	emitidaAyerEnUTC := time.Date(ayer.Year(), ayer.Month(), ayer.Day(), 22, 0, 0, 0, domain.ZonaHorariaPeru).UTC().Format(time.RFC3339)
+
+	repo := &mockRepository{findBoletasFunc: buscarDocumentos(
+		domain.Document{IDDocumento: "B001-00000001", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emitidaAyer, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("100.00"), IgvTotal: money.MustParse("18.00"), MontoTotal: money.MustParse("118.00")},
+		domain.Document{IDDocumento: "B001-00000002", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emitidaAyerEnUTC, Estado: domain.EstadoAnulado, TotalGravado: money.MustParse("50.00"), IgvTotal: money.MustParse("9.00"), MontoTotal: money.MustParse("59.00")},
+		domain.Document{IDDocumento: "B001-00000003", TipoDocumento: domain.TipoNotaCredito, RucEmisor: "20123456786", FechaEmision: emitidaAyer, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("10.00"), IgvTotal: money.MustParse("1.80"), MontoTotal: money.MustParse("11.80"), Referencia: &domain.DocumentoReferencia{IDDocumento: "B001-00000001", TipoDocumento: domain.TipoBoleta, CodigoMotivo: "07"}},
+		domain.Document{IDDocumento: "B001-00000004", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: ayer.AddDate(0, 0, -1).Format("2006-01-02") + "T10:00:00-05:00", Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("30.00"), IgvTotal: money.MustParse("5.40"), MontoTotal: money.MustParse("35.40")},
+		domain.Document{IDDocumento: "B002-00000001", TipoDocumento: domain.TipoBoleta, RucEmisor: "20987654326", FechaEmision: emitidaAyer, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("20.00"), IgvTotal: money.MustParse("3.60"), MontoTotal: money.MustParse("23.60")},
+	)}
+	var guardados []domain.ResumenDiario
+	resumenes := &mockDailySummaryRepository{saveFunc: guardarResumenes(&guardados)}
+	svc := NewDailySummaryService(repo, resumenes)
+
+	generados, err := svc.GenerarResumenes(context.Background(), fecha, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(generados) != 2 {
+		t.Fatalf("Expected one summary per emisor, got %d", len(generados))
+	}
+
+	resumen := generados[0]
+	hoy := time.Now().In(domain.ZonaHorariaPeru).Format("20060102")
+	if resumen.ID != "RC-"+hoy+"-1" || resumen.RucEmisor != "20123456786" || resumen.FechaReferencia != fecha {
+		t.Errorf("Unexpected summary header: %s %s %s", resumen.ID, resumen.RucEmisor, resumen.FechaReferencia)
+	}
+
+	estados := map[string]string{}
+	for _, item := range resumen.Items {
+		estados[item.IDDocumento] = item.Estado
+	}
+	esperados := map[string]string{
+		"B001-00000001": domain.EstadoResumenAdicionar,
+		"B001-00000002": domain.EstadoResumenAnular,
+		"B001-00000003": domain.EstadoResumenAdicionar,
+	}
+	if len(estados) != len(esperados) {
+		t.Errorf("Expected items %v, got %v", esperados, estados)
+	}
+	for id, estado := range esperados {
+		if estados[id] != estado {
+			t.Errorf("Expected %s with estado %s, got %s", id, estado, estados[id])
+		}
+	}
+
+	// Boleta de 118.00 menos nota de crédito de 11.80; la anulada no suma
+	if !resumen.TotalesPorCategoria.TotalGravado.Igual(money.MustParse("90.00")) {
+		t.Errorf("Expected totalGravado 90.00, got %s", resumen.TotalesPorCategoria.TotalGravado)
+	}
+	if !resumen.TotalesPorCategoria.MontoTotal.Igual(money.MustParse("106.20")) {
+		t.Errorf("Expected montoTotal 106.20, got %s", resumen.TotalesPorCategoria.MontoTotal)
+	}
+
+	if len(resumen.TotalesPorEstado) != 2 {
+		t.Fatalf("Expected totals for 2 estados, got %+v", resumen.TotalesPorEstado)
+	}
+	if resumen.TotalesPorEstado[0].Documentos != 2 || !resumen.TotalesPorEstado[0].MontoTotal.Igual(money.MustParse("106.20")) {
+		t.Errorf("Unexpected totals for added documents: %+v", resumen.TotalesPorEstado[0])
+	}
+	if resumen.TotalesPorEstado[1].Estado != domain.EstadoResumenAnular || !resumen.TotalesPorEstado[1].MontoTotal.Igual(money.MustParse("59.00")) {
+		t.Errorf("Unexpected totals for voided documents: %+v", resumen.TotalesPorEstado[1])
+	}
+
+	if len(guardados) != 2 || guardados[0].ID != resumen.ID {
+		t.Errorf("Expected both summaries to be persisted, got %+v", guardados)
+	}
+}
+
+func TestGenerarResumenes_SoloCambios(t *testing.T) {
+	fecha := time.Now().In(domain.ZonaHorariaPeru).AddDate(0, 0, -1).Format("2006-01-02")
+	emision := fecha + "T10:00:00-05:00"
+
+	sinCambios := domain.Document{IDDocumento: "B001-00000001", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emision, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("100.00"), IgvTotal: money.MustParse("18.00"), MontoTotal: money.MustParse("118.00")}
+	anulada := domain.Document{IDDocumento: "B001-00000002", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emision, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("50.00"), IgvTotal: money.MustParse("9.00"), MontoTotal: money.MustParse("59.00")}
+	modificada := domain.Document{IDDocumento: "B001-00000003", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emision, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("40.00"), IgvTotal: money.MustParse("7.20"), MontoTotal: money.MustParse("47.20")}
+
+	anterior := armarResumen([]domain.Document{sinCambios, anulada, modificada}, nil)
+	anterior.RucEmisor = "20123456786"
+	anterior.FechaReferencia = fecha
+	guardados := []domain.ResumenDiario{*anterior}
+	resumenes := &mockDailySummaryRepository{
+		listFunc: func(ctx context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error) {
+			if rucEmisor != "20123456786" || fechaReferencia != fecha {
+				t.Errorf("Expected summaries of 20123456786 for %s, got %s %s", fecha, rucEmisor, fechaReferencia)
+			}
+			return guardados, nil
+		},
+		saveFunc: guardarResumenes(&guardados),
+	}
+
+	anulada.Estado = domain.EstadoAnulado
+	modificada = domain.Document{IDDocumento: "B001-00000003", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emision, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("45.00"), IgvTotal: money.MustParse("8.10"), MontoTotal: money.MustParse("53.10")}
+	repo := &mockRepository{findBoletasFunc: buscarDocumentos(sinCambios, anulada, modificada)}
+	svc := NewDailySummaryService(repo, resumenes)
+
+	generados, err := svc.GenerarResumenes(context.Background(), fecha, "20123456786")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(generados) != 1 || len(generados[0].Items) != 2 {
+		t.Fatalf("Expected one summary with 2 items, got %+v", generados)
+	}
+
+	if generados[0].Items[0].IDDocumento != "B001-00000002" || generados[0].Items[0].Estado != domain.EstadoResumenAnular {
+		t.Errorf("Expected voided boleta, got %+v", generados[0].Items[0])
+	}
+
+	if generados[0].Items[1].IDDocumento != "B001-00000003" || generados[0].Items[1].Estado != domain.EstadoResumenModificar {
+		t.Errorf("Expected modified boleta, got %+v", generados[0].Items[1])
+	}
+
+	generados, err = svc.GenerarResumenes(context.Background(), fecha, "20123456786")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(generados) != 0 {
+		t.Errorf("Expected no summary when nothing changed, got %+v", generados)
+	}
+}
+
+func TestGenerarResumenes_Concurrente(t *testing.T) {
+	fecha := time.Now().In(domain.ZonaHorariaPeru).AddDate(0, 0, -1).Format("2006-01-02")
+	emision := fecha + "T10:00:00-05:00"
+	informada := domain.Document{IDDocumento: "B001-00000001", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emision, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("100.00"), IgvTotal: money.MustParse("18.00"), MontoTotal: money.MustParse("118.00")}
+	pendiente := domain.Document{IDDocumento: "B001-00000002", TipoDocumento: domain.TipoBoleta, RucEmisor: "20123456786", FechaEmision: emision, Estado: domain.EstadoEmitido, TotalGravado: money.MustParse("50.00"), IgvTotal: money.MustParse("9.00"), MontoTotal: money.MustParse("59.00")}
+
+	concurrente := armarResumen([]domain.Document{informada}, nil)
+	concurrente.ID = "RC-CONCURRENTE"
+	concurrente.RucEmisor = "20123456786"
+	concurrente.FechaReferencia = fecha
+	concurrente.Secuencia = 1
+	// Otra solicitud guarda la secuencia 1 entre la consulta y el guardado de esta
+	var anteriores []domain.ResumenDiario
+	var secuencias []int
+	resumenes := &mockDailySummaryRepository{
+		listFunc: func(ctx context.Context, rucEmisor, fechaReferencia string) ([]domain.ResumenDiario, error) {
+			return anteriores, nil
+		},
+		saveFunc: func(ctx context.Context, resumen *domain.ResumenDiario) error {
+			secuencias = append(secuencias, resumen.Secuencia)
+			if resumen.Secuencia == concurrente.Secuencia {
+				anteriores = []domain.ResumenDiario{*concurrente}
+				return errors.ErrorConflicto("secuencia ocupada")
+			}
+			return nil
+		},
+	}
+
+	repo := &mockRepository{findBoletasFunc: buscarDocumentos(informada, pendiente)}
+	svc := NewDailySummaryService(repo, resumenes)
+
+	generados, err := svc.GenerarResumenes(context.Background(), fecha, "20123456786")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(generados) != 1 || len(generados[0].Items) != 1 || generados[0].Items[0].IDDocumento != "B001-00000002" {
+		t.Fatalf("Expected a rebuilt summary with only B001-00000002, got %+v", generados)
+	}
+	if generados[0].Secuencia != 2 {
+		t.Errorf("Expected secuencia 2, got %d", generados[0].Secuencia)
+	}
+	if len(secuencias) != 2 {
+		t.Errorf("Expected a retry after the conflict, got saves with secuencias %v", secuencias)
+	}
+}
+
+func TestGenerarResumenes_FechaInvalida(t *testing.T) {
+	manana := time.Now().In(domain.ZonaHorariaPeru).AddDate(0, 0, 1).Format("2006-01-02")
+	svc := NewDailySummaryService(&mockRepository{}, &mockDailySummaryRepository{})
+
+	for _, fecha := range []string{"12/02/2026", manana} {
+		_, err := svc.GenerarResumenes(context.Background(), fecha, "")
+
+		appErr, ok := err.(*errors.AppError)
+		if !ok || appErr.Code != 400 {
+			t.Errorf("Expected validation error for %s, got: %v", fecha, err)
+		}
+	}
+}
@@ -2,93 +2,860 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"ms1-documents/internal/config"
 	"ms1-documents/internal/domain"
 	"ms1-documents/internal/repository"
 	"ms1-documents/internal/utils"
 	"ms1-documents/internal/validator"
 	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+const formatoFecha = "2006-01-02"
+
+const maxIntentosCorrelativo = 10
+
+const longitudMaximaMotivoBaja = 100
+
 type documentService struct {
-	repo        repository.DocumentRepository
-	publisher   MessagePublisher
-	validator   *validator.DocumentValidator
-	rabbitmqURL string
+	repo                       repository.DocumentRepository
+	series                     repository.SeriesRepository
+	permitirCorrelativoCliente bool
+	comunicacionesBaja         repository.VoidSummaryRepository
+	emisores                   repository.IssuerRepository
+	clientes                   repository.CustomerRepository
+	productos                  repository.ProductRepository
+	reglasNegocio              repository.BusinessRuleRepository
+	publisher                  MessagePublisher
+	validator                  *validator.DocumentValidator
+	rabbitmqURL                string
 }
 
 type MessagePublisher interface {
 	PublishDocumentCreated(documentID, uuid string) error
 }
 
-func NewDocumentService(repo repository.DocumentRepository, publisher MessagePublisher, validator *validator.DocumentValidator, rabbitmqURL string) DocumentService {
-	return &documentService{
+// OpcionServicio configura un documentService al construirlo
+type OpcionServicio func(*documentService)
+
+// ConNumeracion hace que MS1 asigne el correlativo de cada serie
+func ConNumeracion(series repository.SeriesRepository, permitirCorrelativoCliente bool) OpcionServicio {
+	return func(s *documentService) {
+		s.series = series
+		s.permitirCorrelativoCliente = permitirCorrelativoCliente
+	}
+}
+
+func ConComunicacionesBaja(comunicaciones repository.VoidSummaryRepository) OpcionServicio {
+	return func(s *documentService) {
+		s.comunicacionesBaja = comunicaciones
+	}
+}
+
+// ConEmisores exige que el emisor de cada documento nuevo esté registrado y activo
+func ConEmisores(emisores repository.IssuerRepository) OpcionServicio {
+	return func(s *documentService) {
+		s.emisores = emisores
+	}
+}
+
+func ConClientes(clientes repository.CustomerRepository) OpcionServicio {
+	return func(s *documentService) {
+		s.clientes = clientes
+	}
+}
+
+func ConProductos(productos repository.ProductRepository) OpcionServicio {
+	return func(s *documentService) {
+		s.productos = productos
+	}
+}
+
+func ConReglasNegocio(reglas repository.BusinessRuleRepository) OpcionServicio {
+	return func(s *documentService) {
+		s.reglasNegocio = reglas
+	}
+}
+
+func NewDocumentService(repo repository.DocumentRepository, publisher MessagePublisher, validator *validator.DocumentValidator, rabbitmqURL string, opciones ...OpcionServicio) DocumentService {
+	servicio := &documentService{
 		repo:        repo,
 		publisher:   publisher,
 		validator:   validator,
 		rabbitmqURL: rabbitmqURL,
 	}
+	for _, opcion := range opciones {
+		opcion(servicio)
+	}
+	return servicio
 }
 
 func (s *documentService) CrearDocumento(contexto context.Context, documento *domain.Document) error {
 	documento.UUID = uuid.New().String()
+	documento.Estado = domain.EstadoBorrador
+	documento.Anulacion = nil
+	documento.Emisor = nil
+
+	emisor, err := s.aplicarEmisor(contexto, documento)
+	if err != nil {
+		return err
+	}
 
-	if err := s.validator.ValidarDocumento(documento); err != nil {
+	if err := s.completarDesdeCatalogo(contexto, documento); err != nil {
 		return err
 	}
 
-	if err := s.repo.Crear(contexto, documento); err != nil {
+	if err := s.validarConReglasNegocio(contexto, documento); err != nil {
+		return err
+	}
+
+	if err := validarSerieEmisor(emisor, documento); err != nil {
+		return err
+	}
+	if emisor != nil {
+		documento.Emisor = emisor.Datos()
+	}
+
+	acreditado, rechazado, err := s.validarDocumentoReferenciado(contexto, documento)
+	if err != nil {
+		return err
+	}
+
+	idReferencia, credito := creditoDeNota(documento)
+	if err := s.reservarCredito(contexto, documento.RucEmisor, idReferencia, credito, acreditado, rechazado); err != nil {
+		return err
+	}
+
+	if err := s.guardarConCorrelativo(contexto, documento); err != nil {
+		s.liberarCredito(contexto, documento.RucEmisor, idReferencia, credito)
+		return err
+	}
+	s.guardarCliente(contexto, documento)
+
+	return s.publicar(contexto, documento)
+}
+
+const (
+	verificacionEmisor                = "emisor"
+	verificacionCatalogo              = "catalogoProductos"
+	verificacionSerieEmisor           = "serieEmisor"
+	verificacionDocumentoReferenciado = "documentoReferenciado"
+	verificacionIDDocumento           = "idDocumentoDisponible"
+)
+
+// ValidarDocumento recorre las verificaciones de CrearDocumento sin guardar ni publicar el documento
+func (s *documentService) ValidarDocumento(contexto context.Context, documento *domain.Document) (*domain.ReporteValidacion, error) {
+	documento.Estado = domain.EstadoBorrador
+	documento.Anulacion = nil
+	documento.Emisor = nil
+	reporte := domain.NuevoReporteValidacion(documento)
+
+	emisor, err := s.aplicarEmisor(contexto, documento)
+	if err := agregarVerificacion(reporte, verificacionEmisor, domain.EtapaEmisor, err); err != nil {
+		return nil, err
+	}
+
+	err = s.completarDesdeCatalogo(contexto, documento)
+	if err := agregarVerificacion(reporte, verificacionCatalogo, domain.EtapaCatalogo, err); err != nil {
+		return nil, err
+	}
+
+	reglas, err := s.cargarReglasNegocio(contexto, documento.RucEmisor)
+	if err != nil {
+		return nil, err
+	}
+	verificaciones, err := s.validator.EvaluarDocumento(contexto, documento, reglas...)
+	if err != nil {
+		return nil, err
+	}
+	if !reporte.Cumplida(verificacionCatalogo) {
+		for indice := range verificaciones {
+			verificaciones[indice].Resultado = domain.ResultadoOmitida
+			verificaciones[indice].Errores = nil
+		}
+	}
+	reporte.Agregar(verificaciones...)
+
+	if reporte.Cumplida(verificacionEmisor) && reporte.Cumplida(validator.ReglaSerie) {
+		err := validarSerieEmisor(emisor, documento)
+		if err == nil && emisor != nil {
+			documento.Emisor = emisor.Datos()
+		}
+		if err := agregarVerificacion(reporte, verificacionSerieEmisor, domain.EtapaEmisor, err); err != nil {
+			return nil, err
+		}
+	} else {
+		reporte.Agregar(verificacionOmitida(verificacionSerieEmisor, domain.EtapaEmisor))
+	}
+
+	if reporte.Cumplida(validator.ReglaReferencia) {
+		_, _, err := s.validarDocumentoReferenciado(contexto, documento)
+		if err := agregarVerificacion(reporte, verificacionDocumentoReferenciado, domain.EtapaReferencia, err); err != nil {
+			return nil, err
+		}
+	} else {
+		reporte.Agregar(verificacionOmitida(verificacionDocumentoReferenciado, domain.EtapaReferencia))
+	}
+
+	if reporte.Cumplida(validator.ReglaSerie) {
+		if err := s.verificarIDDocumento(contexto, reporte, documento); err != nil {
+			return nil, err
+		}
+	} else {
+		reporte.Agregar(verificacionOmitida(verificacionIDDocumento, domain.EtapaDuplicado))
+	}
+
+	return reporte, nil
+}
+
+func (s *documentService) verificarIDDocumento(contexto context.Context, reporte *domain.ReporteValidacion, documento *domain.Document) error {
+	verificacion := domain.Verificacion{Nombre: verificacionIDDocumento, Etapa: domain.EtapaDuplicado, Resultado: domain.ResultadoCumplida}
+	incumplida := func(codigo, mensaje string) {
+		verificacion.Resultado = domain.ResultadoIncumplida
+		verificacion.Errores = []errors.FieldError{{Path: "/idDocumento", Code: codigo, Message: mensaje}}
+	}
+
+	switch {
+	case documento.IDDocumento == "" && s.series == nil:
+		incumplida(validator.CodigoRequerido, "idDocumento es obligatorio")
+	case documento.IDDocumento == "":
+	case s.series != nil && !s.permitirCorrelativoCliente:
+		incumplida(validator.CodigoNoPermitido, fmt.Sprintf("idDocumento lo asigna el sistema; envíe solo la serie %s", documento.Serie))
+	default:
+		if s.series != nil {
+			siguiente, err := s.siguienteCorrelativo(contexto, documento)
+			if err != nil {
+				return err
+			}
+			if correlativo := normalizarIDDocumento(documento); correlativo > siguiente {
+				incumplida(validator.CodigoFueraDeRango, fmt.Sprintf("el correlativo %d salta la numeración de la serie %s; el siguiente es %d", correlativo, documento.Serie, siguiente))
+				break
+			}
+		}
+		_, err := s.repo.BuscarPorID(contexto, documento.RucEmisor, documento.IDDocumento)
+		if err == nil {
+			incumplida(validator.CodigoDuplicado, fmt.Sprintf("idDocumento %s ya está registrado", documento.IDDocumento))
+		} else if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != http.StatusNotFound {
+			return err
+		}
+	}
+
+	reporte.Agregar(verificacion)
+	return nil
+}
+
+func (s *documentService) siguienteCorrelativo(contexto context.Context, documento *domain.Document) (int64, error) {
+	series, err := s.series.ListarSeries(contexto, documento.RucEmisor)
+	if err != nil {
+		return 0, err
+	}
+	for _, serie := range series {
+		if serie.Serie == documento.Serie {
+			return serie.SiguienteCorrelativo(), nil
+		}
+	}
+	return 1, nil
+}
+
+func agregarVerificacion(reporte *domain.ReporteValidacion, nombre, etapa string, err error) error {
+	verificacion := domain.Verificacion{Nombre: nombre, Etapa: etapa, Resultado: domain.ResultadoCumplida}
+	if err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if !ok || appErr.Code != http.StatusBadRequest {
+			return err
+		}
+
+		verificacion.Resultado = domain.ResultadoIncumplida
+		verificacion.Errores = appErr.Errors
+		if len(verificacion.Errores) == 0 {
+			verificacion.Errores = []errors.FieldError{{Code: validator.CodigoInvalido, Message: appErr.Message}}
+		}
+	}
+
+	reporte.Agregar(verificacion)
+	return nil
+}
+
+func errorDeCampo(ruta, codigo, mensaje string) *errors.AppError {
+	return errors.ErrorValidacionCampos([]errors.FieldError{{Path: ruta, Code: codigo, Message: mensaje}})
+}
+
+func verificacionOmitida(nombre, etapa string) domain.Verificacion {
+	return domain.Verificacion{Nombre: nombre, Etapa: etapa, Resultado: domain.ResultadoOmitida}
+}
+
+func (s *documentService) guardarCliente(contexto context.Context, documento *domain.Document) {
+	if s.clientes == nil || !documento.GuardarCliente {
+		return
+	}
+
+	cliente := domain.ClienteDesdeDocumento(documento)
+	cliente.FechaActualizacion = time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	if err := s.clientes.Guardar(contexto, cliente); err != nil {
+		config.Logger.Warn("No se pudo guardar el cliente",
+			zap.String("rucEmisor", cliente.RucEmisor),
+			zap.String("numeroDocumento", cliente.NumeroDocumento),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *documentService) emisorHabilitado(contexto context.Context, rucEmisor string) (*domain.Emisor, error) {
+	if s.emisores == nil {
+		return nil, nil
+	}
+
+	emisor, err := s.emisores.BuscarPorRUC(contexto, rucEmisor)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == http.StatusNotFound {
+			return nil, errorDeCampo("/rucEmisor", validator.CodigoNoPermitido, fmt.Sprintf("El emisor %s no está registrado", rucEmisor))
+		}
+		return nil, err
+	}
+
+	if !emisor.EstaActivo() {
+		return nil, errorDeCampo("/rucEmisor", validator.CodigoNoPermitido, fmt.Sprintf("El emisor %s está deshabilitado", rucEmisor))
+	}
+	return emisor, nil
+}
+
+func (s *documentService) aplicarEmisor(contexto context.Context, documento *domain.Document) (*domain.Emisor, error) {
+	emisor, err := s.emisorHabilitado(contexto, documento.RucEmisor)
+	if err != nil || emisor == nil {
+		return nil, err
+	}
+
+	if documento.Moneda == "" {
+		documento.Moneda = emisor.Configuracion.MonedaPorDefecto
+	}
+	if documento.RegimenIgv == "" {
+		documento.RegimenIgv = emisor.Configuracion.RegimenIgv
+	}
+	return emisor, nil
+}
+
+func validarSerieEmisor(emisor *domain.Emisor, documento *domain.Document) error {
+	if emisor == nil || emisor.AdmiteSerie(documento.Serie) {
+		return nil
+	}
+	return errorDeCampo("/serie", validator.CodigoNoPermitido, fmt.Sprintf("La serie %s no está configurada para el emisor %s", documento.Serie, emisor.RUC))
+}
+
+func (s *documentService) completarDesdeCatalogo(contexto context.Context, documento *domain.Document) error {
+	if s.productos == nil {
+		return nil
+	}
+
+	for indice := range documento.Items {
+		item := &documento.Items[indice]
+		item.CodigoProducto = strings.TrimSpace(item.CodigoProducto)
+		if item.CodigoProducto == "" {
+			continue
+		}
+
+		producto, err := s.productos.BuscarPorCodigo(contexto, documento.RucEmisor, item.CodigoProducto)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.Code == http.StatusNotFound {
+				return errorDeCampo("/items/"+strconv.Itoa(indice)+"/codigoProducto", validator.CodigoFueraDeCatalogo, fmt.Sprintf("codigoProducto del item %d no existe en el catálogo del emisor: %s", indice, item.CodigoProducto))
+			}
+			return err
+		}
+		producto.Completar(item)
+	}
+	return nil
+}
+
+func (s *documentService) validarConReglasNegocio(contexto context.Context, documento *domain.Document) error {
+	reglas, err := s.cargarReglasNegocio(contexto, documento.RucEmisor)
+	if err != nil {
 		return err
 	}
+	return s.validator.ValidarDocumento(contexto, documento, reglas...)
+}
+
+func (s *documentService) cargarReglasNegocio(contexto context.Context, rucEmisor string) ([]validator.Regla, error) {
+	if s.reglasNegocio == nil {
+		return nil, nil
+	}
+
+	definiciones, err := s.reglasNegocio.BuscarPorEmisor(contexto, rucEmisor)
+	if err != nil {
+		return nil, err
+	}
 
+	var reglas []validator.Regla
+	for _, definicion := range definiciones {
+		if !definicion.EstaActiva() {
+			continue
+		}
+		regla, err := validator.NuevaReglaNegocio(definicion)
+		if err != nil {
+			return nil, errors.ErrorInterno(fmt.Sprintf("La regla %s del emisor %s no se puede aplicar: %v", definicion.Nombre, rucEmisor, err))
+		}
+		reglas = append(reglas, regla)
+	}
+	return reglas, nil
+}
+
+func (s *documentService) publicar(contexto context.Context, documento *domain.Document) error {
 	if err := s.publisher.PublishDocumentCreated(documento.IDDocumento, documento.UUID); err != nil {
 		return errors.ErrorInterno("Error al publicar mensaje a RabbitMQ")
 	}
 
+	if documento.CodigoEstado() == domain.EstadoBorrador {
+		if err := s.repo.MarcarEmitido(contexto, documento.RucEmisor, documento.IDDocumento); err != nil {
+			return err
+		}
+		documento.Estado = domain.EstadoEmitido
+	}
 	return nil
 }
 
+func (s *documentService) guardarConCorrelativo(contexto context.Context, documento *domain.Document) error {
+	if s.series == nil {
+		if documento.IDDocumento == "" {
+			return errorDeCampo("/idDocumento", validator.CodigoRequerido, "idDocumento es obligatorio")
+		}
+		return s.repo.Crear(contexto, documento)
+	}
+
+	if documento.IDDocumento != "" {
+		if !s.permitirCorrelativoCliente {
+			return errorDeCampo("/idDocumento", validator.CodigoNoPermitido, fmt.Sprintf("El correlativo lo asigna el sistema; envíe solo la serie %s sin idDocumento", documento.Serie))
+		}
+		correlativo := normalizarIDDocumento(documento)
+		tomado, err := s.series.RegistrarCorrelativo(contexto, documento.RucEmisor, documento.Serie, correlativo)
+		if err != nil {
+			return err
+		}
+		err = s.repo.Crear(contexto, documento)
+		if err != nil && tomado {
+			s.liberarCorrelativo(contexto, documento, correlativo)
+		}
+		return err
+	}
+
+	for intento := 0; intento < maxIntentosCorrelativo; intento++ {
+		correlativo, err := s.series.ReservarCorrelativo(contexto, documento.RucEmisor, documento.Serie)
+		if err != nil {
+			return err
+		}
+
+		documento.IDDocumento = domain.FormatoIDDocumento(documento.Serie, correlativo)
+		err = s.repo.Crear(contexto, documento)
+		if err == nil {
+			return nil
+		}
+
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == http.StatusConflict {
+			continue
+		}
+
+		documento.IDDocumento = ""
+		s.liberarCorrelativo(contexto, documento, correlativo)
+		return err
+	}
+
+	documento.IDDocumento = ""
+	return errors.ErrorConflicto(fmt.Sprintf("No se encontró un correlativo libre en la serie %s", documento.Serie))
+}
+
+func (s *documentService) liberarCorrelativo(contexto context.Context, documento *domain.Document, correlativo int64) {
+	if err := s.series.LiberarCorrelativo(contexto, documento.RucEmisor, documento.Serie, correlativo); err != nil && config.Logger != nil {
+		config.Logger.Error("No se pudo liberar el correlativo",
+			zap.String("serie", documento.Serie),
+			zap.Int64("correlativo", correlativo),
+			zap.Error(err),
+		)
+	}
+}
+
+func normalizarIDDocumento(documento *domain.Document) int64 {
+	correlativo, _ := strconv.ParseInt(strings.TrimPrefix(documento.IDDocumento, documento.Serie+"-"), 10, 64)
+	documento.IDDocumento = domain.FormatoIDDocumento(documento.Serie, correlativo)
+	return correlativo
+}
+
+func (s *documentService) ListarSeries(contexto context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error) {
+	if s.series == nil {
+		return []domain.SerieCorrelativo{}, nil
+	}
+	series, err := s.series.ListarSeries(contexto, rucEmisor)
+	if err != nil {
+		return nil, err
+	}
+	for i := range series {
+		if series[i].Liberados == nil {
+			series[i].Liberados = []int64{}
+		}
+	}
+	return series, nil
+}
+
 func (s *documentService) ObtenerTodosDocumentos(contexto context.Context) ([]domain.Document, error) {
 	return s.repo.BuscarTodos(contexto)
 }
 
-func (s *documentService) ObtenerDocumentoPorID(contexto context.Context, id string) (*domain.Document, error) {
-	return s.repo.BuscarPorID(contexto, id)
+func (s *documentService) ObtenerDocumentoPorID(contexto context.Context, rucEmisor, id string) (*domain.Document, error) {
+	documento, err := s.repo.BuscarPorID(contexto, rucEmisor, id)
+	if err != nil || domain.EsNota(documento.TipoDocumento) {
+		return documento, err
+	}
+
+	notas, err := s.repo.BuscarNotasPorReferencia(contexto, documento.RucEmisor, documento.IDDocumento)
+	if err != nil {
+		return nil, err
+	}
+	saldo := calcularSaldo(documento, notas, "")
+	documento.Saldo = &saldo
+	return documento, nil
 }
 
+// ActualizarDocumento solo modifica borradores que aún no se publicaron
 func (s *documentService) ActualizarDocumento(contexto context.Context, id string, documento *domain.Document) error {
-	if err := s.validator.ValidarDocumento(documento); err != nil {
+	if documento.IDDocumento == "" {
+		documento.IDDocumento = id
+	}
+	if s.series != nil && !s.permitirCorrelativoCliente && documento.IDDocumento != id {
+		return errors.ErrorValidacion("No se puede cambiar el idDocumento asignado por la numeración")
+	}
+
+	emisor, err := s.aplicarEmisor(contexto, documento)
+	if err != nil {
+		return err
+	}
+
+	if err := s.completarDesdeCatalogo(contexto, documento); err != nil {
 		return err
 	}
 
-	documentoExistente, err := s.repo.BuscarPorID(contexto, id)
+	if err := s.validarConReglasNegocio(contexto, documento); err != nil {
+		return err
+	}
+
+	if err := validarSerieEmisor(emisor, documento); err != nil {
+		return err
+	}
+
+	acreditado, rechazado, err := s.validarDocumentoReferenciado(contexto, documento)
 	if err != nil {
 		return err
 	}
 
+	documentoExistente, err := s.repo.BuscarPorID(contexto, documento.RucEmisor, id)
+	if err != nil {
+		return err
+	}
+
+	switch documentoExistente.CodigoEstado() {
+	case domain.EstadoBorrador:
+	case domain.EstadoAnulado:
+		return errors.ErrorConflicto(fmt.Sprintf("El documento %s está anulado y no se puede modificar", id))
+	default:
+		return errors.ErrorConflicto(fmt.Sprintf("El documento %s ya fue emitido; corríjalo con una nota de crédito o débito", id))
+	}
+
 	documento.UUID = documentoExistente.UUID
+	documento.Emisor = documentoExistente.Emisor
 	documento.Validacion = nil
+	documento.Estado = documentoExistente.Estado
+	documento.Anulacion = nil
 
-	if err := s.repo.Actualizar(contexto, id, documento); err != nil {
+	idReferenciaAnterior, creditoAnterior := creditoDeNota(documentoExistente)
+	idReferencia, credito := creditoDeNota(documento)
+	if idReferencia != "" && idReferencia == idReferenciaAnterior {
+		credito = credito.Restar(creditoAnterior)
+		acreditado = acreditado.Sumar(creditoAnterior)
+	}
+	if err := s.reservarCredito(contexto, documento.RucEmisor, idReferencia, credito, acreditado, rechazado); err != nil {
 		return err
 	}
 
-	if err := s.publisher.PublishDocumentCreated(documento.IDDocumento, documento.UUID); err != nil {
-		return errors.ErrorInterno("Error al publicar mensaje a RabbitMQ")
+	if err := s.repo.Actualizar(contexto, documento.RucEmisor, id, documento); err != nil {
+		s.liberarCredito(contexto, documento.RucEmisor, idReferencia, credito)
+		return err
+	}
+	if idReferenciaAnterior != idReferencia {
+		s.liberarCredito(contexto, documento.RucEmisor, idReferenciaAnterior, creditoAnterior)
+	}
+
+	return s.publicar(contexto, documento)
+}
+
+func (s *documentService) validarDocumentoReferenciado(contexto context.Context, documento *domain.Document) (money.Money, money.Money, error) {
+	if documento.Referencia == nil {
+		return money.Cero(), money.Cero(), nil
+	}
+
+	referenciado, err := s.repo.BuscarPorID(contexto, documento.RucEmisor, documento.Referencia.IDDocumento)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == http.StatusNotFound {
+			return money.Cero(), money.Cero(), errorDeCampo("/referencia/idDocumento", validator.CodigoInvalido, fmt.Sprintf("El documento de referencia %s no existe", documento.Referencia.IDDocumento))
+		}
+		return money.Cero(), money.Cero(), err
+	}
+
+	if referenciado.TipoDocumento != documento.Referencia.TipoDocumento {
+		return money.Cero(), money.Cero(), errorDeCampo("/referencia/tipoDocumento", validator.CodigoNoCoincide, fmt.Sprintf("El documento de referencia %s no es de tipo %s", documento.Referencia.IDDocumento, documento.Referencia.TipoDocumento))
+	}
+
+	if referenciado.CodigoEstado() == domain.EstadoAnulado {
+		return money.Cero(), money.Cero(), errorDeCampo("/referencia/idDocumento", validator.CodigoNoPermitido, fmt.Sprintf("El documento de referencia %s está anulado", documento.Referencia.IDDocumento))
+	}
+
+	if !referenciado.EstaValidado() {
+		return money.Cero(), money.Cero(), errorDeCampo("/referencia/idDocumento", validator.CodigoNoPermitido, fmt.Sprintf("El documento de referencia %s no ha sido validado", documento.Referencia.IDDocumento))
+	}
+
+	if referenciado.RucEmisor != documento.RucEmisor {
+		return money.Cero(), money.Cero(), errorDeCampo("/referencia/idDocumento", validator.CodigoNoPermitido, fmt.Sprintf("El documento de referencia %s pertenece a otro emisor", documento.Referencia.IDDocumento))
+	}
+
+	if referenciado.CodigoMoneda() != documento.CodigoMoneda() {
+		return money.Cero(), money.Cero(), errorDeCampo("/moneda", validator.CodigoNoCoincide, fmt.Sprintf("La nota debe emitirse en %s, la moneda del documento de referencia", referenciado.CodigoMoneda()))
+	}
+
+	if documento.TipoDocumento != domain.TipoNotaCredito {
+		return money.Cero(), money.Cero(), nil
+	}
+
+	notas, err := s.repo.BuscarNotasPorReferencia(contexto, referenciado.RucEmisor, referenciado.IDDocumento)
+	if err != nil {
+		return money.Cero(), money.Cero(), err
+	}
+	saldo := calcularSaldo(referenciado, notas, documento.IDDocumento)
+	rechazado := creditoRechazado(notas, documento.IDDocumento)
+	acreditado := saldo.TotalNotasCredito.Sumar(documento.MontoTotal)
+	if acreditado.Comparar(referenciado.MontoTotal) > 0 {
+		return money.Cero(), money.Cero(), errorDeCampo("/montoTotal", validator.CodigoFueraDeRango, fmt.Sprintf("Las notas de crédito sobre %s sumarían %s y superan su importe total %s",
+			referenciado.IDDocumento, acreditado.StringFijo(money.DecimalesMonto), referenciado.MontoTotal.StringFijo(money.DecimalesMonto)))
+	}
+
+	return saldo.TotalNotasCredito.Sumar(rechazado), rechazado, nil
+}
+
+func creditoDeNota(documento *domain.Document) (string, money.Money) {
+	if documento.TipoDocumento != domain.TipoNotaCredito || documento.Referencia == nil {
+		return "", money.Cero()
 	}
+	return documento.Referencia.IDDocumento, documento.MontoTotal
+}
 
+func (s *documentService) reservarCredito(contexto context.Context, rucEmisor, idReferencia string, credito, acreditado, rechazado money.Money) error {
+	if idReferencia == "" {
+		return nil
+	}
+	registrado, err := s.repo.RegistrarCredito(contexto, rucEmisor, idReferencia, credito, acreditado, rechazado)
+	if err != nil {
+		return err
+	}
+	if !registrado {
+		return errors.ErrorConflicto(fmt.Sprintf("Las notas de crédito registradas sobre %s no dejan saldo para acreditar %s",
+			idReferencia, credito.StringFijo(money.DecimalesMonto)))
+	}
 	return nil
 }
 
-func (s *documentService) EliminarDocumento(contexto context.Context, id string) error {
-	return s.repo.Eliminar(contexto, id)
+func (s *documentService) liberarCredito(contexto context.Context, rucEmisor, idReferencia string, credito money.Money) {
+	if idReferencia == "" {
+		return
+	}
+	if err := s.repo.LiberarCredito(contexto, rucEmisor, idReferencia, credito); err != nil && config.Logger != nil {
+		config.Logger.Error("No se pudo liberar el crédito de la nota",
+			zap.String("idDocumento", idReferencia),
+			zap.String("monto", credito.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func calcularSaldo(documento *domain.Document, notas []domain.Document, excluir string) domain.Saldo {
+	saldo := domain.Saldo{TotalNotasCredito: money.Cero(), TotalNotasDebito: money.Cero()}
+	for _, nota := range notas {
+		if (excluir != "" && nota.IDDocumento == excluir) || nota.CodigoEstado() == domain.EstadoAnulado || notaRechazada(&nota) {
+			continue
+		}
+		switch nota.TipoDocumento {
+		case domain.TipoNotaCredito:
+			saldo.TotalNotasCredito = saldo.TotalNotasCredito.Sumar(nota.MontoTotal)
+		case domain.TipoNotaDebito:
+			saldo.TotalNotasDebito = saldo.TotalNotasDebito.Sumar(nota.MontoTotal)
+		}
+	}
+	saldo.SaldoNeto = documento.MontoTotal.Restar(saldo.TotalNotasCredito).Sumar(saldo.TotalNotasDebito)
+	return saldo
+}
+
+func creditoRechazado(notas []domain.Document, excluir string) money.Money {
+	total := money.Cero()
+	for _, nota := range notas {
+		if nota.TipoDocumento == domain.TipoNotaCredito && nota.IDDocumento != excluir &&
+			nota.CodigoEstado() != domain.EstadoAnulado && notaRechazada(&nota) {
+			total = total.Sumar(nota.MontoTotal)
+		}
+	}
+	return total
+}
+
+func notaRechazada(nota *domain.Document) bool {
+	return nota.Validacion != nil && nota.Validacion.Estado == domain.EstadoInvalido
+}
+
+// EliminarDocumento solo borra borradores que nunca se publicaron
+func (s *documentService) EliminarDocumento(contexto context.Context, rucEmisor, id string) error {
+	documento, err := s.repo.BuscarPorID(contexto, rucEmisor, id)
+	if err != nil {
+		return err
+	}
+
+	if documento.CodigoEstado() != domain.EstadoBorrador {
+		return errors.ErrorConflicto(fmt.Sprintf("El documento %s ya fue emitido; anúlelo con POST /documents/%s/void", id, id))
+	}
+
+	if err := s.repo.Eliminar(contexto, documento.RucEmisor, id); err != nil {
+		return err
+	}
+	idReferencia, credito := creditoDeNota(documento)
+	s.liberarCredito(contexto, documento.RucEmisor, idReferencia, credito)
+
+	if s.series != nil && documento.Serie != "" {
+		correlativo, _ := strconv.ParseInt(strings.TrimPrefix(id, documento.Serie+"-"), 10, 64)
+		s.liberarCorrelativo(contexto, documento, correlativo)
+	}
+	return nil
+}
+
+func (s *documentService) AnularDocumento(contexto context.Context, rucEmisor, id, motivo string) (*domain.Document, *domain.ComunicacionBaja, error) {
+	if s.comunicacionesBaja == nil {
+		return nil, nil, errors.ErrorInterno("La anulación de documentos no está configurada")
+	}
+
+	motivo = strings.TrimSpace(motivo)
+	if motivo == "" {
+		return nil, nil, errors.ErrorValidacion("motivo es obligatorio")
+	}
+	if len([]rune(motivo)) > longitudMaximaMotivoBaja {
+		return nil, nil, errors.ErrorValidacion(fmt.Sprintf("motivo no puede tener más de %d caracteres", longitudMaximaMotivoBaja))
+	}
+
+	documento, err := s.repo.BuscarPorID(contexto, rucEmisor, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch documento.CodigoEstado() {
+	case domain.EstadoAnulado:
+		return nil, nil, errors.ErrorConflicto(fmt.Sprintf("El documento %s ya fue anulado", id))
+	case domain.EstadoBorrador:
+		return nil, nil, errors.ErrorConflicto(fmt.Sprintf("El documento %s no fue emitido; elimínelo en lugar de anularlo", id))
+	}
+
+	ahora := time.Now().In(domain.ZonaHorariaPeru)
+	emision, err := time.Parse(time.RFC3339, documento.FechaEmision)
+	if err != nil {
+		return nil, nil, errors.ErrorInterno(fmt.Sprintf("El documento %s tiene una fecha de emisión inválida", id))
+	}
+	fechaReferencia := emision.In(domain.ZonaHorariaPeru).Format(formatoFecha)
+	if diasEntre(fechaReferencia, ahora.Format(formatoFecha)) > domain.PlazoComunicacionBaja {
+		return nil, nil, errors.ErrorValidacion(fmt.Sprintf("El plazo para anular el documento venció: SUNAT admite la baja hasta %d días después de la emisión", domain.PlazoComunicacionBaja))
+	}
+
+	anulacion := domain.Anulacion{Motivo: motivo, FechaAnulacion: ahora.Format(time.RFC3339)}
+	if err := s.repo.Anular(contexto, documento.RucEmisor, id, anulacion); err != nil {
+		return nil, nil, err
+	}
+	documento.Estado = domain.EstadoAnulado
+	documento.Anulacion = &anulacion
+
+	idReferencia, credito := creditoDeNota(documento)
+	if documento.EsDeBoleta() {
+		s.liberarCredito(contexto, documento.RucEmisor, idReferencia, credito)
+		return documento, nil, nil
+	}
+
+	item := domain.ItemBaja{IDDocumento: documento.IDDocumento, TipoDocumento: documento.TipoDocumento, Motivo: motivo}
+	comunicacion, err := s.comunicacionesBaja.AgregarItem(contexto, documento.RucEmisor, ahora.Format(formatoFecha), fechaReferencia, item)
+	if err != nil {
+		if errRevertir := s.repo.RevertirAnulacion(contexto, documento.RucEmisor, id); errRevertir != nil {
+			config.Logger.Error("No se pudo revertir la anulación sin comunicación de baja",
+				zap.String("idDocumento", id),
+				zap.Error(errRevertir),
+			)
+		}
+		return nil, nil, err
+	}
+	s.liberarCredito(contexto, documento.RucEmisor, idReferencia, credito)
+
+	return documento, comunicacion, nil
+}
+
+func (s *documentService) ListarComunicacionesBaja(contexto context.Context, rucEmisor, fechaGeneracion string) ([]domain.ComunicacionBaja, error) {
+	if s.comunicacionesBaja == nil {
+		return []domain.ComunicacionBaja{}, nil
+	}
+	return s.comunicacionesBaja.ListarComunicaciones(contexto, rucEmisor, fechaGeneracion)
+}
+
+func (s *documentService) ListarDocumentosVencidos(contexto context.Context, rucEmisor, fecha string) ([]domain.DocumentoVencido, error) {
+	if fecha == "" {
+		fecha = time.Now().In(domain.ZonaHorariaPeru).Format(formatoFecha)
+	} else if _, err := time.Parse(formatoFecha, fecha); err != nil {
+		return nil, errors.ErrorValidacion("fecha debe tener el formato AAAA-MM-DD")
+	}
+
+	documentos, err := s.repo.BuscarConCuotasVencidas(contexto, rucEmisor, fecha)
+	if err != nil {
+		return nil, err
+	}
+
+	vencidos := []domain.DocumentoVencido{}
+	for _, documento := range documentos {
+		if documento.FormaPago == nil {
+			continue
+		}
+
+		vencido := domain.DocumentoVencido{
+			IDDocumento:    documento.IDDocumento,
+			RucEmisor:      documento.RucEmisor,
+			RucReceptor:    documento.RucReceptor,
+			FechaEmision:   documento.FechaEmision,
+			Moneda:         documento.CodigoMoneda(),
+			MontoPendiente: documento.FormaPago.MontoPendiente,
+			CuotasVencidas: []domain.Cuota{},
+			MontoVencido:   money.Cero(),
+		}
+		for _, cuota := range documento.FormaPago.Cuotas {
+			if cuota.FechaVencimiento >= fecha {
+				continue
+			}
+			vencido.CuotasVencidas = append(vencido.CuotasVencidas, cuota)
+			vencido.MontoVencido = vencido.MontoVencido.Sumar(cuota.Monto)
+			vencido.DiasVencido = max(vencido.DiasVencido, diasEntre(cuota.FechaVencimiento, fecha))
+		}
+		if len(vencido.CuotasVencidas) > 0 {
+			vencidos = append(vencidos, vencido)
+		}
+	}
+
+	return vencidos, nil
+}
+
+func diasEntre(desde, hasta string) int {
+	inicio, _ := time.Parse(formatoFecha, desde)
+	fin, _ := time.Parse(formatoFecha, hasta)
+	return int(fin.Sub(inicio).Hours() / 24)
 }
 
 func (s *documentService) VerificarDocumento(contexto context.Context, documento *domain.Document, firma string) (bool, error) {
-	documentoExistente, err := s.repo.BuscarPorID(contexto, documento.IDDocumento)
+	documentoExistente, err := s.repo.BuscarPorID(contexto, documento.RucEmisor, documento.IDDocumento)
 	if err != nil {
 		return false, errors.ErrorNoEncontrado("Documento no existe en el sistema")
 	}
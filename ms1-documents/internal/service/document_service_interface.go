@@ -7,9 +7,14 @@ import (
 
 type DocumentService interface {
 	CrearDocumento(contexto context.Context, documento *domain.Document) error
+	ValidarDocumento(contexto context.Context, documento *domain.Document) (*domain.ReporteValidacion, error)
 	ObtenerTodosDocumentos(contexto context.Context) ([]domain.Document, error)
-	ObtenerDocumentoPorID(contexto context.Context, id string) (*domain.Document, error)
+	ObtenerDocumentoPorID(contexto context.Context, rucEmisor, id string) (*domain.Document, error)
 	ActualizarDocumento(contexto context.Context, id string, documento *domain.Document) error
-	EliminarDocumento(contexto context.Context, id string) error
+	EliminarDocumento(contexto context.Context, rucEmisor, id string) error
+	AnularDocumento(contexto context.Context, rucEmisor, id, motivo string) (*domain.Document, *domain.ComunicacionBaja, error)
+	ListarComunicacionesBaja(contexto context.Context, rucEmisor, fechaGeneracion string) ([]domain.ComunicacionBaja, error)
+	ListarDocumentosVencidos(contexto context.Context, rucEmisor, fecha string) ([]domain.DocumentoVencido, error)
+	ListarSeries(contexto context.Context, rucEmisor string) ([]domain.SerieCorrelativo, error)
 	VerificarDocumento(contexto context.Context, documento *domain.Document, firma string) (bool, error)
 }
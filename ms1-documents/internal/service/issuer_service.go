@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/repository"
+	"ms1-documents/internal/validator"
+	"ms1-documents/pkg/errors"
+	"time"
+)
+
+type issuerService struct {
+	repo      repository.IssuerRepository
+	validator *validator.DocumentValidator
+}
+
+func NewIssuerService(repo repository.IssuerRepository, validator *validator.DocumentValidator) IssuerService {
+	return &issuerService{
+		repo:      repo,
+		validator: validator,
+	}
+}
+
+func (s *issuerService) CrearEmisor(contexto context.Context, emisor *domain.Emisor) error {
+	if err := s.validator.ValidarEmisor(emisor); err != nil {
+		return err
+	}
+
+	ahora := time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	emisor.FechaCreacion = ahora
+	emisor.FechaActualizacion = ahora
+	return s.repo.Crear(contexto, emisor)
+}
+
+func (s *issuerService) ObtenerEmisores(contexto context.Context) ([]domain.Emisor, error) {
+	return s.repo.BuscarTodos(contexto)
+}
+
+func (s *issuerService) ObtenerEmisor(contexto context.Context, ruc string) (*domain.Emisor, error) {
+	return s.repo.BuscarPorRUC(contexto, ruc)
+}
+
+func (s *issuerService) ActualizarEmisor(contexto context.Context, ruc string, emisor *domain.Emisor) error {
+	if emisor.RUC == "" {
+		emisor.RUC = ruc
+	}
+	if emisor.RUC != ruc {
+		return errors.ErrorValidacion(fmt.Sprintf("No se puede cambiar el RUC del emisor %s", ruc))
+	}
+
+	if err := s.validator.ValidarEmisor(emisor); err != nil {
+		return err
+	}
+
+	existente, err := s.repo.BuscarPorRUC(contexto, ruc)
+	if err != nil {
+		return err
+	}
+
+	emisor.FechaCreacion = existente.FechaCreacion
+	emisor.FechaActualizacion = time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	return s.repo.Actualizar(contexto, ruc, emisor)
+}
+
+func (s *issuerService) EliminarEmisor(contexto context.Context, ruc string) error {
+	return s.repo.Eliminar(contexto, ruc)
+}
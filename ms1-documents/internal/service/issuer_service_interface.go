@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type IssuerService interface {
+	CrearEmisor(contexto context.Context, emisor *domain.Emisor) error
+	ObtenerEmisores(contexto context.Context) ([]domain.Emisor, error)
+	ObtenerEmisor(contexto context.Context, ruc string) (*domain.Emisor, error)
+	ActualizarEmisor(contexto context.Context, ruc string, emisor *domain.Emisor) error
+	EliminarEmisor(contexto context.Context, ruc string) error
+}
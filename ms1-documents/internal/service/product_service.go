@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/repository"
+	"ms1-documents/internal/validator"
+	"ms1-documents/pkg/errors"
+	"time"
+)
+
+type productService struct {
+	repo      repository.ProductRepository
+	validator *validator.DocumentValidator
+}
+
+func NewProductService(repo repository.ProductRepository, validator *validator.DocumentValidator) ProductService {
+	return &productService{
+		repo:      repo,
+		validator: validator,
+	}
+}
+
+func (s *productService) CrearProducto(contexto context.Context, producto *domain.Producto) error {
+	if err := s.validator.ValidarProducto(producto); err != nil {
+		return err
+	}
+
+	ahora := time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	producto.FechaCreacion = ahora
+	producto.FechaActualizacion = ahora
+	return s.repo.Crear(contexto, producto)
+}
+
+func (s *productService) ObtenerProductos(contexto context.Context, rucEmisor string) ([]domain.Producto, error) {
+	return s.repo.BuscarPorEmisor(contexto, rucEmisor)
+}
+
+func (s *productService) ObtenerProducto(contexto context.Context, rucEmisor, codigo string) (*domain.Producto, error) {
+	return s.repo.BuscarPorCodigo(contexto, rucEmisor, codigo)
+}
+
+func (s *productService) ActualizarProducto(contexto context.Context, rucEmisor, codigo string, producto *domain.Producto) error {
+	if producto.Codigo == "" {
+		producto.Codigo = codigo
+	}
+	if producto.Codigo != codigo {
+		return errors.ErrorValidacion(fmt.Sprintf("No se puede cambiar el código del producto %s", codigo))
+	}
+	producto.RucEmisor = rucEmisor
+
+	if err := s.validator.ValidarProducto(producto); err != nil {
+		return err
+	}
+
+	existente, err := s.repo.BuscarPorCodigo(contexto, rucEmisor, codigo)
+	if err != nil {
+		return err
+	}
+
+	producto.FechaCreacion = existente.FechaCreacion
+	producto.FechaActualizacion = time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
+	return s.repo.Actualizar(contexto, rucEmisor, codigo, producto)
+}
+
+func (s *productService) EliminarProducto(contexto context.Context, rucEmisor, codigo string) error {
+	return s.repo.Eliminar(contexto, rucEmisor, codigo)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+)
+
+type ProductService interface {
+	CrearProducto(contexto context.Context, producto *domain.Producto) error
+	ObtenerProductos(contexto context.Context, rucEmisor string) ([]domain.Producto, error)
+	ObtenerProducto(contexto context.Context, rucEmisor, codigo string) (*domain.Producto, error)
+	ActualizarProducto(contexto context.Context, rucEmisor, codigo string, producto *domain.Producto) error
+	EliminarProducto(contexto context.Context, rucEmisor, codigo string) error
+}
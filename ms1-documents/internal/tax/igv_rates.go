@@ -0,0 +1,142 @@
+package tax
+
+import (
+	"encoding/json"
+	"fmt"
+	"ms1-documents/pkg/money"
+	"os"
+	"strings"
+	"time"
+)
+
+// Regímenes de IGV que determinan la tasa aplicable
+const (
+	RegimenGeneral              = "GENERAL"
+	RegimenMypeRestauranteHotel = "MYPE_RESTAURANTE_HOTEL"
+)
+
+const formatoFecha = "2006-01-02"
+
+// ProveedorTasasIGV resuelve la tasa de IGV (incluido el IPM) vigente para un régimen en una fecha
+type ProveedorTasasIGV interface {
+	TasaIGV(regimen string, fecha time.Time) (money.Money, error)
+}
+
+// VigenciaTasa es una tasa aplicable a un régimen entre Desde y Hasta (ambos inclusive, Hasta vacío = indefinido)
+type VigenciaTasa struct {
+	Regimen string      `json:"regimen"`
+	Desde   string      `json:"desde"`
+	Hasta   string      `json:"hasta,omitempty"`
+	Tasa    money.Money `json:"tasa"`
+}
+
+type vigencia struct {
+	regimen string
+	desde   time.Time
+	hasta   time.Time
+	tasa    money.Money
+}
+
+type TablaTasasIGV struct {
+	vigencias []vigencia
+}
+
+// vigenciasPorDefecto recoge la tasa general y la tasa reducida de la Ley 31556,
+// prorrogada por la Ley 32219, para micro y pequeñas empresas de restaurantes y hoteles
+var vigenciasPorDefecto = []VigenciaTasa{
+	{Regimen: RegimenGeneral, Desde: "2003-08-01", Hasta: "2011-02-28", Tasa: money.MustParse("0.19")},
+	{Regimen: RegimenGeneral, Desde: "2011-03-01", Tasa: money.MustParse("0.18")},
+	{Regimen: RegimenMypeRestauranteHotel, Desde: "2022-09-01", Hasta: "2026-12-31", Tasa: money.MustParse("0.10")},
+}
+
+func NewTablaTasasIGV(vigencias []VigenciaTasa) (*TablaTasasIGV, error) {
+	tabla := &TablaTasasIGV{}
+
+	for indice, entrada := range vigencias {
+		regimen := strings.ToUpper(strings.TrimSpace(entrada.Regimen))
+		if !EsRegimenValido(regimen) {
+			return nil, fmt.Errorf("vigencia %d: régimen desconocido %q", indice, entrada.Regimen)
+		}
+
+		desde, err := time.Parse(formatoFecha, entrada.Desde)
+		if err != nil {
+			return nil, fmt.Errorf("vigencia %d: desde debe tener formato AAAA-MM-DD", indice)
+		}
+
+		hasta := time.Time{}
+		if entrada.Hasta != "" {
+			if hasta, err = time.Parse(formatoFecha, entrada.Hasta); err != nil {
+				return nil, fmt.Errorf("vigencia %d: hasta debe tener formato AAAA-MM-DD", indice)
+			}
+			if hasta.Before(desde) {
+				return nil, fmt.Errorf("vigencia %d: hasta es anterior a desde", indice)
+			}
+		}
+
+		if !entrada.Tasa.EsPositivo() || entrada.Tasa.Comparar(money.NewFromInt(1)) >= 0 {
+			return nil, fmt.Errorf("vigencia %d: la tasa debe estar entre 0 y 1", indice)
+		}
+
+		tabla.vigencias = append(tabla.vigencias, vigencia{regimen, desde, hasta, entrada.Tasa})
+	}
+
+	return tabla, nil
+}
+
+// TablaPorDefecto devuelve la tabla de tasas incluida en el servicio
+func TablaPorDefecto() *TablaTasasIGV {
+	tabla, err := NewTablaTasasIGV(vigenciasPorDefecto)
+	if err != nil {
+		panic(err)
+	}
+	return tabla
+}
+
+// CargarTablaTasasIGV lee una tabla de vigencias en JSON que reemplaza a la incluida por defecto
+func CargarTablaTasasIGV(ruta string) (*TablaTasasIGV, error) {
+	contenido, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, err
+	}
+
+	var vigencias []VigenciaTasa
+	if err := json.Unmarshal(contenido, &vigencias); err != nil {
+		return nil, fmt.Errorf("tabla de tasas IGV inválida: %w", err)
+	}
+
+	return NewTablaTasasIGV(vigencias)
+}
+
+// TasaIGV busca la vigencia del régimen que cubre la fecha. Cuando un régimen especial no tiene
+// tasa vigente se aplica la del régimen general, como ocurre al terminar un beneficio temporal.
+func (t *TablaTasasIGV) TasaIGV(regimen string, fecha time.Time) (money.Money, error) {
+	dia := time.Date(fecha.Year(), fecha.Month(), fecha.Day(), 0, 0, 0, 0, time.UTC)
+
+	if tasa, ok := t.buscar(regimen, dia); ok {
+		return tasa, nil
+	}
+	if regimen != RegimenGeneral {
+		if tasa, ok := t.buscar(RegimenGeneral, dia); ok {
+			return tasa, nil
+		}
+	}
+
+	return money.Money{}, fmt.Errorf("no hay tasa de IGV vigente para el régimen %s al %s", regimen, dia.Format(formatoFecha))
+}
+
+func (t *TablaTasasIGV) buscar(regimen string, dia time.Time) (money.Money, bool) {
+	for _, vigencia := range t.vigencias {
+		if vigencia.regimen != regimen || dia.Before(vigencia.desde) {
+			continue
+		}
+		if !vigencia.hasta.IsZero() && dia.After(vigencia.hasta) {
+			continue
+		}
+		return vigencia.tasa, true
+	}
+	return money.Money{}, false
+}
+
+func EsRegimenValido(regimen string) bool {
+	return regimen == RegimenGeneral || regimen == RegimenMypeRestauranteHotel
+}
@@ -0,0 +1,87 @@
+package tax
+
+import (
+	"ms1-documents/pkg/money"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func fecha(texto string) time.Time {
+	valor, _ := time.Parse(time.RFC3339, texto)
+	return valor
+}
+
+func TestTablaPorDefecto_TasaIGV(t *testing.T) {
+	testCases := []struct {
+		name     string
+		regimen  string
+		fecha    string
+		esperado string
+	}{
+		{"General vigente", RegimenGeneral, "2026-02-12T10:00:00Z", "0.18"},
+		{"General antes de 2011", RegimenGeneral, "2010-06-01T10:00:00Z", "0.19"},
+		{"MYPE restaurante con tasa reducida", RegimenMypeRestauranteHotel, "2024-05-10T10:00:00Z", "0.10"},
+		{"MYPE antes de la ley usa la general", RegimenMypeRestauranteHotel, "2022-08-31T23:00:00Z", "0.18"},
+		{"MYPE después de la vigencia usa la general", RegimenMypeRestauranteHotel, "2027-01-01T10:00:00Z", "0.18"},
+	}
+
+	tabla := TablaPorDefecto()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tasa, err := tabla.TasaIGV(tc.regimen, fecha(tc.fecha))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !tasa.Igual(money.MustParse(tc.esperado)) {
+				t.Errorf("Expected %s, got %s", tc.esperado, tasa)
+			}
+		})
+	}
+}
+
+func TestTablaTasasIGV_SinVigencia(t *testing.T) {
+	tabla := TablaPorDefecto()
+	if _, err := tabla.TasaIGV(RegimenGeneral, fecha("2000-01-01T00:00:00Z")); err == nil {
+		t.Error("Expected error for a date without an applicable rate")
+	}
+}
+
+func TestNewTablaTasasIGV_Invalida(t *testing.T) {
+	testCases := []struct {
+		name     string
+		vigencia VigenciaTasa
+	}{
+		{"Régimen desconocido", VigenciaTasa{Regimen: "AGRARIO", Desde: "2020-01-01", Tasa: money.MustParse("0.15")}},
+		{"Fecha inválida", VigenciaTasa{Regimen: RegimenGeneral, Desde: "01/01/2020", Tasa: money.MustParse("0.18")}},
+		{"Hasta antes de desde", VigenciaTasa{Regimen: RegimenGeneral, Desde: "2020-01-01", Hasta: "2019-01-01", Tasa: money.MustParse("0.18")}},
+		{"Tasa fuera de rango", VigenciaTasa{Regimen: RegimenGeneral, Desde: "2020-01-01", Tasa: money.MustParse("18")}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewTablaTasasIGV([]VigenciaTasa{tc.vigencia}); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestCargarTablaTasasIGV(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "tasas.json")
+	contenido := `[{"regimen":"GENERAL","desde":"2011-03-01","tasa":0.18},{"regimen":"MYPE_RESTAURANTE_HOTEL","desde":"2022-09-01","tasa":"0.105"}]`
+	if err := os.WriteFile(ruta, []byte(contenido), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tabla, err := CargarTablaTasasIGV(ruta)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tasa, _ := tabla.TasaIGV(RegimenMypeRestauranteHotel, fecha("2030-01-01T00:00:00Z"))
+	if tasa.String() != "0.105" {
+		t.Errorf("Expected 0.105, got %s", tasa)
+	}
+}
@@ -0,0 +1,504 @@
+package ubl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"ms1-documents/internal/catalog"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/letras"
+	"ms1-documents/pkg/money"
+	"strconv"
+	"time"
+)
+
+const (
+	VersionUBL      = "2.1"
+	VersionSUNAT    = "2.0"
+	UnidadMedidaNIU = "NIU"
+	// ListaUNSPSC es el listID del código de producto SUNAT en cac:CommodityClassification
+	ListaUNSPSC = "UNSPSC"
+	// LeyendaMontoEnLetras es el código del catálogo 52 para el importe total en letras
+	LeyendaMontoEnLetras = "1000"
+	// LeyendaDetraccion es el código del catálogo 52 para operaciones sujetas al SPOT
+	LeyendaDetraccion = "2006"
+	// IDFormaPago identifica los cac:PaymentTerms con la forma de pago y las cuotas
+	IDFormaPago = "FormaPago"
+	// IDDetraccion identifica el cac:PaymentMeans con la cuenta y el cac:PaymentTerms con el monto de la detracción
+	IDDetraccion = "Detraccion"
+	// IDPercepcion identifica el cac:PaymentTerms con el total cobrado incluida la percepción
+	IDPercepcion = "Percepcion"
+	// MedioPagoDeposito es el código del catálogo 59 para el depósito en cuenta
+	MedioPagoDeposito = "001"
+)
+
+// monedaSoles es la moneda en que se informan la detracción, la percepción y la retención
+const monedaSoles = moneda(domain.MonedaPEN)
+
+type tributo struct {
+	id, nombre, tipo, categoria string
+}
+
+// Tributos del catálogo 05 que se informan en TaxSubtotal
+var (
+	tributoIGV       = tributo{"1000", "IGV", "VAT", "S"}
+	tributoExonerado = tributo{"9997", "EXO", "VAT", "E"}
+	tributoInafecto  = tributo{"9998", "INA", "FRE", "O"}
+	tributoGratuito  = tributo{"9996", "GRA", "FRE", "Z"}
+	tributoISC       = tributo{"2000", "ISC", "EXC", "S"}
+	tributoICBPER    = tributo{"7152", "ICBPER", "OTH", ""}
+)
+
+var tributosPorCategoria = map[string]tributo{
+	domain.CategoriaGravado:   tributoIGV,
+	domain.CategoriaExonerado: tributoExonerado,
+	domain.CategoriaInafecto:  tributoInafecto,
+}
+
+// Generar serializa el documento como Invoice (factura y boleta), CreditNote o DebitNote de UBL 2.1.
+// Si el documento ya fue validado por MS2 se incluye su firma en UBLExtensions.
+func Generar(doc *domain.Document) ([]byte, error) {
+	comprobante, err := NuevoComprobante(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	contenido, err := xml.MarshalIndent(comprobante, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), contenido...), nil
+}
+
+// NuevoComprobante construye la estructura UBL sin serializarla
+func NuevoComprobante(doc *domain.Document) (*Comprobante, error) {
+	raiz, ok := raizPorTipo(doc.TipoDocumento)
+	if !ok {
+		return nil, fmt.Errorf("tipo de documento %q no tiene representación UBL", doc.TipoDocumento)
+	}
+
+	monedaDocumento := moneda(doc.CodigoMoneda())
+	comprobante := &Comprobante{
+		XMLName:              xml.Name{Local: raiz},
+		Xmlns:                namespacePorRaiz[raiz],
+		XmlnsCac:             NamespaceCac,
+		XmlnsCbc:             NamespaceCbc,
+		XmlnsExt:             NamespaceExt,
+		XmlnsDs:              NamespaceDs,
+		UBLVersionID:         VersionUBL,
+		CustomizationID:      VersionSUNAT,
+		ID:                   doc.IDDocumento,
+		DocumentCurrencyCode: string(monedaDocumento),
+		Proveedor:            proveedor(doc),
+		Cliente:              cliente(doc),
+	}
+
+	if fecha, err := time.Parse(time.RFC3339, doc.FechaEmision); err == nil {
+		comprobante.IssueDate = fecha.Format("2006-01-02")
+		comprobante.IssueTime = fecha.Format("15:04:05")
+	}
+
+	if montoEnLetras, err := letras.MontoEnLetras(doc.MontoTotal, doc.CodigoMoneda()); err == nil {
+		comprobante.Notas = []Nota{{Codigo: LeyendaMontoEnLetras, Valor: montoEnLetras}}
+	}
+	if doc.Detraccion != nil {
+		comprobante.Notas = append(comprobante.Notas, Nota{Codigo: LeyendaDetraccion, Valor: "Operación sujeta a detracción"})
+	}
+
+	if doc.Validacion != nil && doc.Validacion.Firma != "" {
+		firma := &FirmaDigital{ID: "SignatureSP", SignatureValue: doc.Validacion.Firma}
+		if doc.Validacion.DigestValue != "" {
+			firma.SignedInfo = &InfoFirmada{Referencia: ReferenciaFirmada{
+				DigestMethod: MetodoResumen{Algorithm: "http://www.w3.org/2001/04/xmlenc#sha256"},
+				DigestValue:  doc.Validacion.DigestValue,
+			}}
+		}
+		comprobante.Extensiones = &Extensiones{Extension: []Extension{{Contenido: ContenidoExtension{Firma: firma}}}}
+		comprobante.Signature = &FirmaReferencia{
+			ID:             doc.IDDocumento,
+			SignatoryParty: participante(domain.IdentidadRUC, doc.RucEmisor).Party,
+			Adjunto:        AdjuntoFirma{ExternalReference: ReferenciaExterna{URI: "#SignatureSP"}},
+		}
+	}
+
+	if doc.Referencia != nil {
+		comprobante.DiscrepancyResponse = &Discrepancia{
+			ReferenceID:  doc.Referencia.IDDocumento,
+			ResponseCode: doc.Referencia.CodigoMotivo,
+			Description:  doc.Referencia.Sustento,
+		}
+		comprobante.BillingReference = &ReferenciaFacturacion{Documento: DocumentoReferenciado{
+			ID:               doc.Referencia.IDDocumento,
+			DocumentTypeCode: doc.Referencia.TipoDocumento,
+		}}
+	}
+
+	if doc.OrdenCompra != "" {
+		comprobante.OrderReference = &ReferenciaOrden{ID: doc.OrdenCompra}
+	}
+
+	if doc.TipoDocumento == domain.TipoFactura || doc.FormaPago != nil {
+		comprobante.TerminosPago = terminosPago(doc, monedaDocumento)
+	}
+	if doc.Detraccion != nil {
+		comprobante.MediosPago = []MedioPago{{
+			ID:                    IDDetraccion,
+			PaymentMeansCode:      MedioPagoDeposito,
+			PayeeFinancialAccount: CuentaFinanciera{ID: doc.Detraccion.CuentaBancoNacion},
+		}}
+		comprobante.TerminosPago = append(comprobante.TerminosPago, TerminoPago{
+			ID:             IDDetraccion,
+			PaymentMeansID: doc.Detraccion.Codigo,
+			PaymentPercent: doc.Detraccion.Porcentaje.MultiplicarEntero(100).String(),
+			Amount:         monedaSoles.importePuntero(doc.Detraccion.Monto),
+		})
+	}
+	if doc.Percepcion != nil {
+		comprobante.TerminosPago = append(comprobante.TerminosPago, TerminoPago{
+			ID:     IDPercepcion,
+			Amount: monedaSoles.importePuntero(doc.Percepcion.MontoTotalCobrado),
+		})
+	}
+
+	for _, cargoDescuento := range doc.CargosDescuentos {
+		comprobante.CargosDescuentos = append(comprobante.CargosDescuentos, cargoDescuentoUBL(cargoDescuento, monedaDocumento))
+	}
+	if doc.Percepcion != nil {
+		comprobante.CargosDescuentos = append(comprobante.CargosDescuentos, cargoDescuentoUBL(domain.CargoDescuento{
+			Codigo:    doc.Percepcion.Codigo,
+			EsCargo:   true,
+			Factor:    doc.Percepcion.Porcentaje,
+			MontoBase: doc.Percepcion.MontoBase,
+			Monto:     doc.Percepcion.Monto,
+		}, monedaSoles))
+	}
+	if doc.Retencion != nil {
+		comprobante.CargosDescuentos = append(comprobante.CargosDescuentos, cargoDescuentoUBL(domain.CargoDescuento{
+			Codigo:    domain.CodigoRetencion,
+			Factor:    doc.Retencion.Porcentaje,
+			MontoBase: doc.Retencion.MontoBase,
+			Monto:     doc.Retencion.Monto,
+		}, monedaSoles))
+	}
+
+	comprobante.TaxTotal = totalImpuestos(doc)
+	totalMonetario := totalMonetario(doc)
+
+	lineas := make([]Linea, 0, len(doc.Items))
+	for indice, item := range doc.Items {
+		lineas = append(lineas, linea(indice+1, item, doc.TasaIgv, doc.TipoDocumento, monedaDocumento))
+	}
+
+	switch doc.TipoDocumento {
+	case domain.TipoNotaCredito:
+		comprobante.LegalMonetaryTotal = totalMonetario
+		comprobante.CreditNoteLines = lineas
+	case domain.TipoNotaDebito:
+		comprobante.RequestedTotal = totalMonetario
+		comprobante.DebitNoteLines = lineas
+	default:
+		comprobante.InvoiceTypeCode = &Codigo{ListID: tipoOperacion(doc), Valor: doc.TipoDocumento}
+		comprobante.LegalMonetaryTotal = totalMonetario
+		comprobante.InvoiceLines = lineas
+	}
+
+	return comprobante, nil
+}
+
+var namespacePorRaiz = map[string]string{
+	"Invoice":    NamespaceInvoice,
+	"CreditNote": NamespaceCreditNote,
+	"DebitNote":  NamespaceDebitNote,
+}
+
+func raizPorTipo(tipoDocumento string) (string, bool) {
+	switch tipoDocumento {
+	case domain.TipoFactura, domain.TipoBoleta:
+		return "Invoice", true
+	case domain.TipoNotaCredito:
+		return "CreditNote", true
+	case domain.TipoNotaDebito:
+		return "DebitNote", true
+	}
+	return "", false
+}
+
+// tipoOperacion elige el código del catálogo 51 según si la venta está sujeta a detracción o percepción
+func tipoOperacion(doc *domain.Document) string {
+	switch {
+	case doc.Detraccion != nil:
+		return catalog.OperacionDetraccion
+	case doc.Percepcion != nil:
+		return catalog.OperacionPercepcion
+	}
+	return catalog.OperacionVentaInterna
+}
+
+// terminosPago informa la forma de pago y, en ventas al crédito, el neto pendiente y cada cuota
+func terminosPago(doc *domain.Document, m moneda) []TerminoPago {
+	if doc.CodigoFormaPago() == domain.FormaPagoContado {
+		return []TerminoPago{{ID: IDFormaPago, PaymentMeansID: domain.FormaPagoContado}}
+	}
+
+	terminos := []TerminoPago{{ID: IDFormaPago, PaymentMeansID: domain.FormaPagoCredito, Amount: m.importePuntero(doc.FormaPago.MontoPendiente)}}
+	for indice, cuota := range doc.FormaPago.Cuotas {
+		terminos = append(terminos, TerminoPago{
+			ID:             IDFormaPago,
+			PaymentMeansID: fmt.Sprintf("Cuota%03d", indice+1),
+			Amount:         m.importePuntero(cuota.Monto),
+			PaymentDueDate: cuota.FechaVencimiento,
+		})
+	}
+	return terminos
+}
+
+// proveedor agrega al RUC del emisor la razón social, el nombre comercial y el domicilio guardados con el documento
+func proveedor(doc *domain.Document) Participante {
+	resultado := participante(domain.IdentidadRUC, doc.RucEmisor)
+	if doc.Emisor == nil {
+		return resultado
+	}
+
+	if doc.Emisor.NombreComercial != "" {
+		resultado.Party.Nombre = &NombreParte{Name: doc.Emisor.NombreComercial}
+	}
+	resultado.Party.EntidadLegal = &EntidadLegal{
+		RegistrationName: doc.Emisor.RazonSocial,
+		RegistrationAddress: &DireccionRegistro{
+			ID:              doc.Emisor.Ubigeo,
+			AddressTypeCode: doc.Emisor.CodigoEstablecimiento,
+			AddressLine:     LineaDomicilio{Line: doc.Emisor.Direccion},
+		},
+	}
+	return resultado
+}
+
+func cliente(doc *domain.Document) Participante {
+	resultado := participante(doc.TipoDocumentoReceptor, doc.RucReceptor)
+	if doc.Receptor == nil {
+		return resultado
+	}
+
+	resultado.Party.EntidadLegal = &EntidadLegal{RegistrationName: doc.Receptor.Nombre}
+	if doc.Receptor.Direccion != "" {
+		resultado.Party.EntidadLegal.RegistrationAddress = &DireccionRegistro{AddressLine: LineaDomicilio{Line: doc.Receptor.Direccion}}
+	}
+	if doc.Receptor.Email != "" {
+		resultado.Party.Contacto = &Contacto{ElectronicMail: doc.Receptor.Email}
+	}
+	return resultado
+}
+
+func participante(tipoIdentidad, numero string) Participante {
+	return Participante{Party: Parte{
+		Identificacion: IdentificacionParte{ID: Identificador{SchemeID: tipoIdentidad, Valor: numero}},
+	}}
+}
+
+// totalImpuestos agrupa los montos del documento por tributo; solo se informan los que tienen base o impuesto
+func totalImpuestos(doc *domain.Document) TotalImpuestos {
+	m := moneda(doc.CodigoMoneda())
+	total := TotalImpuestos{TaxAmount: m.importe(doc.IgvTotal.Sumar(doc.IscTotal).Sumar(doc.IcbperTotal))}
+
+	subtotales := []struct {
+		tributo  tributo
+		base     money.Money
+		impuesto money.Money
+	}{
+		{tributoIGV, doc.TotalGravado.Sumar(doc.IscTotal), doc.IgvTotal},
+		{tributoExonerado, doc.TotalExonerado, money.Cero()},
+		{tributoInafecto, doc.TotalInafecto, money.Cero()},
+		{tributoGratuito, doc.TotalGratuito, money.Cero()},
+		{tributoISC, doc.TotalGravado, doc.IscTotal},
+	}
+
+	for _, subtotal := range subtotales {
+		if subtotal.impuesto.EsCero() && (subtotal.base.EsCero() || subtotal.tributo == tributoISC) {
+			continue
+		}
+		total.TaxSubtotals = append(total.TaxSubtotals, SubtotalImpuesto{
+			TaxableAmount: m.importePuntero(subtotal.base),
+			TaxAmount:     m.importe(subtotal.impuesto),
+			TaxCategory:   CategoriaImpuesto{TaxScheme: esquema(subtotal.tributo)},
+		})
+	}
+
+	if !doc.IcbperTotal.EsCero() {
+		total.TaxSubtotals = append(total.TaxSubtotals, SubtotalImpuesto{
+			TaxAmount:   m.importe(doc.IcbperTotal),
+			TaxCategory: CategoriaImpuesto{TaxScheme: esquema(tributoICBPER)},
+		})
+	}
+
+	return total
+}
+
+func totalMonetario(doc *domain.Document) *TotalMonetario {
+	m := moneda(doc.CodigoMoneda())
+	total := &TotalMonetario{
+		LineExtensionAmount: m.importe(doc.MontoTotalSinImpuestos),
+		TaxInclusiveAmount:  m.importe(doc.MontoTotalSinImpuestos.Sumar(doc.IscTotal).Sumar(doc.IgvTotal).Sumar(doc.IcbperTotal)),
+		PayableAmount:       m.importe(doc.MontoTotal),
+	}
+
+	descuentos, cargos := money.Cero(), money.Cero()
+	for _, cargoDescuento := range doc.CargosDescuentos {
+		if cargoDescuento.AfectaBase {
+			continue
+		}
+		if cargoDescuento.EsCargo {
+			cargos = cargos.Sumar(cargoDescuento.Monto)
+		} else {
+			descuentos = descuentos.Sumar(cargoDescuento.Monto)
+		}
+	}
+	if !descuentos.EsCero() {
+		total.AllowanceTotalAmount = m.importePuntero(descuentos)
+	}
+	if !cargos.EsCero() {
+		total.ChargeTotalAmount = m.importePuntero(cargos)
+	}
+
+	return total
+}
+
+// linea arma la línea del comprobante. El precio de referencia es el precio unitario con impuestos
+// (tipo 01) o, en transferencias gratuitas, el valor referencial (tipo 02) con precio de venta cero.
+func linea(numero int, item domain.Item, tasaIGV money.Money, tipoDocumento string, m moneda) Linea {
+	categoria, gratuito, _ := domain.CategoriaAfectacion(item.TipoAfectacionIgv)
+	cantidad := &Cantidad{UnidadMedida: item.CodigoUnidadMedida(), Valor: item.Cantidad.StringCantidad()}
+
+	resultado := Linea{
+		ID:                  strconv.Itoa(numero),
+		LineExtensionAmount: m.importe(item.PrecioTotal),
+		Item:                descripcionItem(item),
+		Price:               Precio{PriceAmount: m.importeDetalle(item.PrecioUnitario)},
+	}
+
+	switch tipoDocumento {
+	case domain.TipoNotaCredito:
+		resultado.CreditedQuantity = cantidad
+	case domain.TipoNotaDebito:
+		resultado.DebitedQuantity = cantidad
+	default:
+		resultado.InvoicedQuantity = cantidad
+	}
+
+	precioReferencia := PrecioAlternativo{PriceTypeCode: "01"}
+	if gratuito {
+		precioReferencia.PriceTypeCode = "02"
+		precioReferencia.PriceAmount = m.importeDetalle(item.PrecioUnitario)
+		resultado.Price.PriceAmount = m.importeDetalle(money.Cero())
+	} else {
+		conImpuestos := item.PrecioTotal.Sumar(item.IscTotal).Sumar(item.IgvTotal)
+		precioReferencia.PriceAmount = m.importeDetalle(conImpuestos.Dividir(item.Cantidad, money.RedondeoMitadArriba))
+	}
+	resultado.PricingReference = &ReferenciaPrecio{Precio: precioReferencia}
+
+	for _, cargoDescuento := range item.CargosDescuentos {
+		resultado.CargosDescuentos = append(resultado.CargosDescuentos, cargoDescuentoUBL(cargoDescuento, m))
+	}
+
+	resultado.TaxTotal = impuestosLinea(item, categoria, gratuito, tasaIGV, m)
+	return resultado
+}
+
+// descripcionItem incluye el código interno y el código UNSPSC cuando el item los tiene
+func descripcionItem(item domain.Item) DescripcionItem {
+	descripcion := DescripcionItem{Description: item.Descripcion}
+	if item.CodigoProducto != "" {
+		descripcion.SellersItemIdentification = &IdentificacionItem{ID: item.CodigoProducto}
+	}
+	if item.CodigoProductoSunat != "" {
+		descripcion.CommodityClassification = &ClasificacionProducto{
+			ItemClassificationCode: CodigoClasificacion{ListID: ListaUNSPSC, Valor: item.CodigoProductoSunat},
+		}
+	}
+	return descripcion
+}
+
+func impuestosLinea(item domain.Item, categoria string, gratuito bool, tasaIGV money.Money, m moneda) TotalImpuestos {
+	total := TotalImpuestos{TaxAmount: m.importe(item.IgvTotal.Sumar(item.IscTotal).Sumar(item.IcbperTotal))}
+
+	if !item.IscTotal.EsCero() {
+		total.TaxSubtotals = append(total.TaxSubtotals, SubtotalImpuesto{
+			TaxableAmount: m.importePuntero(item.PrecioTotal),
+			TaxAmount:     m.importe(item.IscTotal),
+			TaxCategory:   CategoriaImpuesto{ID: categoriaUNECE(tributoISC), TaxScheme: esquema(tributoISC)},
+		})
+	}
+
+	tributoItem := tributosPorCategoria[categoria]
+	if gratuito {
+		tributoItem = tributoGratuito
+	}
+	porcentaje := "0.00"
+	if categoria == domain.CategoriaGravado {
+		porcentaje = tasaIGV.MultiplicarEntero(100).String()
+	}
+	total.TaxSubtotals = append(total.TaxSubtotals, SubtotalImpuesto{
+		TaxableAmount: m.importePuntero(item.PrecioTotal.Sumar(item.IscTotal)),
+		TaxAmount:     m.importe(item.IgvTotal),
+		TaxCategory: CategoriaImpuesto{
+			ID:                     categoriaUNECE(tributoItem),
+			Percent:                porcentaje,
+			TaxExemptionReasonCode: item.TipoAfectacionIgv,
+			TaxScheme:              esquema(tributoItem),
+		},
+	})
+
+	if !item.IcbperTotal.EsCero() {
+		total.TaxSubtotals = append(total.TaxSubtotals, SubtotalImpuesto{
+			TaxAmount:       m.importe(item.IcbperTotal),
+			BaseUnitMeasure: &Cantidad{UnidadMedida: UnidadMedidaNIU, Valor: item.Cantidad.StringCantidad()},
+			TaxCategory: CategoriaImpuesto{
+				PerUnitAmount: m.importePuntero(item.IcbperTotal.Dividir(item.Cantidad, money.RedondeoMitadArriba)),
+				TaxScheme:     esquema(tributoICBPER),
+			},
+		})
+	}
+
+	return total
+}
+
+func cargoDescuentoUBL(cargoDescuento domain.CargoDescuento, m moneda) CargoDescuento {
+	resultado := CargoDescuento{
+		ChargeIndicator:           cargoDescuento.EsCargo,
+		AllowanceChargeReasonCode: cargoDescuento.Codigo,
+		Amount:                    m.importe(cargoDescuento.Monto),
+	}
+	if !cargoDescuento.Factor.EsCero() {
+		resultado.MultiplierFactorNumeric = cargoDescuento.Factor.String()
+	}
+	if !cargoDescuento.MontoBase.EsCero() {
+		resultado.BaseAmount = m.importePuntero(cargoDescuento.MontoBase)
+	}
+	return resultado
+}
+
+func esquema(t tributo) EsquemaImpuesto {
+	return EsquemaImpuesto{ID: t.id, Name: t.nombre, TaxTypeCode: t.tipo}
+}
+
+func categoriaUNECE(t tributo) *Identificador {
+	if t.categoria == "" {
+		return nil
+	}
+	return &Identificador{SchemeID: "UN/ECE 5305", Valor: t.categoria}
+}
+
+// moneda es el código ISO 4217 que se informa en currencyID de cada importe
+type moneda string
+
+func (m moneda) importe(valor money.Money) Importe {
+	return Importe{Moneda: string(m), Valor: valor.StringFijo(money.DecimalesMonto)}
+}
+
+func (m moneda) importePuntero(valor money.Money) *Importe {
+	resultado := m.importe(valor)
+	return &resultado
+}
+
+// importeDetalle conserva los decimales de los precios unitarios, que SUNAT admite hasta 10
+func (m moneda) importeDetalle(valor money.Money) Importe {
+	return Importe{Moneda: string(m), Valor: valor.String()}
+}
@@ -0,0 +1,340 @@
+package ubl
+
+import (
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func facturaDePrueba() *domain.Document {
+	return &domain.Document{
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		FechaEmision:           "2026-02-12T10:30:00-05:00",
+		TotalGravado:           money.MustParse("100.00"),
+		TotalGratuito:          money.MustParse("20.00"),
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		TasaIgv:                money.MustParse("0.18"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
+		Items: []domain.Item{
+			{Descripcion: "Producto A", TipoAfectacionIgv: "10", PrecioUnitario: money.MustParse("50.00"), Cantidad: money.NewFromInt(2), PrecioTotal: money.MustParse("100.00"), IgvTotal: money.MustParse("18.00")},
+			{Descripcion: "Muestra", TipoAfectacionIgv: "21", PrecioUnitario: money.MustParse("20.00"), Cantidad: money.NewFromInt(1), PrecioTotal: money.MustParse("20.00"), IgvTotal: money.MustParse("0")},
+		},
+		Validacion: &domain.Validacion{Firma: "ZmlybWE=", DigestValue: "cmVzdW1lbg==", Estado: "VALIDO"},
+	}
+}
+
+func TestGenerar_Factura(t *testing.T) {
+	contenido, err := Generar(facturaDePrueba())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	esperados := []string{
+		`<Invoice xmlns="` + NamespaceInvoice + `"`,
+		`<cbc:ID>F001-00000001</cbc:ID>`,
+		`<cbc:IssueDate>2026-02-12</cbc:IssueDate>`,
+		`<cbc:InvoiceTypeCode listID="0101">01</cbc:InvoiceTypeCode>`,
+		`<cbc:Note languageLocaleID="1000">CIENTO DIECIOCHO CON 00/100 SOLES</cbc:Note>`,
+		`<cbc:ID schemeID="6">20123456786</cbc:ID>`,
+		`<cbc:ID schemeID="6">20987654326</cbc:ID>`,
+		`<ds:Reference URI="">`,
+		`<ds:DigestValue>cmVzdW1lbg==</ds:DigestValue>`,
+		`<ds:SignatureValue>ZmlybWE=</ds:SignatureValue>`,
+		`<cbc:TaxAmount currencyID="PEN">18.00</cbc:TaxAmount>`,
+		`<cbc:Name>GRA</cbc:Name>`,
+		`<cbc:InvoicedQuantity unitCode="NIU">2</cbc:InvoicedQuantity>`,
+		`<cbc:PriceAmount currencyID="PEN">59.00</cbc:PriceAmount>`,
+		`<cbc:PriceTypeCode>02</cbc:PriceTypeCode>`,
+		`<cbc:Percent>18.00</cbc:Percent>`,
+		`<cbc:PayableAmount currencyID="PEN">118.00</cbc:PayableAmount>`,
+		`<cbc:ID>FormaPago</cbc:ID>`,
+		`<cbc:PaymentMeansID>Contado</cbc:PaymentMeansID>`,
+	}
+	for _, esperado := range esperados {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+
+	if strings.Count(xml, "<cac:InvoiceLine>") != 2 {
+		t.Errorf("Expected 2 invoice lines, got %d", strings.Count(xml, "<cac:InvoiceLine>"))
+	}
+}
+
+func TestGenerar_MonedaExtranjera(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Moneda = domain.MonedaUSD
+	doc.TipoCambio = money.MustParse("3.712")
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	for _, esperado := range []string{
+		`<cbc:DocumentCurrencyCode>USD</cbc:DocumentCurrencyCode>`,
+		`<cbc:Note languageLocaleID="1000">CIENTO DIECIOCHO CON 00/100 DOLARES AMERICANOS</cbc:Note>`,
+		`<cbc:PayableAmount currencyID="USD">118.00</cbc:PayableAmount>`,
+	} {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+	if strings.Contains(xml, `currencyID="PEN"`) {
+		t.Error("Expected every amount in USD")
+	}
+}
+
+func TestGenerar_Credito(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.FormaPago = &domain.FormaPago{
+		Tipo:           domain.FormaPagoCredito,
+		MontoPendiente: money.MustParse("118.00"),
+		Cuotas: []domain.Cuota{
+			{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-03-14"},
+			{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-04-13"},
+		},
+	}
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	for _, esperado := range []string{
+		`<cbc:PaymentMeansID>Credito</cbc:PaymentMeansID>`,
+		`<cbc:Amount currencyID="PEN">118.00</cbc:Amount>`,
+		`<cbc:PaymentMeansID>Cuota001</cbc:PaymentMeansID>`,
+		`<cbc:PaymentMeansID>Cuota002</cbc:PaymentMeansID>`,
+		`<cbc:PaymentDueDate>2026-04-13</cbc:PaymentDueDate>`,
+	} {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+
+	importado, err := Importar(contenido)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	formaPago := importado.FormaPago
+	if formaPago == nil || formaPago.Tipo != domain.FormaPagoCredito || !formaPago.MontoPendiente.Igual(money.MustParse("118.00")) {
+		t.Fatalf("Expected credit payment terms to be imported, got %+v", formaPago)
+	}
+	if len(formaPago.Cuotas) != 2 || formaPago.Cuotas[1].FechaVencimiento != "2026-04-13" {
+		t.Errorf("Expected 2 installments, got %+v", formaPago.Cuotas)
+	}
+}
+
+func TestGenerar_DetraccionYRetencion(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Moneda = domain.MonedaUSD
+	doc.Detraccion = &domain.Detraccion{Codigo: "037", Porcentaje: money.MustParse("0.12"), Monto: money.MustParse("52"), CuentaBancoNacion: "00741123456"}
+	doc.Retencion = &domain.Retencion{Porcentaje: money.MustParse("0.03"), MontoBase: money.MustParse("436.60"), Monto: money.MustParse("13.10")}
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	for _, esperado := range []string{
+		`<cbc:InvoiceTypeCode listID="1001">01</cbc:InvoiceTypeCode>`,
+		`<cbc:Note languageLocaleID="2006">Operación sujeta a detracción</cbc:Note>`,
+		`<cbc:PaymentMeansCode>001</cbc:PaymentMeansCode>`,
+		`<cbc:ID>00741123456</cbc:ID>`,
+		`<cbc:PaymentMeansID>037</cbc:PaymentMeansID>`,
+		`<cbc:PaymentPercent>12.00</cbc:PaymentPercent>`,
+		`<cbc:Amount currencyID="PEN">52.00</cbc:Amount>`,
+		`<cbc:AllowanceChargeReasonCode>62</cbc:AllowanceChargeReasonCode>`,
+		`<cbc:BaseAmount currencyID="PEN">436.60</cbc:BaseAmount>`,
+	} {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+
+	importado, err := Importar(contenido)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if importado.Detraccion == nil || fmt.Sprintf("%+v", *importado.Detraccion) != fmt.Sprintf("%+v", *doc.Detraccion) {
+		t.Errorf("Expected detraction to be imported, got %+v", importado.Detraccion)
+	}
+	if importado.Retencion == nil || !importado.Retencion.Monto.Igual(doc.Retencion.Monto) || len(importado.CargosDescuentos) != 0 {
+		t.Errorf("Expected retention apart from cargosDescuentos, got %+v %+v", importado.Retencion, importado.CargosDescuentos)
+	}
+}
+
+func TestGenerar_Percepcion(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Percepcion = &domain.Percepcion{
+		Codigo:            "51",
+		Porcentaje:        money.MustParse("0.02"),
+		MontoBase:         money.MustParse("118.00"),
+		Monto:             money.MustParse("2.36"),
+		MontoTotalCobrado: money.MustParse("120.36"),
+	}
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	for _, esperado := range []string{
+		`<cbc:InvoiceTypeCode listID="2001">01</cbc:InvoiceTypeCode>`,
+		`<cbc:ID>Percepcion</cbc:ID>`,
+		`<cbc:Amount currencyID="PEN">120.36</cbc:Amount>`,
+		`<cbc:AllowanceChargeReasonCode>51</cbc:AllowanceChargeReasonCode>`,
+	} {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+
+	importado, err := Importar(contenido)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if importado.Percepcion == nil || fmt.Sprintf("%+v", *importado.Percepcion) != fmt.Sprintf("%+v", *doc.Percepcion) {
+		t.Errorf("Expected perception to be imported, got %+v", importado.Percepcion)
+	}
+}
+
+func TestGenerar_DatosEmisor(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Emisor = &domain.DatosEmisor{
+		RazonSocial:           "EMPRESA DEMO S.A.C.",
+		NombreComercial:       "Demo",
+		Direccion:             "Av. Arequipa 123, Lima",
+		Ubigeo:                "150101",
+		CodigoEstablecimiento: "0000",
+	}
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	esperados := []string{
+		`<cbc:Name>Demo</cbc:Name>`,
+		`<cbc:RegistrationName>EMPRESA DEMO S.A.C.</cbc:RegistrationName>`,
+		`<cbc:ID>150101</cbc:ID>`,
+		`<cbc:AddressTypeCode>0000</cbc:AddressTypeCode>`,
+		`<cbc:Line>Av. Arequipa 123, Lima</cbc:Line>`,
+	}
+	for _, esperado := range esperados {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+
+	if strings.Count(xml, "<cbc:RegistrationName>") != 1 {
+		t.Errorf("Expected only the supplier to carry a registration name, got %d", strings.Count(xml, "<cbc:RegistrationName>"))
+	}
+}
+
+func TestGenerar_ProductoYCantidadDecimal(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Items[0].Cantidad = money.MustParse("2.5")
+	doc.Items[0].UnidadMedida = "KGM"
+	doc.Items[0].CodigoProducto = "P-001"
+	doc.Items[0].CodigoProductoSunat = "50221101"
+	doc.Items[0].PrecioUnitario = money.MustParse("40.00")
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	xml := string(contenido)
+
+	esperados := []string{
+		`<cbc:InvoicedQuantity unitCode="KGM">2.5</cbc:InvoicedQuantity>`,
+		`<cbc:InvoicedQuantity unitCode="NIU">1</cbc:InvoicedQuantity>`,
+		`<cbc:ID>P-001</cbc:ID>`,
+		`<cbc:ItemClassificationCode listID="UNSPSC">50221101</cbc:ItemClassificationCode>`,
+		`<cbc:PriceAmount currencyID="PEN">47.20</cbc:PriceAmount>`,
+	}
+	for _, esperado := range esperados {
+		if !strings.Contains(xml, esperado) {
+			t.Errorf("Expected XML to contain %s", esperado)
+		}
+	}
+
+	if strings.Count(xml, "<cac:CommodityClassification>") != 1 {
+		t.Errorf("Expected only the first item to carry a UNSPSC code, got %d", strings.Count(xml, "<cac:CommodityClassification>"))
+	}
+}
+
+func TestGenerar_SinFirma(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.Validacion = nil
+
+	contenido, err := Generar(doc)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if strings.Contains(string(contenido), "UBLExtensions") {
+		t.Error("Expected no UBLExtensions for an unsigned document")
+	}
+}
+
+func TestGenerar_Notas(t *testing.T) {
+	testCases := []struct {
+		tipo      string
+		raiz      string
+		cantidad  string
+		totalTag  string
+		noEsperar string
+	}{
+		{domain.TipoNotaCredito, "<CreditNote", "<cbc:CreditedQuantity", "<cac:LegalMonetaryTotal>", "<cbc:InvoiceTypeCode"},
+		{domain.TipoNotaDebito, "<DebitNote", "<cbc:DebitedQuantity", "<cac:RequestedMonetaryTotal>", "<cac:LegalMonetaryTotal>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.raiz, func(t *testing.T) {
+			doc := facturaDePrueba()
+			doc.IDDocumento = "F001-00000002"
+			doc.TipoDocumento = tc.tipo
+			doc.Referencia = &domain.DocumentoReferencia{IDDocumento: "F001-00000001", TipoDocumento: domain.TipoFactura, CodigoMotivo: "01", Sustento: "Anulación de la operación"}
+
+			contenido, err := Generar(doc)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			xml := string(contenido)
+
+			for _, esperado := range []string{tc.raiz, tc.cantidad, tc.totalTag, "<cac:BillingReference>", "<cbc:ReferenceID>F001-00000001</cbc:ReferenceID>", "<cbc:ResponseCode>01</cbc:ResponseCode>", "<cbc:Description>Anulación de la operación</cbc:Description>"} {
+				if !strings.Contains(xml, esperado) {
+					t.Errorf("Expected XML to contain %s", esperado)
+				}
+			}
+
+			if strings.Contains(xml, tc.noEsperar) {
+				t.Errorf("Expected XML not to contain %s", tc.noEsperar)
+			}
+		})
+	}
+}
+
+func TestGenerar_TipoDesconocido(t *testing.T) {
+	doc := facturaDePrueba()
+	doc.TipoDocumento = "09"
+
+	if _, err := Generar(doc); err == nil {
+		t.Error("Expected error for a document type without UBL representation")
+	}
+}
@@ -0,0 +1,578 @@
+package ubl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var prefijosPorNamespace = map[string]string{
+	NamespaceCac: "cac",
+	NamespaceCbc: "cbc",
+	NamespaceExt: "ext",
+	NamespaceDs:  "ds",
+}
+
+// ErrorImportacion indica el elemento del XML que no se pudo interpretar
+type ErrorImportacion struct {
+	Ruta    string
+	Mensaje string
+}
+
+func (e *ErrorImportacion) Error() string {
+	return fmt.Sprintf("%s: %s", e.Ruta, e.Mensaje)
+}
+
+// lectorConPrefijos reescribe los nombres de elementos a la forma prefijo:Nombre de los tags de
+// Comprobante, sin importar qué prefijos haya usado el XML de origen
+type lectorConPrefijos struct {
+	decodificador *xml.Decoder
+}
+
+func (l *lectorConPrefijos) Token() (xml.Token, error) {
+	token, err := l.decodificador.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch elemento := token.(type) {
+	case xml.StartElement:
+		elemento.Name = nombreConPrefijo(elemento.Name)
+		atributos := elemento.Attr[:0]
+		for _, atributo := range elemento.Attr {
+			if atributo.Name.Space != "xmlns" && atributo.Name.Local != "xmlns" {
+				atributos = append(atributos, atributo)
+			}
+		}
+		elemento.Attr = atributos
+		return elemento, nil
+	case xml.EndElement:
+		elemento.Name = nombreConPrefijo(elemento.Name)
+		return elemento, nil
+	}
+	return token, nil
+}
+
+func nombreConPrefijo(nombre xml.Name) xml.Name {
+	if prefijo, ok := prefijosPorNamespace[nombre.Space]; ok {
+		return xml.Name{Local: prefijo + ":" + nombre.Local}
+	}
+	return xml.Name{Space: nombre.Space, Local: nombre.Local}
+}
+
+// Importar interpreta un Invoice, CreditNote o DebitNote de UBL 2.1 como domain.Document.
+// No valida reglas de negocio: el resultado debe pasar por DocumentValidator como cualquier otro documento.
+func Importar(contenido []byte) (*domain.Document, error) {
+	decodificador := xml.NewTokenDecoder(&lectorConPrefijos{decodificador: xml.NewDecoder(bytes.NewReader(contenido))})
+
+	var comprobante Comprobante
+	if err := decodificador.Decode(&comprobante); err != nil {
+		var errorSintaxis *xml.SyntaxError
+		if errors.As(err, &errorSintaxis) {
+			return nil, &ErrorImportacion{Ruta: "/", Mensaje: fmt.Sprintf("XML mal formado en la línea %d: %s", errorSintaxis.Line, errorSintaxis.Msg)}
+		}
+		if err == io.EOF {
+			return nil, &ErrorImportacion{Ruta: "/", Mensaje: "el XML está vacío"}
+		}
+		return nil, &ErrorImportacion{Ruta: "/", Mensaje: err.Error()}
+	}
+
+	lector := &lectorComprobante{raiz: "/" + comprobante.XMLName.Local}
+	doc := lector.documento(&comprobante)
+	if lector.err != nil {
+		return nil, lector.err
+	}
+	return doc, nil
+}
+
+// identificacion separa la serie de un cbc:ID sin correlativo (F001, F001- o F001-00000000) para que MS1
+// asigne el número; un ID numerado se conserva como idDocumento
+func identificacion(id string) (string, string) {
+	id = strings.TrimSpace(id)
+	serie, correlativo, numerado := strings.Cut(id, "-")
+	if !numerado || strings.Trim(correlativo, "0") == "" {
+		return serie, ""
+	}
+	return "", id
+}
+
+// lectorComprobante guarda el primer error encontrado para no cortar el mapeo en cada campo
+type lectorComprobante struct {
+	raiz string
+	err  error
+}
+
+func (l *lectorComprobante) fallar(ruta, mensaje string) {
+	if l.err == nil {
+		l.err = &ErrorImportacion{Ruta: l.raiz + ruta, Mensaje: mensaje}
+	}
+}
+
+func (l *lectorComprobante) importe(valor Importe, ruta string) money.Money {
+	if strings.TrimSpace(valor.Valor) == "" {
+		return money.Cero()
+	}
+	resultado, err := money.Parse(valor.Valor)
+	if err != nil {
+		l.fallar(ruta, fmt.Sprintf("importe inválido %q", valor.Valor))
+	}
+	return resultado
+}
+
+func (l *lectorComprobante) documento(comprobante *Comprobante) *domain.Document {
+	serie, idDocumento := identificacion(comprobante.ID)
+	doc := &domain.Document{
+		IDDocumento:           idDocumento,
+		Serie:                 serie,
+		RucEmisor:             strings.TrimSpace(comprobante.Proveedor.Party.Identificacion.ID.Valor),
+		TipoDocumentoReceptor: comprobante.Cliente.Party.Identificacion.ID.SchemeID,
+		RucReceptor:           strings.TrimSpace(comprobante.Cliente.Party.Identificacion.ID.Valor),
+		Receptor:              datosReceptor(comprobante.Cliente.Party),
+		Moneda:                strings.TrimSpace(comprobante.DocumentCurrencyCode),
+	}
+
+	lineas := comprobante.InvoiceLines
+	totalMonetario, rutaTotal := comprobante.LegalMonetaryTotal, "/cac:LegalMonetaryTotal"
+	switch comprobante.XMLName.Local {
+	case "Invoice":
+		if comprobante.InvoiceTypeCode == nil {
+			l.fallar("/cbc:InvoiceTypeCode", "es obligatorio")
+		} else {
+			doc.TipoDocumento = strings.TrimSpace(comprobante.InvoiceTypeCode.Valor)
+		}
+	case "CreditNote":
+		doc.TipoDocumento = domain.TipoNotaCredito
+		lineas = comprobante.CreditNoteLines
+	case "DebitNote":
+		doc.TipoDocumento = domain.TipoNotaDebito
+		lineas = comprobante.DebitNoteLines
+		totalMonetario, rutaTotal = comprobante.RequestedTotal, "/cac:RequestedMonetaryTotal"
+	default:
+		l.raiz = ""
+		l.fallar("/"+comprobante.XMLName.Local, "el elemento raíz debe ser Invoice, CreditNote o DebitNote")
+		return nil
+	}
+
+	doc.FechaEmision = l.fechaEmision(comprobante.IssueDate, comprobante.IssueTime)
+
+	if comprobante.BillingReference != nil {
+		doc.Referencia = &domain.DocumentoReferencia{
+			IDDocumento:   strings.TrimSpace(comprobante.BillingReference.Documento.ID),
+			TipoDocumento: strings.TrimSpace(comprobante.BillingReference.Documento.DocumentTypeCode),
+		}
+		if comprobante.DiscrepancyResponse != nil {
+			doc.Referencia.CodigoMotivo = strings.TrimSpace(comprobante.DiscrepancyResponse.ResponseCode)
+			doc.Referencia.Sustento = strings.TrimSpace(comprobante.DiscrepancyResponse.Description)
+		}
+	}
+
+	if comprobante.OrderReference != nil {
+		doc.OrdenCompra = strings.TrimSpace(comprobante.OrderReference.ID)
+	}
+
+	doc.FormaPago = l.formaPago(comprobante.TerminosPago)
+	l.detraccionPercepcion(doc, comprobante.MediosPago, comprobante.TerminosPago)
+
+	for indice, cargoDescuento := range comprobante.CargosDescuentos {
+		ruta := fmt.Sprintf("/cac:AllowanceCharge[%d]", indice+1)
+		leido := l.cargoDescuento(cargoDescuento, ruta)
+
+		switch _, esPercepcion := domain.PorcentajesPercepcion[leido.Codigo]; {
+		case esPercepcion && leido.EsCargo:
+			if doc.Percepcion == nil {
+				doc.Percepcion = &domain.Percepcion{}
+			}
+			doc.Percepcion.Codigo, doc.Percepcion.Porcentaje = leido.Codigo, leido.Factor
+			doc.Percepcion.MontoBase, doc.Percepcion.Monto = leido.MontoBase, leido.Monto
+		case leido.Codigo == domain.CodigoRetencion && !leido.EsCargo:
+			doc.Retencion = &domain.Retencion{Porcentaje: leido.Factor, MontoBase: leido.MontoBase, Monto: leido.Monto}
+		default:
+			doc.CargosDescuentos = append(doc.CargosDescuentos, leido)
+		}
+	}
+
+	if totalMonetario == nil {
+		l.fallar(rutaTotal, "es obligatorio")
+	} else {
+		doc.MontoTotalSinImpuestos = l.importe(totalMonetario.LineExtensionAmount, rutaTotal+"/cbc:LineExtensionAmount")
+		doc.MontoTotal = l.importe(totalMonetario.PayableAmount, rutaTotal+"/cbc:PayableAmount")
+	}
+
+	l.totalesPorTributo(doc, comprobante.TaxTotal)
+
+	for indice, linea := range lineas {
+		doc.Items = append(doc.Items, l.item(doc, linea, fmt.Sprintf("/cac:%s[%d]", elementoLinea(doc.TipoDocumento), indice+1)))
+	}
+	if len(lineas) == 0 {
+		l.fallar("/cac:"+elementoLinea(doc.TipoDocumento), "el comprobante debe tener al menos una línea")
+	}
+
+	return doc
+}
+
+// datosReceptor lee el nombre, el domicilio y el correo del adquiriente; sin cac:PartyLegalEntity no hay datos
+func datosReceptor(parte Parte) *domain.DatosReceptor {
+	if parte.EntidadLegal == nil {
+		return nil
+	}
+
+	receptor := &domain.DatosReceptor{Nombre: strings.TrimSpace(parte.EntidadLegal.RegistrationName)}
+	if parte.EntidadLegal.RegistrationAddress != nil {
+		receptor.Direccion = strings.TrimSpace(parte.EntidadLegal.RegistrationAddress.AddressLine.Line)
+	}
+	if parte.Contacto != nil {
+		receptor.Email = strings.TrimSpace(parte.Contacto.ElectronicMail)
+	}
+	return receptor
+}
+
+// formaPago lee los cac:PaymentTerms con ID FormaPago: el de Contado o Credito da el tipo y el neto
+// pendiente, y los de CuotaNNN las cuotas en el orden del XML
+func (l *lectorComprobante) formaPago(terminos []TerminoPago) *domain.FormaPago {
+	var formaPago *domain.FormaPago
+	for indice, termino := range terminos {
+		if strings.TrimSpace(termino.ID) != IDFormaPago {
+			continue
+		}
+		if formaPago == nil {
+			formaPago = &domain.FormaPago{}
+		}
+
+		ruta := fmt.Sprintf("/cac:PaymentTerms[%d]", indice+1)
+		monto := money.Cero()
+		if termino.Amount != nil {
+			monto = l.importe(*termino.Amount, ruta+"/cbc:Amount")
+		}
+
+		switch medio := strings.TrimSpace(termino.PaymentMeansID); {
+		case strings.HasPrefix(medio, "Cuota"):
+			formaPago.Cuotas = append(formaPago.Cuotas, domain.Cuota{Monto: monto, FechaVencimiento: strings.TrimSpace(termino.PaymentDueDate)})
+		case medio == domain.FormaPagoContado || medio == domain.FormaPagoCredito:
+			formaPago.Tipo = medio
+			formaPago.MontoPendiente = monto
+		default:
+			l.fallar(ruta+"/cbc:PaymentMeansID", fmt.Sprintf("forma de pago %q no soportada", medio))
+		}
+	}
+	return formaPago
+}
+
+// detraccionPercepcion lee la cuenta del cac:PaymentMeans y el código, porcentaje y monto del cac:PaymentTerms
+// con ID Detraccion, y el total cobrado del cac:PaymentTerms con ID Percepcion
+func (l *lectorComprobante) detraccionPercepcion(doc *domain.Document, medios []MedioPago, terminos []TerminoPago) {
+	for indice, termino := range terminos {
+		ruta := fmt.Sprintf("/cac:PaymentTerms[%d]", indice+1)
+		monto := money.Cero()
+		if termino.Amount != nil {
+			monto = l.importe(*termino.Amount, ruta+"/cbc:Amount")
+		}
+
+		switch strings.TrimSpace(termino.ID) {
+		case IDDetraccion:
+			doc.Detraccion = &domain.Detraccion{Codigo: strings.TrimSpace(termino.PaymentMeansID), Monto: monto}
+			if porcentaje := strings.TrimSpace(termino.PaymentPercent); porcentaje != "" {
+				valor, err := money.Parse(porcentaje)
+				if err != nil {
+					l.fallar(ruta+"/cbc:PaymentPercent", fmt.Sprintf("porcentaje inválido %q", porcentaje))
+				}
+				doc.Detraccion.Porcentaje = valor.DividirEntero(100, money.RedondeoMitadArriba)
+			}
+		case IDPercepcion:
+			if doc.Percepcion == nil {
+				doc.Percepcion = &domain.Percepcion{}
+			}
+			doc.Percepcion.MontoTotalCobrado = monto
+		}
+	}
+
+	for _, medio := range medios {
+		if strings.TrimSpace(medio.ID) != IDDetraccion {
+			continue
+		}
+		if doc.Detraccion == nil {
+			doc.Detraccion = &domain.Detraccion{}
+		}
+		doc.Detraccion.CuentaBancoNacion = strings.TrimSpace(medio.PayeeFinancialAccount.ID)
+	}
+}
+
+func (l *lectorComprobante) fechaEmision(fecha, hora string) string {
+	fecha, hora = strings.TrimSpace(fecha), strings.TrimSpace(hora)
+	if fecha == "" {
+		return ""
+	}
+	if hora == "" {
+		hora = "00:00:00"
+	}
+
+	if emision, err := time.Parse(time.RFC3339, fecha+"T"+hora); err == nil {
+		return emision.Format(time.RFC3339)
+	}
+	// IssueTime no suele traer zona horaria en los XML de SUNAT
+	emision, err := time.ParseInLocation("2006-01-02T15:04:05", fecha+"T"+hora, domain.ZonaHorariaPeru)
+	if err != nil {
+		l.fallar("/cbc:IssueDate", "la fecha debe tener formato AAAA-MM-DD y la hora HH:MM:SS")
+		return ""
+	}
+	return emision.Format(time.RFC3339)
+}
+
+// totalesPorTributo reparte los TaxSubtotal del documento en los totales por afectación.
+// El TaxableAmount del IGV incluye el ISC, que se descuenta para obtener totalGravado.
+func (l *lectorComprobante) totalesPorTributo(doc *domain.Document, total TotalImpuestos) {
+	baseIGV := money.Cero()
+	for indice, subtotal := range total.TaxSubtotals {
+		ruta := fmt.Sprintf("/cac:TaxTotal/cac:TaxSubtotal[%d]", indice+1)
+		base := money.Cero()
+		if subtotal.TaxableAmount != nil {
+			base = l.importe(*subtotal.TaxableAmount, ruta+"/cbc:TaxableAmount")
+		}
+		impuesto := l.importe(subtotal.TaxAmount, ruta+"/cbc:TaxAmount")
+
+		switch strings.TrimSpace(subtotal.TaxCategory.TaxScheme.ID) {
+		case tributoIGV.id:
+			baseIGV, doc.IgvTotal = base, impuesto
+		case tributoExonerado.id:
+			doc.TotalExonerado = base
+		case tributoInafecto.id:
+			doc.TotalInafecto = base
+		case tributoGratuito.id:
+			doc.TotalGratuito = base
+		case tributoISC.id:
+			doc.IscTotal = impuesto
+		case tributoICBPER.id:
+			doc.IcbperTotal = impuesto
+		default:
+			l.fallar(ruta+"/cac:TaxCategory/cac:TaxScheme/cbc:ID", fmt.Sprintf("tributo %q no soportado", subtotal.TaxCategory.TaxScheme.ID))
+		}
+	}
+	doc.TotalGravado = baseIGV.Restar(doc.IscTotal)
+}
+
+func (l *lectorComprobante) item(doc *domain.Document, linea Linea, ruta string) domain.Item {
+	item := domain.Item{
+		Descripcion: strings.TrimSpace(linea.Item.Description),
+		PrecioTotal: l.importe(linea.LineExtensionAmount, ruta+"/cbc:LineExtensionAmount"),
+	}
+	if linea.Item.SellersItemIdentification != nil {
+		item.CodigoProducto = strings.TrimSpace(linea.Item.SellersItemIdentification.ID)
+	}
+	if linea.Item.CommodityClassification != nil {
+		item.CodigoProductoSunat = strings.TrimSpace(linea.Item.CommodityClassification.ItemClassificationCode.Valor)
+	}
+
+	cantidad, rutaCantidad := linea.InvoicedQuantity, "cbc:InvoicedQuantity"
+	if linea.CreditedQuantity != nil {
+		cantidad, rutaCantidad = linea.CreditedQuantity, "cbc:CreditedQuantity"
+	}
+	if linea.DebitedQuantity != nil {
+		cantidad, rutaCantidad = linea.DebitedQuantity, "cbc:DebitedQuantity"
+	}
+	if cantidad == nil {
+		l.fallar(ruta+"/"+rutaCantidad, "es obligatorio")
+	} else {
+		valor, err := money.Parse(cantidad.Valor)
+		if err != nil {
+			l.fallar(ruta+"/"+rutaCantidad, fmt.Sprintf("cantidad inválida %q", cantidad.Valor))
+		}
+		item.Cantidad = valor
+		item.UnidadMedida = strings.TrimSpace(cantidad.UnidadMedida)
+	}
+
+	item.PrecioUnitario = l.importe(linea.Price.PriceAmount, ruta+"/cac:Price/cbc:PriceAmount")
+	if linea.PricingReference != nil && strings.TrimSpace(linea.PricingReference.Precio.PriceTypeCode) == "02" {
+		item.PrecioUnitario = l.importe(linea.PricingReference.Precio.PriceAmount, ruta+"/cac:PricingReference/cac:AlternativeConditionPrice/cbc:PriceAmount")
+	}
+
+	for indice, cargoDescuento := range linea.CargosDescuentos {
+		item.CargosDescuentos = append(item.CargosDescuentos, l.cargoDescuento(cargoDescuento, fmt.Sprintf("%s/cac:AllowanceCharge[%d]", ruta, indice+1)))
+	}
+
+	for indice, subtotal := range linea.TaxTotal.TaxSubtotals {
+		rutaSubtotal := fmt.Sprintf("%s/cac:TaxTotal/cac:TaxSubtotal[%d]", ruta, indice+1)
+		impuesto := l.importe(subtotal.TaxAmount, rutaSubtotal+"/cbc:TaxAmount")
+
+		switch strings.TrimSpace(subtotal.TaxCategory.TaxScheme.ID) {
+		case tributoISC.id:
+			item.IscTotal = impuesto
+		case tributoICBPER.id:
+			item.IcbperTotal = impuesto
+		case tributoIGV.id, tributoExonerado.id, tributoInafecto.id, tributoGratuito.id:
+			item.IgvTotal = impuesto
+			item.TipoAfectacionIgv = strings.TrimSpace(subtotal.TaxCategory.TaxExemptionReasonCode)
+			l.tasaIGV(doc, subtotal.TaxCategory, rutaSubtotal+"/cac:TaxCategory/cbc:Percent")
+		default:
+			l.fallar(rutaSubtotal+"/cac:TaxCategory/cac:TaxScheme/cbc:ID", fmt.Sprintf("tributo %q no soportado", subtotal.TaxCategory.TaxScheme.ID))
+		}
+	}
+
+	return item
+}
+
+// tasaIGV toma el porcentaje de la primera línea gravada para que el validador lo compare con la tabla vigente
+func (l *lectorComprobante) tasaIGV(doc *domain.Document, categoria CategoriaImpuesto, ruta string) {
+	if !doc.TasaIgv.EsCero() || strings.TrimSpace(categoria.Percent) == "" {
+		return
+	}
+
+	porcentaje, err := money.Parse(categoria.Percent)
+	if err != nil {
+		l.fallar(ruta, fmt.Sprintf("porcentaje inválido %q", categoria.Percent))
+		return
+	}
+	if porcentaje.EsPositivo() {
+		doc.TasaIgv = porcentaje.DividirEntero(100, money.RedondeoMitadArriba)
+	}
+}
+
+func (l *lectorComprobante) cargoDescuento(cargoDescuento CargoDescuento, ruta string) domain.CargoDescuento {
+	resultado := domain.CargoDescuento{
+		Codigo:  strings.TrimSpace(cargoDescuento.AllowanceChargeReasonCode),
+		EsCargo: cargoDescuento.ChargeIndicator,
+		Monto:   l.importe(cargoDescuento.Amount, ruta+"/cbc:Amount"),
+	}
+	if cargoDescuento.BaseAmount != nil {
+		resultado.MontoBase = l.importe(*cargoDescuento.BaseAmount, ruta+"/cbc:BaseAmount")
+	}
+	if factor := strings.TrimSpace(cargoDescuento.MultiplierFactorNumeric); factor != "" {
+		valor, err := money.Parse(factor)
+		if err != nil {
+			l.fallar(ruta+"/cbc:MultiplierFactorNumeric", fmt.Sprintf("factor inválido %q", factor))
+		}
+		resultado.Factor = valor
+	}
+	return resultado
+}
+
+func elementoLinea(tipoDocumento string) string {
+	switch tipoDocumento {
+	case domain.TipoNotaCredito:
+		return "CreditNoteLine"
+	case domain.TipoNotaDebito:
+		return "DebitNoteLine"
+	}
+	return "InvoiceLine"
+}
+
+// elementoCantidad es el elemento de la línea que lleva la cantidad según el tipo de comprobante
+func elementoCantidad(tipoDocumento string) string {
+	switch tipoDocumento {
+	case domain.TipoNotaCredito:
+		return "cbc:CreditedQuantity"
+	case domain.TipoNotaDebito:
+		return "cbc:DebitedQuantity"
+	}
+	return "cbc:InvoicedQuantity"
+}
+
+var rutasPorCampoItem = map[string]string{
+	"descripcion":         "cac:Item/cbc:Description",
+	"cantidad":            "%s",
+	"unidadMedida":        "%s/@unitCode",
+	"codigoProducto":      "cac:Item/cac:SellersItemIdentification/cbc:ID",
+	"codigoProductoSunat": "cac:Item/cac:CommodityClassification/cbc:ItemClassificationCode",
+	"precioUnitario":      "cac:Price/cbc:PriceAmount",
+	"precioTotal":         "cbc:LineExtensionAmount",
+	"igvTotal":            "cac:TaxTotal/cbc:TaxAmount",
+	"tipoAfectacionIgv":   "cac:TaxTotal/cac:TaxSubtotal/cac:TaxCategory/cbc:TaxExemptionReasonCode",
+	"iscTotal":            "cac:TaxTotal/cac:TaxSubtotal/cbc:TaxAmount",
+	"icbperTotal":         "cac:TaxTotal/cac:TaxSubtotal/cbc:TaxAmount",
+}
+
+var rutasPorCampoDocumento = map[string]string{
+	"idDocumento":                  "cbc:ID",
+	"serie":                        "cbc:ID",
+	"tipoDocumento":                "cbc:InvoiceTypeCode",
+	"rucEmisor":                    "cac:AccountingSupplierParty/cac:Party/cac:PartyIdentification/cbc:ID",
+	"tipoDocumentoReceptor":        "cac:AccountingCustomerParty/cac:Party/cac:PartyIdentification/cbc:ID/@schemeID",
+	"rucReceptor":                  "cac:AccountingCustomerParty/cac:Party/cac:PartyIdentification/cbc:ID",
+	"receptor":                     "cac:AccountingCustomerParty/cac:Party/cac:PartyLegalEntity",
+	"receptor.nombre":              "cac:AccountingCustomerParty/cac:Party/cac:PartyLegalEntity/cbc:RegistrationName",
+	"receptor.email":               "cac:AccountingCustomerParty/cac:Party/cac:Contact/cbc:ElectronicMail",
+	"fechaEmision":                 "cbc:IssueDate",
+	"ordenCompra":                  "cac:OrderReference/cbc:ID",
+	"referencia":                   "cac:BillingReference/cac:InvoiceDocumentReference",
+	"referencia.codigoMotivo":      "cac:DiscrepancyResponse/cbc:ResponseCode",
+	"formaPago":                    "cac:PaymentTerms[cbc:ID='FormaPago']",
+	"formaPago.montoPendiente":     "cac:PaymentTerms[cbc:PaymentMeansID='Credito']/cbc:Amount",
+	"detraccion":                   "cac:PaymentTerms[cbc:ID='Detraccion']",
+	"detraccion.codigo":            "cac:PaymentTerms[cbc:ID='Detraccion']/cbc:PaymentMeansID",
+	"detraccion.porcentaje":        "cac:PaymentTerms[cbc:ID='Detraccion']/cbc:PaymentPercent",
+	"detraccion.monto":             "cac:PaymentTerms[cbc:ID='Detraccion']/cbc:Amount",
+	"detraccion.cuentaBancoNacion": "cac:PaymentMeans[cbc:ID='Detraccion']/cac:PayeeFinancialAccount/cbc:ID",
+	"percepcion":                   "cac:AllowanceCharge[cbc:ChargeIndicator='true'][cbc:AllowanceChargeReasonCode='51' or cbc:AllowanceChargeReasonCode='52' or cbc:AllowanceChargeReasonCode='53']",
+	"percepcion.montoTotalCobrado": "cac:PaymentTerms[cbc:ID='Percepcion']/cbc:Amount",
+	"retencion":                    "cac:AllowanceCharge[cbc:AllowanceChargeReasonCode='62']",
+	"totalGravado":                 "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='1000']/cbc:TaxableAmount",
+	"igvTotal":                     "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='1000']/cbc:TaxAmount",
+	"tasaIgv":                      "cac:TaxTotal/cac:TaxSubtotal/cac:TaxCategory/cbc:Percent",
+	"totalExonerado":               "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='9997']/cbc:TaxableAmount",
+	"totalInafecto":                "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='9998']/cbc:TaxableAmount",
+	"totalGratuito":                "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='9996']/cbc:TaxableAmount",
+	"iscTotal":                     "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='2000']/cbc:TaxAmount",
+	"icbperTotal":                  "cac:TaxTotal/cac:TaxSubtotal[cac:TaxCategory/cac:TaxScheme/cbc:ID='7152']/cbc:TaxAmount",
+	"montoTotalSinImpuestos":       "%s/cbc:LineExtensionAmount",
+	"montoTotal":                   "%s/cbc:PayableAmount",
+}
+
+// RutaXML traduce el JSON pointer de un error de validación a la ruta del elemento UBL correspondiente,
+// para que los errores de un documento importado apunten al XML original
+func RutaXML(tipoDocumento, ruta string) string {
+	raiz, _ := raizPorTipo(tipoDocumento)
+	if raiz == "" {
+		raiz = "Invoice"
+	}
+	raiz = "/" + raiz
+	linea := raiz + "/cac:" + elementoLinea(tipoDocumento)
+
+	segmentos := strings.Split(strings.TrimPrefix(ruta, "/"), "/")
+	switch {
+	case len(segmentos) >= 2 && segmentos[0] == "items":
+		rutaLinea := fmt.Sprintf("%s[%s]", linea, siguiente(segmentos[1]))
+		if len(segmentos) >= 4 && segmentos[2] == "cargosDescuentos" {
+			return fmt.Sprintf("%s/cac:AllowanceCharge[%s]", rutaLinea, siguiente(segmentos[3]))
+		}
+		if len(segmentos) >= 3 {
+			if elemento, ok := rutasPorCampoItem[segmentos[2]]; ok {
+				if strings.Contains(elemento, "%s") {
+					elemento = fmt.Sprintf(elemento, elementoCantidad(tipoDocumento))
+				}
+				rutaLinea += "/" + elemento
+			}
+		}
+		return rutaLinea
+	case len(segmentos) >= 2 && segmentos[0] == "cargosDescuentos":
+		return fmt.Sprintf("%s/cac:AllowanceCharge[%s]", raiz, siguiente(segmentos[1]))
+	case len(segmentos) >= 3 && segmentos[0] == "formaPago" && segmentos[1] == "cuotas":
+		indice, _ := strconv.Atoi(segmentos[2])
+		return fmt.Sprintf("%s/cac:PaymentTerms[cbc:PaymentMeansID='Cuota%03d']", raiz, indice+1)
+	}
+
+	elemento, ok := rutasPorCampoDocumento[strings.Join(segmentos, ".")]
+	if !ok {
+		if elemento, ok = rutasPorCampoDocumento[segmentos[0]]; !ok {
+			return raiz
+		}
+	}
+	if strings.Contains(elemento, "%s") {
+		total := "cac:LegalMonetaryTotal"
+		if tipoDocumento == domain.TipoNotaDebito {
+			total = "cac:RequestedMonetaryTotal"
+		}
+		elemento = fmt.Sprintf(elemento, total)
+	}
+	return raiz + "/" + elemento
+}
+
+// siguiente convierte el índice base 0 de las rutas en la posición base 1 de XPath
+func siguiente(indice string) string {
+	numero, _ := strconv.Atoi(indice)
+	return strconv.Itoa(numero + 1)
+}
@@ -0,0 +1,221 @@
+package ubl
+
+import (
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func TestImportar_IdaYVuelta(t *testing.T) {
+	original := facturaDePrueba()
+	original.Receptor = &domain.DatosReceptor{Nombre: "CLIENTE DEMO S.A.", Direccion: "Jr. Lampa 456, Lima", Email: "compras@cliente.pe"}
+	original.OrdenCompra = "OC-2026-0042"
+	contenido, err := Generar(original)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	doc, err := Importar(contenido)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.IDDocumento != original.IDDocumento || doc.TipoDocumento != domain.TipoFactura {
+		t.Errorf("Expected %s type 01, got %s type %s", original.IDDocumento, doc.IDDocumento, doc.TipoDocumento)
+	}
+
+	if doc.RucEmisor != original.RucEmisor || doc.RucReceptor != original.RucReceptor || doc.TipoDocumentoReceptor != domain.IdentidadRUC {
+		t.Errorf("Expected parties to be preserved, got emisor %s receptor %s (%s)", doc.RucEmisor, doc.RucReceptor, doc.TipoDocumentoReceptor)
+	}
+
+	if doc.Receptor == nil || *doc.Receptor != *original.Receptor {
+		t.Errorf("Expected receptor %+v, got %+v", original.Receptor, doc.Receptor)
+	}
+
+	if doc.OrdenCompra != original.OrdenCompra {
+		t.Errorf("Expected ordenCompra %s, got %q", original.OrdenCompra, doc.OrdenCompra)
+	}
+
+	if doc.FechaEmision != original.FechaEmision {
+		t.Errorf("Expected fechaEmision %s, got %s", original.FechaEmision, doc.FechaEmision)
+	}
+
+	if doc.Moneda != domain.MonedaPEN {
+		t.Errorf("Expected moneda PEN, got %s", doc.Moneda)
+	}
+
+	if !doc.MontoTotal.Igual(original.MontoTotal) || !doc.IgvTotal.Igual(original.IgvTotal) || !doc.TotalGravado.Igual(original.TotalGravado) || !doc.TotalGratuito.Igual(original.TotalGratuito) {
+		t.Errorf("Expected totals to be preserved, got montoTotal %s igv %s gravado %s gratuito %s", doc.MontoTotal, doc.IgvTotal, doc.TotalGravado, doc.TotalGratuito)
+	}
+
+	if doc.TasaIgv.String() != "0.18" {
+		t.Errorf("Expected tasaIgv 0.18, got %s", doc.TasaIgv)
+	}
+
+	if len(doc.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(doc.Items))
+	}
+
+	gratuito := doc.Items[1]
+	if gratuito.TipoAfectacionIgv != "21" || gratuito.PrecioUnitario.String() != "20.00" || gratuito.Cantidad.StringCantidad() != "1" {
+		t.Errorf("Expected free item 21 at 20.00 × 1, got %s at %s × %s", gratuito.TipoAfectacionIgv, gratuito.PrecioUnitario, gratuito.Cantidad)
+	}
+}
+
+func TestImportar_ProductoYCantidadDecimal(t *testing.T) {
+	original := facturaDePrueba()
+	original.Items[0].Cantidad = money.MustParse("2.5")
+	original.Items[0].UnidadMedida = "KGM"
+	original.Items[0].CodigoProducto = "P-001"
+	original.Items[0].CodigoProductoSunat = "50221101"
+	original.Items[0].PrecioUnitario = money.MustParse("40.00")
+
+	contenido, err := Generar(original)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	doc, err := Importar(contenido)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	item := doc.Items[0]
+	if item.Cantidad.StringCantidad() != "2.5" || item.UnidadMedida != "KGM" {
+		t.Errorf("Expected 2.5 KGM, got %s %s", item.Cantidad.StringCantidad(), item.UnidadMedida)
+	}
+
+	if item.CodigoProducto != "P-001" || item.CodigoProductoSunat != "50221101" {
+		t.Errorf("Expected product codes P-001 and 50221101, got %q and %q", item.CodigoProducto, item.CodigoProductoSunat)
+	}
+
+	if item.PrecioUnitario.String() != "40.00" {
+		t.Errorf("Expected precioUnitario 40.00, got %s", item.PrecioUnitario)
+	}
+}
+
+func TestImportar_PrefijosDistintos(t *testing.T) {
+	contenido := `<?xml version="1.0" encoding="UTF-8"?>
+<DebitNote xmlns="urn:oasis:names:specification:ubl:schema:xsd:DebitNote-2"
+  xmlns:a="urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+  xmlns:b="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">
+  <b:ID>F001-00000003</b:ID>
+  <b:IssueDate>2026-02-12</b:IssueDate>
+  <a:DiscrepancyResponse><b:ReferenceID>F001-00000001</b:ReferenceID><b:ResponseCode>01</b:ResponseCode><b:Description>Intereses por mora</b:Description></a:DiscrepancyResponse>
+  <a:BillingReference><a:InvoiceDocumentReference><b:ID>F001-00000001</b:ID><b:DocumentTypeCode>01</b:DocumentTypeCode></a:InvoiceDocumentReference></a:BillingReference>
+  <a:AccountingSupplierParty><a:Party><a:PartyIdentification><b:ID schemeID="6">20123456786</b:ID></a:PartyIdentification></a:Party></a:AccountingSupplierParty>
+  <a:AccountingCustomerParty><a:Party><a:PartyIdentification><b:ID schemeID="6">20987654326</b:ID></a:PartyIdentification></a:Party></a:AccountingCustomerParty>
+  <a:RequestedMonetaryTotal><b:LineExtensionAmount currencyID="PEN">10.00</b:LineExtensionAmount><b:PayableAmount currencyID="PEN">11.80</b:PayableAmount></a:RequestedMonetaryTotal>
+  <a:DebitNoteLine><b:ID>1</b:ID><b:DebitedQuantity unitCode="NIU">1</b:DebitedQuantity><b:LineExtensionAmount currencyID="PEN">10.00</b:LineExtensionAmount><a:Item><b:Description>Interés</b:Description></a:Item><a:Price><b:PriceAmount currencyID="PEN">10.00</b:PriceAmount></a:Price></a:DebitNoteLine>
+</DebitNote>`
+
+	doc, err := Importar([]byte(contenido))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.TipoDocumento != domain.TipoNotaDebito || doc.Referencia == nil || doc.Referencia.IDDocumento != "F001-00000001" {
+		t.Fatalf("Expected debit note referencing F001-00000001, got type %s ref %+v", doc.TipoDocumento, doc.Referencia)
+	}
+
+	if doc.Referencia.CodigoMotivo != "01" || doc.Referencia.Sustento != "Intereses por mora" {
+		t.Errorf("Expected reason 01 Intereses por mora, got %s %s", doc.Referencia.CodigoMotivo, doc.Referencia.Sustento)
+	}
+
+	if doc.MontoTotal.String() != "11.80" || len(doc.Items) != 1 || doc.Items[0].Cantidad.StringCantidad() != "1" {
+		t.Errorf("Expected montoTotal 11.80 with one line, got %s and %d lines", doc.MontoTotal, len(doc.Items))
+	}
+
+	if doc.FechaEmision != "2026-02-12T00:00:00-05:00" {
+		t.Errorf("Expected Peru time zone, got %s", doc.FechaEmision)
+	}
+}
+
+func TestImportar_IDSinCorrelativo(t *testing.T) {
+	valido, _ := Generar(facturaDePrueba())
+
+	testCases := []struct {
+		id          string
+		serie       string
+		idDocumento string
+	}{
+		{"F001", "F001", ""},
+		{"F001-", "F001", ""},
+		{"F001-00000000", "F001", ""},
+		{"F001-00000123", "", "F001-00000123"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			doc, err := Importar([]byte(strings.Replace(string(valido), "<cbc:ID>F001-00000001</cbc:ID>", "<cbc:ID>"+tc.id+"</cbc:ID>", 1)))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if doc.Serie != tc.serie || doc.IDDocumento != tc.idDocumento {
+				t.Errorf("Expected serie %q and idDocumento %q, got %q and %q", tc.serie, tc.idDocumento, doc.Serie, doc.IDDocumento)
+			}
+		})
+	}
+}
+
+func TestImportar_Errores(t *testing.T) {
+	valido, _ := Generar(facturaDePrueba())
+
+	testCases := []struct {
+		name      string
+		contenido string
+		ruta      string
+	}{
+		{"XML mal formado", "<Invoice><cbc:ID>", "/"},
+		{"Raíz desconocida", `<Order xmlns="urn:x"></Order>`, "/Order"},
+		{"Importe inválido en línea", strings.Replace(string(valido), `<cbc:PriceAmount currencyID="PEN">50.00</cbc:PriceAmount>`, `<cbc:PriceAmount currencyID="PEN">cincuenta</cbc:PriceAmount>`, 1), "/Invoice/cac:InvoiceLine[1]/cac:Price/cbc:PriceAmount"},
+		{"Cantidad inválida", strings.Replace(string(valido), `unitCode="NIU">2<`, `unitCode="NIU">2,5<`, 1), "/Invoice/cac:InvoiceLine[1]/cbc:InvoicedQuantity"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Importar([]byte(tc.contenido))
+			errorImportacion, ok := err.(*ErrorImportacion)
+			if !ok {
+				t.Fatalf("Expected ErrorImportacion, got: %v", err)
+			}
+			if errorImportacion.Ruta != tc.ruta {
+				t.Errorf("Expected path %s, got %s (%s)", tc.ruta, errorImportacion.Ruta, errorImportacion.Mensaje)
+			}
+		})
+	}
+}
+
+func TestRutaXML(t *testing.T) {
+	testCases := []struct {
+		tipo     string
+		ruta     string
+		esperado string
+	}{
+		{domain.TipoFactura, "/items/1/precioTotal", "/Invoice/cac:InvoiceLine[2]/cbc:LineExtensionAmount"},
+		{domain.TipoFactura, "/rucEmisor", "/Invoice/cac:AccountingSupplierParty/cac:Party/cac:PartyIdentification/cbc:ID"},
+		{domain.TipoNotaDebito, "/montoTotal", "/DebitNote/cac:RequestedMonetaryTotal/cbc:PayableAmount"},
+		{domain.TipoNotaCredito, "/items/0/cargosDescuentos/1/codigo", "/CreditNote/cac:CreditNoteLine[1]/cac:AllowanceCharge[2]"},
+		{domain.TipoFactura, "/cargosDescuentos/0/factor", "/Invoice/cac:AllowanceCharge[1]"},
+		{domain.TipoNotaCredito, "/referencia/codigoMotivo", "/CreditNote/cac:DiscrepancyResponse/cbc:ResponseCode"},
+		{domain.TipoNotaCredito, "/referencia/idDocumento", "/CreditNote/cac:BillingReference/cac:InvoiceDocumentReference"},
+		{domain.TipoFactura, "/formaPago/cuotas/1/fechaVencimiento", "/Invoice/cac:PaymentTerms[cbc:PaymentMeansID='Cuota002']"},
+		{domain.TipoFactura, "/formaPago/cuotas", "/Invoice/cac:PaymentTerms[cbc:ID='FormaPago']"},
+		{domain.TipoFactura, "/detraccion/cuentaBancoNacion", "/Invoice/cac:PaymentMeans[cbc:ID='Detraccion']/cac:PayeeFinancialAccount/cbc:ID"},
+		{domain.TipoFactura, "/retencion", "/Invoice/cac:AllowanceCharge[cbc:AllowanceChargeReasonCode='62']"},
+		{domain.TipoFactura, "/items/0/unidadMedida", "/Invoice/cac:InvoiceLine[1]/cbc:InvoicedQuantity/@unitCode"},
+		{domain.TipoNotaCredito, "/items/1/cantidad", "/CreditNote/cac:CreditNoteLine[2]/cbc:CreditedQuantity"},
+		{domain.TipoFactura, "/items/0/codigoProducto", "/Invoice/cac:InvoiceLine[1]/cac:Item/cac:SellersItemIdentification/cbc:ID"},
+		{domain.TipoFactura, "/items/2", "/Invoice/cac:InvoiceLine[3]"},
+		{domain.TipoFactura, "/ordenCompra", "/Invoice/cac:OrderReference/cbc:ID"},
+		{domain.TipoFactura, "/items", "/Invoice"},
+		{domain.TipoFactura, "", "/Invoice"},
+	}
+
+	for _, tc := range testCases {
+		if ruta := RutaXML(tc.tipo, tc.ruta); ruta != tc.esperado {
+			t.Errorf("Expected %s for %q, got %s", tc.esperado, tc.ruta, ruta)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package ubl
+
+import "encoding/xml"
+
+// Espacios de nombres de UBL 2.1 que usa SUNAT
+const (
+	NamespaceInvoice    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	NamespaceCreditNote = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	NamespaceDebitNote  = "urn:oasis:names:specification:ubl:schema:xsd:DebitNote-2"
+	NamespaceCac        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	NamespaceCbc        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+	NamespaceExt        = "urn:oasis:names:specification:ubl:schema:xsd:CommonExtensionComponents-2"
+	NamespaceDs         = "http://www.w3.org/2000/09/xmldsig#"
+)
+
+// Comprobante es la raíz común de Invoice, CreditNote y DebitNote. Los campos que solo
+// existen en un tipo quedan vacíos en los demás y se omiten al serializar.
+type Comprobante struct {
+	XMLName  xml.Name
+	Xmlns    string `xml:"xmlns,attr,omitempty"`
+	XmlnsCac string `xml:"xmlns:cac,attr,omitempty"`
+	XmlnsCbc string `xml:"xmlns:cbc,attr,omitempty"`
+	XmlnsExt string `xml:"xmlns:ext,attr,omitempty"`
+	XmlnsDs  string `xml:"xmlns:ds,attr,omitempty"`
+
+	Extensiones          *Extensiones           `xml:"ext:UBLExtensions,omitempty"`
+	UBLVersionID         string                 `xml:"cbc:UBLVersionID"`
+	CustomizationID      string                 `xml:"cbc:CustomizationID"`
+	ID                   string                 `xml:"cbc:ID"`
+	IssueDate            string                 `xml:"cbc:IssueDate"`
+	IssueTime            string                 `xml:"cbc:IssueTime,omitempty"`
+	InvoiceTypeCode      *Codigo                `xml:"cbc:InvoiceTypeCode,omitempty"`
+	Notas                []Nota                 `xml:"cbc:Note"`
+	DocumentCurrencyCode string                 `xml:"cbc:DocumentCurrencyCode"`
+	DiscrepancyResponse  *Discrepancia          `xml:"cac:DiscrepancyResponse,omitempty"`
+	OrderReference       *ReferenciaOrden       `xml:"cac:OrderReference,omitempty"`
+	BillingReference     *ReferenciaFacturacion `xml:"cac:BillingReference,omitempty"`
+	Signature            *FirmaReferencia       `xml:"cac:Signature,omitempty"`
+	Proveedor            Participante           `xml:"cac:AccountingSupplierParty"`
+	Cliente              Participante           `xml:"cac:AccountingCustomerParty"`
+	MediosPago           []MedioPago            `xml:"cac:PaymentMeans"`
+	TerminosPago         []TerminoPago          `xml:"cac:PaymentTerms"`
+	CargosDescuentos     []CargoDescuento       `xml:"cac:AllowanceCharge"`
+	TaxTotal             TotalImpuestos         `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal   *TotalMonetario        `xml:"cac:LegalMonetaryTotal,omitempty"`
+	RequestedTotal       *TotalMonetario        `xml:"cac:RequestedMonetaryTotal,omitempty"`
+	InvoiceLines         []Linea                `xml:"cac:InvoiceLine"`
+	CreditNoteLines      []Linea                `xml:"cac:CreditNoteLine"`
+	DebitNoteLines       []Linea                `xml:"cac:DebitNoteLine"`
+}
+
+type Extensiones struct {
+	Extension []Extension `xml:"ext:UBLExtension"`
+}
+
+type Extension struct {
+	Contenido ContenidoExtension `xml:"ext:ExtensionContent"`
+}
+
+type ContenidoExtension struct {
+	Firma *FirmaDigital `xml:"ds:Signature,omitempty"`
+}
+
+// FirmaDigital lleva el resumen y el valor de firma que MS2 guardó en Validacion
+type FirmaDigital struct {
+	ID             string       `xml:"Id,attr"`
+	SignedInfo     *InfoFirmada `xml:"ds:SignedInfo,omitempty"`
+	SignatureValue string       `xml:"ds:SignatureValue"`
+}
+
+type InfoFirmada struct {
+	Referencia ReferenciaFirmada `xml:"ds:Reference"`
+}
+
+type ReferenciaFirmada struct {
+	URI          string        `xml:"URI,attr"`
+	DigestMethod MetodoResumen `xml:"ds:DigestMethod"`
+	DigestValue  string        `xml:"ds:DigestValue"`
+}
+
+type MetodoResumen struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+type FirmaReferencia struct {
+	ID             string       `xml:"cbc:ID"`
+	SignatoryParty Parte        `xml:"cac:SignatoryParty"`
+	Adjunto        AdjuntoFirma `xml:"cac:DigitalSignatureAttachment"`
+}
+
+type AdjuntoFirma struct {
+	ExternalReference ReferenciaExterna `xml:"cac:ExternalReference"`
+}
+
+type ReferenciaExterna struct {
+	URI string `xml:"cbc:URI"`
+}
+
+type Codigo struct {
+	ListID string `xml:"listID,attr,omitempty"`
+	Valor  string `xml:",chardata"`
+}
+
+// Nota es una leyenda del catálogo 52; el código va en languageLocaleID
+type Nota struct {
+	Codigo string `xml:"languageLocaleID,attr,omitempty"`
+	Valor  string `xml:",chardata"`
+}
+
+type Identificador struct {
+	SchemeID string `xml:"schemeID,attr,omitempty"`
+	Valor    string `xml:",chardata"`
+}
+
+type Importe struct {
+	Moneda string `xml:"currencyID,attr"`
+	Valor  string `xml:",chardata"`
+}
+
+type Cantidad struct {
+	UnidadMedida string `xml:"unitCode,attr"`
+	Valor        string `xml:",chardata"`
+}
+
+// ReferenciaOrden es la orden de compra del adquiriente
+type ReferenciaOrden struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type Discrepancia struct {
+	ReferenceID  string `xml:"cbc:ReferenceID"`
+	ResponseCode string `xml:"cbc:ResponseCode,omitempty"`
+	Description  string `xml:"cbc:Description,omitempty"`
+}
+
+// TerminoPago declara la forma de pago (PaymentMeansID Contado o Credito), una cuota (Cuota001, Cuota002...),
+// la detracción (PaymentMeansID con el código del catálogo 54) o el total cobrado con percepción
+type TerminoPago struct {
+	ID             string   `xml:"cbc:ID"`
+	PaymentMeansID string   `xml:"cbc:PaymentMeansID,omitempty"`
+	PaymentPercent string   `xml:"cbc:PaymentPercent,omitempty"`
+	Amount         *Importe `xml:"cbc:Amount,omitempty"`
+	PaymentDueDate string   `xml:"cbc:PaymentDueDate,omitempty"`
+}
+
+// MedioPago lleva la cuenta del Banco de la Nación donde se deposita la detracción
+type MedioPago struct {
+	ID                    string           `xml:"cbc:ID"`
+	PaymentMeansCode      string           `xml:"cbc:PaymentMeansCode"`
+	PayeeFinancialAccount CuentaFinanciera `xml:"cac:PayeeFinancialAccount"`
+}
+
+type CuentaFinanciera struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type ReferenciaFacturacion struct {
+	Documento DocumentoReferenciado `xml:"cac:InvoiceDocumentReference"`
+}
+
+type DocumentoReferenciado struct {
+	ID               string `xml:"cbc:ID"`
+	DocumentTypeCode string `xml:"cbc:DocumentTypeCode"`
+}
+
+type Participante struct {
+	Party Parte `xml:"cac:Party"`
+}
+
+type Parte struct {
+	Identificacion IdentificacionParte `xml:"cac:PartyIdentification"`
+	Nombre         *NombreParte        `xml:"cac:PartyName,omitempty"`
+	EntidadLegal   *EntidadLegal       `xml:"cac:PartyLegalEntity,omitempty"`
+	Contacto       *Contacto           `xml:"cac:Contact,omitempty"`
+}
+
+type NombreParte struct {
+	Name string `xml:"cbc:Name"`
+}
+
+type IdentificacionParte struct {
+	ID Identificador `xml:"cbc:ID"`
+}
+
+type EntidadLegal struct {
+	RegistrationName    string             `xml:"cbc:RegistrationName"`
+	RegistrationAddress *DireccionRegistro `xml:"cac:RegistrationAddress,omitempty"`
+}
+
+// DireccionRegistro es el domicilio de la parte; el del emisor lleva el ubigeo en ID y el código de
+// establecimiento en AddressTypeCode
+type DireccionRegistro struct {
+	ID              string         `xml:"cbc:ID,omitempty"`
+	AddressTypeCode string         `xml:"cbc:AddressTypeCode,omitempty"`
+	AddressLine     LineaDomicilio `xml:"cac:AddressLine"`
+}
+
+type LineaDomicilio struct {
+	Line string `xml:"cbc:Line"`
+}
+
+type Contacto struct {
+	ElectronicMail string `xml:"cbc:ElectronicMail"`
+}
+
+type CargoDescuento struct {
+	ChargeIndicator           bool     `xml:"cbc:ChargeIndicator"`
+	AllowanceChargeReasonCode string   `xml:"cbc:AllowanceChargeReasonCode"`
+	MultiplierFactorNumeric   string   `xml:"cbc:MultiplierFactorNumeric,omitempty"`
+	Amount                    Importe  `xml:"cbc:Amount"`
+	BaseAmount                *Importe `xml:"cbc:BaseAmount,omitempty"`
+}
+
+type TotalImpuestos struct {
+	TaxAmount    Importe            `xml:"cbc:TaxAmount"`
+	TaxSubtotals []SubtotalImpuesto `xml:"cac:TaxSubtotal"`
+}
+
+type SubtotalImpuesto struct {
+	TaxableAmount   *Importe          `xml:"cbc:TaxableAmount,omitempty"`
+	TaxAmount       Importe           `xml:"cbc:TaxAmount"`
+	BaseUnitMeasure *Cantidad         `xml:"cbc:BaseUnitMeasure,omitempty"`
+	TaxCategory     CategoriaImpuesto `xml:"cac:TaxCategory"`
+}
+
+type CategoriaImpuesto struct {
+	ID                     *Identificador  `xml:"cbc:ID,omitempty"`
+	Percent                string          `xml:"cbc:Percent,omitempty"`
+	PerUnitAmount          *Importe        `xml:"cbc:PerUnitAmount,omitempty"`
+	TaxExemptionReasonCode string          `xml:"cbc:TaxExemptionReasonCode,omitempty"`
+	TaxScheme              EsquemaImpuesto `xml:"cac:TaxScheme"`
+}
+
+type EsquemaImpuesto struct {
+	ID          string `xml:"cbc:ID"`
+	Name        string `xml:"cbc:Name"`
+	TaxTypeCode string `xml:"cbc:TaxTypeCode"`
+}
+
+type TotalMonetario struct {
+	LineExtensionAmount  Importe  `xml:"cbc:LineExtensionAmount"`
+	TaxInclusiveAmount   Importe  `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotalAmount *Importe `xml:"cbc:AllowanceTotalAmount,omitempty"`
+	ChargeTotalAmount    *Importe `xml:"cbc:ChargeTotalAmount,omitempty"`
+	PayableAmount        Importe  `xml:"cbc:PayableAmount"`
+}
+
+// Linea es una InvoiceLine, CreditNoteLine o DebitNoteLine; solo una de las cantidades se llena
+type Linea struct {
+	ID                  string            `xml:"cbc:ID"`
+	InvoicedQuantity    *Cantidad         `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *Cantidad         `xml:"cbc:CreditedQuantity,omitempty"`
+	DebitedQuantity     *Cantidad         `xml:"cbc:DebitedQuantity,omitempty"`
+	LineExtensionAmount Importe           `xml:"cbc:LineExtensionAmount"`
+	PricingReference    *ReferenciaPrecio `xml:"cac:PricingReference,omitempty"`
+	CargosDescuentos    []CargoDescuento  `xml:"cac:AllowanceCharge"`
+	TaxTotal            TotalImpuestos    `xml:"cac:TaxTotal"`
+	Item                DescripcionItem   `xml:"cac:Item"`
+	Price               Precio            `xml:"cac:Price"`
+}
+
+type ReferenciaPrecio struct {
+	Precio PrecioAlternativo `xml:"cac:AlternativeConditionPrice"`
+}
+
+type PrecioAlternativo struct {
+	PriceAmount   Importe `xml:"cbc:PriceAmount"`
+	PriceTypeCode string  `xml:"cbc:PriceTypeCode"`
+}
+
+type DescripcionItem struct {
+	Description               string                 `xml:"cbc:Description"`
+	SellersItemIdentification *IdentificacionItem    `xml:"cac:SellersItemIdentification,omitempty"`
+	CommodityClassification   *ClasificacionProducto `xml:"cac:CommodityClassification,omitempty"`
+}
+
+type IdentificacionItem struct {
+	ID string `xml:"cbc:ID"`
+}
+
+// ClasificacionProducto lleva el código UNSPSC del producto
+type ClasificacionProducto struct {
+	ItemClassificationCode CodigoClasificacion `xml:"cbc:ItemClassificationCode"`
+}
+
+type CodigoClasificacion struct {
+	ListID string `xml:"listID,attr,omitempty"`
+	Valor  string `xml:",chardata"`
+}
+
+type Precio struct {
+	PriceAmount Importe `xml:"cbc:PriceAmount"`
+}
@@ -7,3 +7,17 @@ const (
 	UpdateOperationTimeout  = 7 * time.Second
 	DatabaseTimeout         = 10 * time.Second
 )
+
+// MaxUBLBodySize limita el XML UBL que se lee en memoria al importar un documento
+const MaxUBLBodySize = 1 << 20
+
+const (
+	SeriesCollection         = "series"
+	VoidSummariesCollection  = "comunicaciones_baja"
+	DailySummariesCollection = "resumenes_diarios"
+	IssuersCollection        = "emisores"
+	CustomersCollection      = "clientes"
+	ProductsCollection       = "productos"
+	BusinessRulesCollection  = "reglas_negocio"
+	ExchangeRatesCollection  = "tipos_cambio"
+)
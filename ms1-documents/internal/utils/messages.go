@@ -2,15 +2,55 @@ package utils
 
 const (
 	ErrorInvalidJSON = "JSON invalido o mal formado"
+	ErrorInvalidXML  = "XML UBL invalido"
+	ErrorXMLTooLarge = "El XML UBL supera el tamaño máximo de 1 MB"
+	ErrorInvalidCSV  = "CSV invalido"
 
-	ErrorCreatingDocument  = "Error al crear documento"
-	ErrorFetchingDocuments = "Error al obtener documentos"
-	ErrorFetchingDocument  = "Error al buscar documento"
-	ErrorUpdatingDocument  = "Error al actualizar documento"
-	ErrorDeletingDocument  = "Error al eliminar documento"
-	ErrorVerifyingDocument = "Error al verificar documento"
+	ErrorCreatingDocument       = "Error al crear documento"
+	ErrorFetchingDocuments      = "Error al obtener documentos"
+	ErrorFetchingDocument       = "Error al buscar documento"
+	ErrorUpdatingDocument       = "Error al actualizar documento"
+	ErrorDeletingDocument       = "Error al eliminar documento"
+	ErrorVerifyingDocument      = "Error al verificar documento"
+	ErrorValidatingDocument     = "Error al validar documento"
+	ErrorFetchingSeries         = "Error al obtener series"
+	ErrorVoidingDocument        = "Error al anular documento"
+	ErrorFetchingVoidSummary    = "Error al obtener comunicaciones de baja"
+	ErrorFetchingOverdue        = "Error al obtener documentos con cuotas vencidas"
+	ErrorGeneratingDailySummary = "Error al generar resumen diario"
+	ErrorFetchingDailySummary   = "Error al obtener resumen diario"
+	ErrorGeneratingUBL          = "Error al generar el XML UBL del documento"
+	ErrorGeneratingPrintable    = "Error al generar la representacion impresa del documento"
 
-	SuccessDocumentDeleted  = "Documento eliminado correctamente"
-	SuccessDocumentVerified = "La firma es valida y el documento no ha sido modificado"
-	ErrorInvalidSignature   = "La firma es invalida o el documento ha sido modificado"
+	ErrorRegisteringExchangeRates = "Error al registrar tipos de cambio"
+	ErrorFetchingExchangeRates    = "Error al obtener tipos de cambio"
+
+	ErrorCreatingIssuer  = "Error al registrar emisor"
+	ErrorFetchingIssuers = "Error al obtener emisores"
+	ErrorFetchingIssuer  = "Error al buscar emisor"
+	ErrorUpdatingIssuer  = "Error al actualizar emisor"
+	ErrorDeletingIssuer  = "Error al eliminar emisor"
+
+	ErrorFetchingCustomers = "Error al buscar clientes"
+
+	ErrorCreatingProduct  = "Error al registrar producto"
+	ErrorFetchingProducts = "Error al obtener productos"
+	ErrorFetchingProduct  = "Error al buscar producto"
+	ErrorUpdatingProduct  = "Error al actualizar producto"
+	ErrorDeletingProduct  = "Error al eliminar producto"
+
+	ErrorCreatingBusinessRule  = "Error al registrar regla de negocio"
+	ErrorFetchingBusinessRules = "Error al obtener reglas de negocio"
+	ErrorFetchingBusinessRule  = "Error al buscar regla de negocio"
+	ErrorUpdatingBusinessRule  = "Error al actualizar regla de negocio"
+	ErrorDeletingBusinessRule  = "Error al eliminar regla de negocio"
+
+	SuccessDocumentDeleted         = "Documento eliminado correctamente"
+	SuccessDocumentVoided          = "Documento anulado correctamente"
+	SuccessDocumentVerified        = "La firma es valida y el documento no ha sido modificado"
+	SuccessExchangeRatesRegistered = "Tipos de cambio registrados correctamente"
+	SuccessIssuerDeleted           = "Emisor eliminado correctamente"
+	SuccessProductDeleted          = "Producto eliminado correctamente"
+	SuccessBusinessRuleDeleted     = "Regla de negocio eliminada correctamente"
+	ErrorInvalidSignature          = "La firma es invalida o el documento ha sido modificado"
 )
@@ -8,13 +8,13 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func DocumentIDFilter(id string) bson.M {
-	return bson.M{"idDocumento": id}
+func DocumentIDFilter(rucEmisor, id string) bson.M {
+	return bson.M{"rucEmisor": rucEmisor, "idDocumento": id}
 }
 
 func HandleMongoDuplicateError(err error, idDocumento string) error {
 	if mongo.IsDuplicateKeyError(err) {
-		return errors.ErrorConflicto(fmt.Sprintf("Ya existe un documento con ID %s", idDocumento))
+		return errors.ErrorConflicto(fmt.Sprintf("El emisor ya tiene un documento con ID %s", idDocumento))
 	}
 	return nil
 }
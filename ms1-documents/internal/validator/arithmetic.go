@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/config"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ModoAritmetico decide qué hacer cuando los importes declarados no cuadran
+type ModoAritmetico string
+
+const (
+	ModoAritmeticoEstricto    ModoAritmetico = "STRICT"
+	ModoAritmeticoAdvertencia ModoAritmetico = "WARN"
+)
+
+// toleranciaIGV es la diferencia que SUNAT admite entre igvTotal y el IGV calculado sobre la base total
+var toleranciaIGV = money.MustParse("0.01")
+
+// tasasICBPER contiene el impuesto por bolsa plástica vigente desde cada año
+var tasasICBPER = []struct {
+	desde int
+	tasa  money.Money
+}{
+	{2023, money.MustParse("0.50")},
+	{2022, money.MustParse("0.40")},
+	{2021, money.MustParse("0.30")},
+	{2020, money.MustParse("0.20")},
+	{2019, money.MustParse("0.10")},
+}
+
+func ParseModoAritmetico(valor string) (ModoAritmetico, error) {
+	modo := ModoAritmetico(strings.ToUpper(strings.TrimSpace(valor)))
+	switch modo {
+	case ModoAritmeticoEstricto, ModoAritmeticoAdvertencia:
+		return modo, nil
+	}
+	return "", fmt.Errorf("modo de validación aritmética desconocido: %s", valor)
+}
+
+func ConModoAritmetico(modo ModoAritmetico) Opcion {
+	return func(v *DocumentValidator) {
+		v.modoAritmetico = modo
+	}
+}
+
+// validarAritmetica repite en MS1 los cálculos que MS2 comprueba antes de firmar.
+// En modo advertencia la inconsistencia solo se registra en el log.
+func (v *DocumentValidator) validarAritmetica(doc *domain.Document) error {
+	v.completarCargosDescuentos(doc)
+	completarSubtotales(doc)
+
+	err := v.calcularAritmetica(doc)
+	if err == nil || v.modoAritmetico == ModoAritmeticoEstricto {
+		return err
+	}
+
+	if config.Logger != nil {
+		config.Logger.Warn("Inconsistencia aritmetica en documento",
+			zap.String("idDocumento", doc.IDDocumento),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// subtotales acumula los importes de los items por categoría de afectación
+// y los cargos y descuentos que no afectan la base imponible
+type subtotales struct {
+	gravado          money.Money
+	exonerado        money.Money
+	inafecto         money.Money
+	gratuito         money.Money
+	iscGravado       money.Money
+	igvItems         money.Money
+	icbper           money.Money
+	descuentos       money.Money
+	cargos           money.Money
+	descuentosNoBase money.Money
+	cargosNoBase     money.Money
+}
+
+// completarSubtotales calcula los subtotales por afectación cuando el cliente no envía ninguno,
+// para que los documentos que solo declaran montoTotalSinImpuestos sigan siendo aceptados
+func completarSubtotales(doc *domain.Document) {
+	acumulado := acumularSubtotales(doc)
+
+	if doc.TotalDescuentos.EsCero() {
+		doc.TotalDescuentos = acumulado.descuentos
+	}
+	if doc.TotalCargos.EsCero() {
+		doc.TotalCargos = acumulado.cargos
+	}
+
+	if !doc.TotalGravado.EsCero() || !doc.TotalExonerado.EsCero() || !doc.TotalInafecto.EsCero() || !doc.TotalGratuito.EsCero() {
+		return
+	}
+
+	doc.TotalGravado = acumulado.gravado
+	doc.TotalExonerado = acumulado.exonerado
+	doc.TotalInafecto = acumulado.inafecto
+	doc.TotalGratuito = acumulado.gratuito
+}
+
+// acumularSubtotales suma los items por afectación y aplica los cargos y descuentos globales.
+// Los globales que afectan la base modifican el total gravado.
+func acumularSubtotales(doc *domain.Document) subtotales {
+	acumulado := subtotales{}
+	for _, item := range doc.Items {
+		acumulado.agregar(item)
+		acumulado.agregarCargosDescuentos(item.CargosDescuentos)
+	}
+
+	acumulado.gravado = acumulado.gravado.Sumar(ajusteBase(doc.CargosDescuentos))
+	acumulado.agregarCargosDescuentos(doc.CargosDescuentos)
+	return acumulado
+}
+
+func (v *DocumentValidator) calcularAritmetica(doc *domain.Document) error {
+	fechaEmision, _ := time.Parse(time.RFC3339, doc.FechaEmision)
+
+	var errores violaciones
+	for indice, item := range doc.Items {
+		errores.agregar(v.validarAritmeticaItem(indice, item, fechaEmision, doc.TasaIgv))
+	}
+
+	errores.agregar(v.validarMontosPorFactor(doc.CargosDescuentos, "", ""))
+
+	acumulado := acumularSubtotales(doc)
+
+	totales := []struct {
+		campo    string
+		formula  string
+		esperado money.Money
+		recibido money.Money
+	}{
+		{"totalGravado", "la suma de items gravados ajustada por cargos y descuentos globales", acumulado.gravado, doc.TotalGravado},
+		{"totalExonerado", "la suma de items exonerados", acumulado.exonerado, doc.TotalExonerado},
+		{"totalInafecto", "la suma de items inafectos", acumulado.inafecto, doc.TotalInafecto},
+		{"totalGratuito", "la suma de items gratuitos", acumulado.gratuito, doc.TotalGratuito},
+		{"montoTotalSinImpuestos", "totalGravado + totalExonerado + totalInafecto", acumulado.gravado.Sumar(acumulado.exonerado).Sumar(acumulado.inafecto), doc.MontoTotalSinImpuestos},
+		{"iscTotal", "la suma de iscTotal de los items onerosos", acumulado.iscGravado, doc.IscTotal},
+		{"icbperTotal", "la suma de icbperTotal de los items", acumulado.icbper, doc.IcbperTotal},
+		{"totalDescuentos", "la suma de descuentos de items y globales", acumulado.descuentos, doc.TotalDescuentos},
+		{"totalCargos", "la suma de cargos de items y globales", acumulado.cargos, doc.TotalCargos},
+	}
+
+	for _, total := range totales {
+		if !total.recibido.Igual(total.esperado) {
+			errores.agregar(errorDescuadre("/"+total.campo, total.campo, total.formula, total.esperado, total.recibido))
+		}
+	}
+
+	igvEsperado := v.calcularIGV(acumulado.gravado.Sumar(acumulado.iscGravado), doc.TasaIgv)
+	sinAjusteGlobal := ajusteBase(doc.CargosDescuentos).EsCero()
+	if !igvTotalAceptado(doc.IgvTotal, igvEsperado, acumulado.igvItems, sinAjusteGlobal) {
+		formula := "(totalGravado + iscTotal) × " + porcentaje(doc.TasaIgv) + " ± " + toleranciaIGV.String()
+		if sinAjusteGlobal {
+			formula += " ni con la suma de igvTotal de los items gravados (" + acumulado.igvItems.String() + ")"
+		}
+		errores.agregar(errorDescuadre("/igvTotal", "igvTotal", formula, igvEsperado, doc.IgvTotal))
+	}
+
+	montoEsperado := doc.MontoTotalSinImpuestos.Sumar(doc.IscTotal).Sumar(doc.IgvTotal).Sumar(doc.IcbperTotal).
+		Restar(acumulado.descuentosNoBase).Sumar(acumulado.cargosNoBase)
+	if !doc.MontoTotal.Igual(montoEsperado) {
+		errores.agregar(errorDescuadre("/montoTotal", "montoTotal", "montoTotalSinImpuestos + iscTotal + igvTotal + icbperTotal − descuentos + cargos que no afectan la base", montoEsperado, doc.MontoTotal))
+	}
+
+	return errores.error()
+}
+
+// validarAritmeticaItem comprueba el precio, el IGV según la afectación y el ICBPER de un item.
+// En las transferencias gratuitas gravadas el IGV se calcula sobre el valor referencial.
+func (v *DocumentValidator) validarAritmeticaItem(indice int, item domain.Item, fechaEmision time.Time, tasaIGV money.Money) error {
+	var errores violaciones
+	errores.agregar(v.validarMontosPorFactor(item.CargosDescuentos, fmt.Sprintf("item %d ", indice), "/items/"+strconv.Itoa(indice)))
+
+	formulaPrecio := "precioUnitario × cantidad"
+	if len(item.CargosDescuentos) > 0 {
+		formulaPrecio += " − descuentos + cargos que afectan la base"
+	}
+	precioEsperado := v.valorBruto(item).Sumar(ajusteBase(item.CargosDescuentos))
+	if !item.PrecioTotal.Igual(precioEsperado) {
+		errores.agregar(errorDescuadre(rutaItem(indice, "precioTotal"), fmt.Sprintf("precioTotal del item %d", indice), formulaPrecio, precioEsperado, item.PrecioTotal))
+	}
+
+	categoria, _, _ := domain.CategoriaAfectacion(item.TipoAfectacionIgv)
+	igvEsperado, formula := money.Cero(), "0 para operaciones exoneradas o inafectas"
+	if categoria == domain.CategoriaGravado {
+		igvEsperado, formula = v.calcularIGV(item.PrecioTotal.Sumar(item.IscTotal), tasaIGV), "(precioTotal + iscTotal) × "+porcentaje(tasaIGV)
+	}
+	if !item.IgvTotal.Igual(igvEsperado) {
+		errores.agregar(errorDescuadre(rutaItem(indice, "igvTotal"), fmt.Sprintf("igvTotal del item %d", indice), formula, igvEsperado, item.IgvTotal))
+	}
+
+	if !item.IcbperTotal.EsCero() {
+		icbperEsperado := v.multiplicar(tasaICBPER(fechaEmision), item.Cantidad)
+		if !item.IcbperTotal.Igual(icbperEsperado) {
+			errores.agregar(errorDescuadre(rutaItem(indice, "icbperTotal"), fmt.Sprintf("icbperTotal del item %d", indice), "cantidad × tasa ICBPER vigente", icbperEsperado, item.IcbperTotal))
+		}
+	}
+
+	return errores.error()
+}
+
+func (s *subtotales) agregar(item domain.Item) {
+	categoria, gratuito, _ := domain.CategoriaAfectacion(item.TipoAfectacionIgv)
+	s.icbper = s.icbper.Sumar(item.IcbperTotal)
+
+	if gratuito {
+		s.gratuito = s.gratuito.Sumar(item.PrecioTotal)
+		return
+	}
+
+	switch categoria {
+	case domain.CategoriaGravado:
+		s.gravado = s.gravado.Sumar(item.PrecioTotal)
+		s.iscGravado = s.iscGravado.Sumar(item.IscTotal)
+		s.igvItems = s.igvItems.Sumar(item.IgvTotal)
+	case domain.CategoriaExonerado:
+		s.exonerado = s.exonerado.Sumar(item.PrecioTotal)
+	case domain.CategoriaInafecto:
+		s.inafecto = s.inafecto.Sumar(item.PrecioTotal)
+	}
+}
+
+func (s *subtotales) agregarCargosDescuentos(lista []domain.CargoDescuento) {
+	for _, cargoDescuento := range lista {
+		esCargo, afectaBase, _, _ := domain.MotivoCargoDescuento(cargoDescuento.Codigo)
+		switch {
+		case esCargo:
+			s.cargos = s.cargos.Sumar(cargoDescuento.Monto)
+			if !afectaBase {
+				s.cargosNoBase = s.cargosNoBase.Sumar(cargoDescuento.Monto)
+			}
+		default:
+			s.descuentos = s.descuentos.Sumar(cargoDescuento.Monto)
+			if !afectaBase {
+				s.descuentosNoBase = s.descuentosNoBase.Sumar(cargoDescuento.Monto)
+			}
+		}
+	}
+}
+
+// igvTotalAceptado admite el IGV de la base total con la tolerancia de SUNAT o, cuando ningún cargo o
+// descuento global cambia la base, la suma del IGV ya redondeado de cada item
+func igvTotalAceptado(recibido, esperado, igvItems money.Money, sinAjusteGlobal bool) bool {
+	if sinAjusteGlobal && recibido.Igual(igvItems) {
+		return true
+	}
+	diferencia := recibido.Restar(esperado)
+	return diferencia.Comparar(toleranciaIGV) <= 0 && diferencia.Comparar(money.Cero().Restar(toleranciaIGV)) >= 0
+}
+
+func tasaICBPER(fechaEmision time.Time) money.Money {
+	for _, vigencia := range tasasICBPER {
+		if fechaEmision.Year() >= vigencia.desde {
+			return vigencia.tasa
+		}
+	}
+	return money.Cero()
+}
+
+func (v *DocumentValidator) calcularIGV(base, tasa money.Money) money.Money {
+	return v.multiplicar(base, tasa)
+}
+
+// porcentaje muestra una tasa como 18% o 10.5% en los mensajes de descuadre
+func porcentaje(tasa money.Money) string {
+	texto := strings.TrimRight(strings.TrimRight(tasa.MultiplicarEntero(100).StringFijo(money.Decimales), "0"), ".")
+	return texto + "%"
+}
+
+func (v *DocumentValidator) redondear(valor money.Money) money.Money {
+	return valor.Redondear(money.DecimalesMonto, v.modoRedondeo)
+}
+
+// multiplicar calcula el producto exacto y lo redondea una sola vez a los decimales de un monto
+func (v *DocumentValidator) multiplicar(valor, factor money.Money) money.Money {
+	return valor.MultiplicarRedondeado(factor, money.DecimalesMonto, v.modoRedondeo)
+}
+
+func errorDescuadre(ruta, campo, formula string, esperado, recibido money.Money) error {
+	return violacionEn(ruta, CodigoNoCoincide, fmt.Sprintf("%s no coincide con %s. Esperado: %s, recibido: %s", campo, formula, esperado, recibido))
+}
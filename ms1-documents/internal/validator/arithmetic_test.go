@@ -0,0 +1,279 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func TestAritmetica_Descuadres(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+		campo     string
+		esperado  string
+	}{
+		{
+			"PrecioTotal distinto de precioUnitario × cantidad",
+			func(doc *domain.Document) { doc.Items[0].PrecioTotal = money.MustParse("99.00") },
+			"precioTotal del item 0",
+			"Esperado: 100.00",
+		},
+		{
+			"IGV del item incorrecto",
+			func(doc *domain.Document) { doc.Items[0].IgvTotal = money.MustParse("18.01") },
+			"igvTotal del item 0",
+			"Esperado: 18.00",
+		},
+		{
+			"Suma de items distinta",
+			func(doc *domain.Document) { doc.MontoTotalSinImpuestos = money.MustParse("101.00") },
+			"montoTotalSinImpuestos",
+			"Esperado: 100.00",
+		},
+		{
+			"IGV total incorrecto",
+			func(doc *domain.Document) { doc.IgvTotal = money.MustParse("17.00") },
+			"igvTotal no coincide",
+			"Esperado: 18.00",
+		},
+		{
+			"MontoTotal incorrecto",
+			func(doc *domain.Document) { doc.MontoTotal = money.MustParse("120.00") },
+			"montoTotal",
+			"Esperado: 118.00",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			tc.modificar(doc)
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if err == nil {
+				t.Fatal("Expected arithmetic error")
+			}
+
+			if !contieneViolacion(err, tc.campo, tc.esperado) {
+				t.Errorf("Expected message for %q with %q, got: %s", tc.campo, tc.esperado, err.Error())
+			}
+		})
+	}
+}
+
+func TestAritmetica_RedondeoIGV(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Items[0].PrecioUnitario = money.MustParse("33.3425")
+	doc.Items[0].Cantidad = money.NewFromInt(3)
+	doc.Items[0].PrecioTotal = money.MustParse("100.03")
+	doc.Items[0].IgvTotal = money.MustParse("18.01")
+	doc.MontoTotalSinImpuestos = money.MustParse("100.03")
+	doc.IgvTotal = money.MustParse("18.01")
+	doc.MontoTotal = money.MustParse("118.04")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	err := NewDocumentValidator(ConModoRedondeo(money.RedondeoAbajo)).ValidarDocumento(context.Background(), doc)
+	if err == nil {
+		t.Error("Expected error when rounding down 100.0275 to 100.02")
+	}
+}
+
+func TestAritmetica_RedondeoUnico(t *testing.T) {
+	// 2.469999 bolsas × 0.50 = 1.2349995 y 0.25 × 18% = 0.045: ambos quedan en el límite del medio céntimo
+	documento := func(igv, icbper string) *domain.Document {
+		doc := documentoBase(domain.TipoFactura, "F001-00000001")
+		doc.FechaEmision = "2026-02-12T10:00:00Z"
+		doc.Items = []domain.Item{{
+			Descripcion:    "Bolsa plástica",
+			PrecioUnitario: money.MustParse("0.10"),
+			Cantidad:       money.MustParse("2.469999"),
+			PrecioTotal:    money.MustParse("0.25"),
+			IgvTotal:       money.MustParse(igv),
+			IcbperTotal:    money.MustParse(icbper),
+		}}
+		doc.MontoTotalSinImpuestos = money.MustParse("0.25")
+		doc.IgvTotal = money.MustParse(igv)
+		doc.IcbperTotal = money.MustParse(icbper)
+		doc.MontoTotal = doc.MontoTotalSinImpuestos.Sumar(doc.IgvTotal).Sumar(doc.IcbperTotal)
+		return doc
+	}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), documento("0.05", "1.23")); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), documento("0.05", "1.24"))
+	if !contieneViolacion(err, "icbperTotal del item 0", "Esperado: 1.23") {
+		t.Errorf("Expected icbperTotal rounded once to 1.23, got: %v", err)
+	}
+
+	err = NewDocumentValidator(ConModoRedondeo(money.RedondeoMitadPar)).ValidarDocumento(context.Background(), documento("0.05", "1.23"))
+	if !contieneViolacion(err, "igvTotal del item 0", "Esperado: 0.04") {
+		t.Errorf("Expected igvTotal of 0.045 rounded half even to 0.04, got: %v", err)
+	}
+}
+
+func TestAritmetica_ToleranciaIGV(t *testing.T) {
+	// 20 items de 0.05: el IGV redondeado por línea suma 0.20 y el calculado sobre el total es 0.18
+	documento := func(igvTotal string) *domain.Document {
+		doc := documentoBase(domain.TipoFactura, "F001-00000001")
+		doc.Items = nil
+		for i := 0; i < 20; i++ {
+			doc.Items = append(doc.Items, domain.Item{
+				Descripcion:    "Item",
+				PrecioUnitario: money.MustParse("0.05"),
+				Cantidad:       money.NewFromInt(1),
+				PrecioTotal:    money.MustParse("0.05"),
+				IgvTotal:       money.MustParse("0.01"),
+			})
+		}
+		doc.MontoTotalSinImpuestos = money.MustParse("1.00")
+		doc.IgvTotal = money.MustParse(igvTotal)
+		doc.MontoTotal = money.MustParse("1.00").Sumar(doc.IgvTotal)
+		return doc
+	}
+
+	testCases := []struct {
+		name     string
+		igvTotal string
+		valido   bool
+	}{
+		{"IGV calculado sobre el total", "0.18", true},
+		{"Suma del IGV de los items", "0.20", true},
+		{"Dentro de la tolerancia de 1 céntimo", "0.19", true},
+		{"Fuera de la tolerancia", "0.21", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), documento(tc.igvTotal))
+			if tc.valido && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if !tc.valido && !contieneViolacion(err, "igvTotal", "igvTotal") {
+				t.Errorf("Expected igvTotal error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestAritmetica_ModoAdvertencia(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.MontoTotal = money.MustParse("120.00")
+
+	err := NewDocumentValidator(ConModoAritmetico(ModoAritmeticoAdvertencia)).ValidarDocumento(context.Background(), doc)
+	if err != nil {
+		t.Errorf("Expected no error in warning mode, got: %v", err)
+	}
+}
+
+func TestParseModoAritmetico(t *testing.T) {
+	if modo, err := ParseModoAritmetico("warn"); err != nil || modo != ModoAritmeticoAdvertencia {
+		t.Errorf("Expected WARN, got %s (%v)", modo, err)
+	}
+
+	if _, err := ParseModoAritmetico("LENIENT"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func documentoConAfectaciones() *domain.Document {
+	return &domain.Document{
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		FechaEmision:           "2026-02-12T10:00:00Z",
+		TotalGravado:           money.MustParse("100.20"),
+		TotalExonerado:         money.MustParse("50.00"),
+		TotalInafecto:          money.MustParse("30.00"),
+		TotalGratuito:          money.MustParse("20.00"),
+		MontoTotalSinImpuestos: money.MustParse("180.20"),
+		IscTotal:               money.MustParse("10.00"),
+		IgvTotal:               money.MustParse("19.84"),
+		IcbperTotal:            money.MustParse("1.00"),
+		MontoTotal:             money.MustParse("211.04"),
+		Items: []domain.Item{
+			{Descripcion: "Licor", TipoAfectacionIgv: "10", PrecioUnitario: money.MustParse("50.00"), Cantidad: money.NewFromInt(2), PrecioTotal: money.MustParse("100.00"), IscTotal: money.MustParse("10.00"), IgvTotal: money.MustParse("19.80")},
+			{Descripcion: "Libro", TipoAfectacionIgv: "20", PrecioUnitario: money.MustParse("25.00"), Cantidad: money.NewFromInt(2), PrecioTotal: money.MustParse("50.00"), IgvTotal: money.MustParse("0")},
+			{Descripcion: "Servicio educativo", TipoAfectacionIgv: "30", PrecioUnitario: money.MustParse("30.00"), Cantidad: money.NewFromInt(1), PrecioTotal: money.MustParse("30.00"), IgvTotal: money.MustParse("0")},
+			{Descripcion: "Muestra gratuita", TipoAfectacionIgv: "11", PrecioUnitario: money.MustParse("20.00"), Cantidad: money.NewFromInt(1), PrecioTotal: money.MustParse("20.00"), IgvTotal: money.MustParse("3.60")},
+			{Descripcion: "Bolsa plástica", TipoAfectacionIgv: "10", PrecioUnitario: money.MustParse("0.10"), Cantidad: money.NewFromInt(2), PrecioTotal: money.MustParse("0.20"), IgvTotal: money.MustParse("0.04"), IcbperTotal: money.MustParse("1.00")},
+		},
+	}
+}
+
+func TestAritmetica_AfectacionesMixtas(t *testing.T) {
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), documentoConAfectaciones()); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+}
+
+func TestAritmetica_AfectacionesInvalidas(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+	}{
+		{"IGV en item exonerado", func(doc *domain.Document) { doc.Items[1].IgvTotal = money.MustParse("9.00") }},
+		{"ISC en item inafecto", func(doc *domain.Document) { doc.Items[2].IscTotal = money.MustParse("1.00") }},
+		{"Código fuera del catálogo 07", func(doc *domain.Document) { doc.Items[0].TipoAfectacionIgv = "99" }},
+		{"ICBPER con tasa de otro año", func(doc *domain.Document) { doc.Items[4].IcbperTotal = money.MustParse("0.80") }},
+		{"Gratuito sumado a gravado", func(doc *domain.Document) { doc.TotalGravado = money.MustParse("120.20") }},
+		{"IGV total sin considerar ISC", func(doc *domain.Document) { doc.IgvTotal = money.MustParse("18.04") }},
+		{"Subtotal negativo", func(doc *domain.Document) { doc.TotalInafecto = money.MustParse("-30.00") }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoConAfectaciones()
+			tc.modificar(doc)
+
+			if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestAritmetica_SubtotalesOmitidos(t *testing.T) {
+	doc := documentoConAfectaciones()
+	doc.TotalGravado = money.Cero()
+	doc.TotalExonerado = money.Cero()
+	doc.TotalInafecto = money.Cero()
+	doc.TotalGratuito = money.Cero()
+	doc.Items[0].TipoAfectacionIgv = ""
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.Items[0].TipoAfectacionIgv != domain.AfectacionGravadoOneroso {
+		t.Errorf("Expected default tipoAfectacionIgv 10, got %q", doc.Items[0].TipoAfectacionIgv)
+	}
+
+	if doc.TotalGravado.String() != "100.20" || doc.TotalGratuito.String() != "20.00" {
+		t.Errorf("Expected subtotals to be filled, got gravado %s gratuito %s", doc.TotalGravado, doc.TotalGratuito)
+	}
+}
+
+// contieneViolacion busca entre los errores acumulados el del campo con el texto esperado
+func contieneViolacion(err error, campo, texto string) bool {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		return false
+	}
+	for _, violacion := range appErr.Errors {
+		if strings.HasPrefix(violacion.Message, campo) && strings.Contains(violacion.Message, texto) {
+			return true
+		}
+	}
+	return false
+}
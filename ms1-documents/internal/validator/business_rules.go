@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/expression"
+	"ms1-documents/pkg/errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	longitudMaximaNombreRegla  = 50
+	longitudMaximaMensajeRegla = 200
+
+	// prefijoReglaNegocio distingue en el registro las reglas de un emisor de las reglas base
+	prefijoReglaNegocio = "negocio:"
+)
+
+var (
+	nombreReglaRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	tiposPorAmbito = map[string]reflect.Type{
+		domain.AmbitoReglaDocumento: reflect.TypeOf(domain.Document{}),
+		domain.AmbitoReglaItem:      reflect.TypeOf(domain.Item{}),
+	}
+)
+
+// ValidarReglaNegocio normaliza la regla de un emisor y comprueba que su condición y expresión compilen
+// sobre los campos de su ámbito
+func (v *DocumentValidator) ValidarReglaNegocio(regla *domain.ReglaNegocio) error {
+	regla.Nombre = strings.ToLower(strings.TrimSpace(regla.Nombre))
+	if !nombreReglaRegex.MatchString(regla.Nombre) || len(regla.Nombre) > longitudMaximaNombreRegla {
+		return errors.ErrorValidacion(fmt.Sprintf("nombre debe tener hasta %d letras minúsculas, números y guiones (por ejemplo monto-maximo)", longitudMaximaNombreRegla))
+	}
+
+	regla.Ambito = strings.ToUpper(strings.TrimSpace(regla.Ambito))
+	if regla.Ambito == "" {
+		regla.Ambito = domain.AmbitoReglaDocumento
+	}
+	tipo, ok := tiposPorAmbito[regla.Ambito]
+	if !ok {
+		return errors.ErrorValidacion(fmt.Sprintf("ambito inválido. Debe ser %s o %s", domain.AmbitoReglaDocumento, domain.AmbitoReglaItem))
+	}
+
+	regla.Campo = strings.TrimSpace(regla.Campo)
+	if regla.Campo != "" && !expression.ExisteCampo(tipo, regla.Campo) {
+		return errors.ErrorValidacion(fmt.Sprintf("campo %s no existe en el %s o no se puede usar en expresiones", regla.Campo, strings.ToLower(regla.Ambito)))
+	}
+
+	regla.Mensaje = strings.TrimSpace(regla.Mensaje)
+	if regla.Mensaje == "" || utf8.RuneCountInString(regla.Mensaje) > longitudMaximaMensajeRegla {
+		return errors.ErrorValidacion(fmt.Sprintf("mensaje es obligatorio y puede tener hasta %d caracteres", longitudMaximaMensajeRegla))
+	}
+
+	regla.Estado = strings.ToUpper(strings.TrimSpace(regla.Estado))
+	switch regla.Estado {
+	case "":
+		regla.Estado = domain.EstadoReglaActiva
+	case domain.EstadoReglaActiva, domain.EstadoReglaInactiva:
+	default:
+		return errors.ErrorValidacion(fmt.Sprintf("estado inválido. Debe ser %s o %s", domain.EstadoReglaActiva, domain.EstadoReglaInactiva))
+	}
+
+	regla.Condicion = strings.TrimSpace(regla.Condicion)
+	regla.Expresion = strings.TrimSpace(regla.Expresion)
+	_, err := NuevaReglaNegocio(*regla)
+	return err
+}
+
+// reglaNegocio aplica la regla de un emisor al documento o a cada item según su ámbito
+type reglaNegocio struct {
+	definicion domain.ReglaNegocio
+	condicion  *expression.Expresion
+	expresion  *expression.Expresion
+}
+
+// NuevaReglaNegocio compila la regla de un emisor para pasarla a ValidarDocumento
+func NuevaReglaNegocio(definicion domain.ReglaNegocio) (Regla, error) {
+	tipo, ok := tiposPorAmbito[definicion.Ambito]
+	if !ok {
+		return nil, errors.ErrorValidacion(fmt.Sprintf("ambito inválido. Debe ser %s o %s", domain.AmbitoReglaDocumento, domain.AmbitoReglaItem))
+	}
+
+	regla := &reglaNegocio{definicion: definicion}
+	if definicion.Condicion != "" {
+		condicion, err := expression.Compilar(definicion.Condicion, tipo)
+		if err != nil {
+			return nil, errors.ErrorValidacion(fmt.Sprintf("condicion inválida en la %v", err))
+		}
+		regla.condicion = condicion
+	}
+
+	expresion, err := expression.Compilar(definicion.Expresion, tipo)
+	if err != nil {
+		return nil, errors.ErrorValidacion(fmt.Sprintf("expresion inválida en la %v", err))
+	}
+	regla.expresion = expresion
+	return regla, nil
+}
+
+func (r *reglaNegocio) Nombre() string {
+	return prefijoReglaNegocio + r.definicion.Nombre
+}
+
+func (r *reglaNegocio) Validar(_ context.Context, doc *domain.Document) error {
+	if r.definicion.Ambito == domain.AmbitoReglaDocumento {
+		if r.incumple(doc) {
+			return violacionEn(r.ruta(""), CodigoReglaNegocio, r.mensaje("", "el documento"))
+		}
+		return nil
+	}
+
+	var errores violaciones
+	for indice := range doc.Items {
+		if r.incumple(&doc.Items[indice]) {
+			errores.agregar(violacionEn(r.ruta(fmt.Sprintf("/items/%d", indice)), CodigoReglaNegocio,
+				r.mensaje(fmt.Sprintf(" del item %d", indice), fmt.Sprintf("el item %d", indice))))
+		}
+	}
+	return errores.error()
+}
+
+func (r *reglaNegocio) incumple(objeto any) bool {
+	if r.condicion != nil && !r.condicion.Evaluar(objeto) {
+		return false
+	}
+	return !r.expresion.Evaluar(objeto)
+}
+
+func (r *reglaNegocio) ruta(base string) string {
+	if r.definicion.Campo == "" {
+		return base
+	}
+	return base + "/" + strings.ReplaceAll(r.definicion.Campo, ".", "/")
+}
+
+// mensaje empieza con el campo, como los de las reglas base
+func (r *reglaNegocio) mensaje(sufijoCampo, sinCampo string) string {
+	sujeto := sinCampo
+	if r.definicion.Campo != "" {
+		sujeto = r.definicion.Campo + sufijoCampo
+	}
+	return fmt.Sprintf("%s incumple la regla %s: %s", sujeto, r.definicion.Nombre, r.definicion.Mensaje)
+}
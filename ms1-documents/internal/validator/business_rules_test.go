@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func TestReglaNegocio_Validar(t *testing.T) {
+	testCases := []struct {
+		name      string
+		regla     domain.ReglaNegocio
+		modificar func(doc *domain.Document)
+		ruta      string
+		mensaje   string
+	}{
+		{
+			"Monto máximo excedido",
+			domain.ReglaNegocio{RucEmisor: "20123456786", Nombre: "monto-maximo", Ambito: domain.AmbitoReglaDocumento, Expresion: "montoTotal <= 100", Campo: "montoTotal", Mensaje: "mensaje de prueba"},
+			func(doc *domain.Document) {},
+			"/montoTotal",
+			"montoTotal incumple la regla monto-maximo: mensaje de prueba",
+		},
+		{
+			"Orden de compra exigida al receptor",
+			domain.ReglaNegocio{RucEmisor: "20123456786", Nombre: "orden-compra", Ambito: domain.AmbitoReglaDocumento, Condicion: `rucReceptor en ["20987654326"]`, Expresion: `ordenCompra != ""`, Campo: "ordenCompra", Mensaje: "mensaje de prueba"},
+			func(doc *domain.Document) {},
+			"/ordenCompra",
+			"ordenCompra incumple la regla orden-compra",
+		},
+		{
+			"Palabra prohibida en un item",
+			domain.ReglaNegocio{RucEmisor: "20123456786", Nombre: "palabras-prohibidas", Ambito: domain.AmbitoReglaItem, Expresion: `!(descripcion contiene ["tabaco", "licor"])`, Campo: "descripcion", Mensaje: "mensaje de prueba"},
+			func(doc *domain.Document) { doc.Items[0].Descripcion = "Licor de anís" },
+			"/items/0/descripcion",
+			"descripcion del item 0 incumple la regla palabras-prohibidas",
+		},
+		{
+			"Regla sin campo",
+			domain.ReglaNegocio{RucEmisor: "20123456786", Nombre: "solo-soles", Ambito: domain.AmbitoReglaDocumento, Expresion: `moneda == "PEN"`, Mensaje: "mensaje de prueba"},
+			func(doc *domain.Document) { doc.Moneda = "USD"; doc.TipoCambio = money.MustParse("3.712") },
+			"",
+			"el documento incumple la regla solo-soles",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			regla, err := NuevaReglaNegocio(tc.regla)
+			if err != nil {
+				t.Fatalf("Expected rule to compile, got: %v", err)
+			}
+
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			tc.modificar(doc)
+			err = NewDocumentValidator().ValidarDocumento(context.Background(), doc, regla)
+
+			appErr, ok := err.(*errors.AppError)
+			if !ok || len(appErr.Errors) != 1 {
+				t.Fatalf("Expected one violation, got: %v", err)
+			}
+
+			violacion := appErr.Errors[0]
+			if violacion.Path != tc.ruta || violacion.Code != CodigoReglaNegocio || !strings.HasPrefix(violacion.Message, tc.mensaje) {
+				t.Errorf("Expected %q %s %q, got %q %s %q", tc.ruta, CodigoReglaNegocio, tc.mensaje, violacion.Path, violacion.Code, violacion.Message)
+			}
+		})
+	}
+}
+
+func TestReglaNegocio_Cumplida(t *testing.T) {
+	reglas := []domain.ReglaNegocio{
+		{RucEmisor: "20123456786", Nombre: "monto-maximo", Ambito: domain.AmbitoReglaDocumento, Expresion: "montoTotal <= 50000", Campo: "montoTotal", Mensaje: "mensaje de prueba"},
+		{RucEmisor: "20123456786", Nombre: "orden-compra", Ambito: domain.AmbitoReglaDocumento, Condicion: `rucReceptor en ["20100070970"]`, Expresion: `ordenCompra != ""`, Campo: "ordenCompra", Mensaje: "mensaje de prueba"},
+		{RucEmisor: "20123456786", Nombre: "palabras-prohibidas", Ambito: domain.AmbitoReglaItem, Expresion: `!(descripcion contiene "tabaco")`, Campo: "descripcion", Mensaje: "mensaje de prueba"},
+	}
+
+	var compiladas []Regla
+	for _, definicion := range reglas {
+		regla, err := NuevaReglaNegocio(definicion)
+		if err != nil {
+			t.Fatalf("Expected %s to compile, got: %v", definicion.Nombre, err)
+		}
+		compiladas = append(compiladas, regla)
+	}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), documentoBase(domain.TipoFactura, "F001-00000001"), compiladas...); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+}
+
+func TestValidarReglaNegocio(t *testing.T) {
+	regla := domain.ReglaNegocio{RucEmisor: "20123456786", Nombre: " Monto-Maximo ", Ambito: " documento ", Expresion: " montoTotal <= 50000 ", Campo: "montoTotal", Mensaje: "mensaje de prueba"}
+	if err := NewDocumentValidator().ValidarReglaNegocio(&regla); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if regla.Nombre != "monto-maximo" || regla.Ambito != domain.AmbitoReglaDocumento || regla.Estado != domain.EstadoReglaActiva || regla.Expresion != "montoTotal <= 50000" {
+		t.Errorf("Expected normalized rule, got %+v", regla)
+	}
+}
+
+func TestValidarReglaNegocio_Errores(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(regla *domain.ReglaNegocio)
+		mensaje   string
+	}{
+		{"Nombre con espacios", func(r *domain.ReglaNegocio) { r.Nombre = "monto maximo" }, "nombre"},
+		{"Ámbito desconocido", func(r *domain.ReglaNegocio) { r.Ambito = "CLIENTE" }, "ambito"},
+		{"Campo inexistente", func(r *domain.ReglaNegocio) { r.Campo = "importe" }, "campo importe"},
+		{"Campo de documento en ámbito item", func(r *domain.ReglaNegocio) { r.Ambito = domain.AmbitoReglaItem }, "campo montoTotal"},
+		{"Sin mensaje", func(r *domain.ReglaNegocio) { r.Mensaje = " " }, "mensaje"},
+		{"Estado desconocido", func(r *domain.ReglaNegocio) { r.Estado = "PAUSADA" }, "estado"},
+		{"Expresión vacía", func(r *domain.ReglaNegocio) { r.Expresion = "" }, "expresion inválida en la posición 1"},
+		{"Expresión con campo desconocido", func(r *domain.ReglaNegocio) { r.Expresion = "importe > 10" }, "expresion inválida en la posición 1"},
+		{"Condición que no es lógica", func(r *domain.ReglaNegocio) { r.Condicion = "rucReceptor" }, "condicion inválida"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			regla := domain.ReglaNegocio{RucEmisor: "20123456786", Nombre: "monto-maximo", Ambito: domain.AmbitoReglaDocumento, Expresion: "montoTotal <= 50000", Campo: "montoTotal", Mensaje: "mensaje de prueba"}
+			tc.modificar(&regla)
+
+			err := NewDocumentValidator().ValidarReglaNegocio(&regla)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error starting with %q, got: %v", tc.mensaje, err)
+			}
+		})
+	}
+}
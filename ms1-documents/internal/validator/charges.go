@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strconv"
+)
+
+var factorMaximo = money.NewFromInt(1)
+
+// validarCargosDescuentos comprueba el motivo del catálogo 53 según el nivel (item o global),
+// el factor y los importes, y deriva EsCargo y AfectaBase del código. rutaBase es el JSON pointer del
+// item que contiene la lista, o vacío para los globales.
+func (v *DocumentValidator) validarCargosDescuentos(lista []domain.CargoDescuento, global bool, prefijo, rutaBase string) error {
+	var errores violaciones
+	for indice := range lista {
+		errores.agregar(v.validarCargoDescuento(&lista[indice], global, fmt.Sprintf("%scargosDescuentos %d", prefijo, indice), rutaCargoDescuento(rutaBase, indice)))
+	}
+	return errores.error()
+}
+
+func (v *DocumentValidator) validarCargoDescuento(cargoDescuento *domain.CargoDescuento, global bool, campo, ruta string) error {
+	esCargo, afectaBase, esGlobal, ok := domain.MotivoCargoDescuento(cargoDescuento.Codigo)
+	if !ok {
+		return violacionEn(ruta+"/codigo", CodigoFueraDeCatalogo, fmt.Sprintf("codigo de %s no existe en el catálogo 53", campo))
+	}
+	if esGlobal != global {
+		nivel := "de item"
+		if global {
+			nivel = "global"
+		}
+		return violacionEn(ruta+"/codigo", CodigoNoPermitido, fmt.Sprintf("codigo %s de %s no es un motivo %s", cargoDescuento.Codigo, campo, nivel))
+	}
+	cargoDescuento.EsCargo = esCargo
+	cargoDescuento.AfectaBase = afectaBase
+
+	var errores violaciones
+	if cargoDescuento.Factor.EsNegativo() || cargoDescuento.Factor.Comparar(factorMaximo) > 0 {
+		errores.agregar(violacionEn(ruta+"/factor", CodigoFueraDeRango, fmt.Sprintf("factor de %s debe estar entre 0 y 1", campo)))
+	}
+
+	if cargoDescuento.Factor.EsCero() && !cargoDescuento.Monto.EsPositivo() {
+		errores.agregar(violacionEn(ruta+"/monto", CodigoFueraDeRango, fmt.Sprintf("monto de %s debe ser positivo cuando no se indica factor", campo)))
+	}
+
+	for _, monto := range []struct {
+		valor  money.Money
+		nombre string
+	}{
+		{cargoDescuento.MontoBase, "montoBase"},
+		{cargoDescuento.Monto, "monto"},
+	} {
+		nombre := fmt.Sprintf("%s de %s", monto.nombre, campo)
+		if !errores.agregar(validarMontoNoNegativo(monto.valor, ruta+"/"+monto.nombre, nombre)) {
+			errores.agregar(v.validarPrecisionMonto(monto.valor, ruta+"/"+monto.nombre, nombre))
+		}
+	}
+
+	return errores.error()
+}
+
+// completarCargosDescuentos calcula montoBase y monto de los cargos o descuentos expresados como
+// porcentaje cuando el cliente no los envía. La base por defecto es precioUnitario × cantidad en
+// los items y la suma de precioTotal de los items onerosos en los globales.
+func (v *DocumentValidator) completarCargosDescuentos(doc *domain.Document) {
+	baseGlobal := money.Cero()
+
+	for indice := range doc.Items {
+		item := &doc.Items[indice]
+		v.completarMontosPorFactor(item.CargosDescuentos, v.valorBruto(*item))
+
+		if _, gratuito, _ := domain.CategoriaAfectacion(item.TipoAfectacionIgv); !gratuito {
+			baseGlobal = baseGlobal.Sumar(item.PrecioTotal)
+		}
+	}
+
+	v.completarMontosPorFactor(doc.CargosDescuentos, baseGlobal)
+}
+
+func (v *DocumentValidator) completarMontosPorFactor(lista []domain.CargoDescuento, base money.Money) {
+	for indice := range lista {
+		cargoDescuento := &lista[indice]
+		if cargoDescuento.Factor.EsCero() {
+			continue
+		}
+		if cargoDescuento.MontoBase.EsCero() {
+			cargoDescuento.MontoBase = base
+		}
+		if cargoDescuento.Monto.EsCero() {
+			cargoDescuento.Monto = v.montoPorFactor(*cargoDescuento)
+		}
+	}
+}
+
+// validarMontosPorFactor exige que monto = montoBase × factor en los cargos o descuentos porcentuales
+func (v *DocumentValidator) validarMontosPorFactor(lista []domain.CargoDescuento, prefijo, rutaBase string) error {
+	var errores violaciones
+	for indice, cargoDescuento := range lista {
+		if cargoDescuento.Factor.EsCero() {
+			continue
+		}
+		esperado := v.montoPorFactor(cargoDescuento)
+		if !cargoDescuento.Monto.Igual(esperado) {
+			errores.agregar(errorDescuadre(rutaCargoDescuento(rutaBase, indice)+"/monto", fmt.Sprintf("monto de %scargosDescuentos %d", prefijo, indice), "montoBase × factor", esperado, cargoDescuento.Monto))
+		}
+	}
+	return errores.error()
+}
+
+// rutaCargoDescuento es el JSON pointer de un cargo o descuento de la lista que está en rutaBase
+func rutaCargoDescuento(rutaBase string, indice int) string {
+	return rutaBase + "/cargosDescuentos/" + strconv.Itoa(indice)
+}
+
+func (v *DocumentValidator) montoPorFactor(cargoDescuento domain.CargoDescuento) money.Money {
+	return v.multiplicar(cargoDescuento.MontoBase, cargoDescuento.Factor)
+}
+
+func (v *DocumentValidator) valorBruto(item domain.Item) money.Money {
+	return v.multiplicar(item.PrecioUnitario, item.Cantidad)
+}
+
+// ajusteBase devuelve cargos menos descuentos que afectan la base imponible
+func ajusteBase(lista []domain.CargoDescuento) money.Money {
+	ajuste := money.Cero()
+	for _, cargoDescuento := range lista {
+		esCargo, afectaBase, _, _ := domain.MotivoCargoDescuento(cargoDescuento.Codigo)
+		if !afectaBase {
+			continue
+		}
+		if esCargo {
+			ajuste = ajuste.Sumar(cargoDescuento.Monto)
+		} else {
+			ajuste = ajuste.Restar(cargoDescuento.Monto)
+		}
+	}
+	return ajuste
+}
@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"testing"
+)
+
+func TestCargosDescuentos_DescuentoItemPorcentual(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Items[0].CargosDescuentos = []domain.CargoDescuento{{Codigo: "00", Factor: money.MustParse("0.10")}}
+	doc.Items[0].PrecioTotal = money.MustParse("90.00")
+	doc.Items[0].IgvTotal = money.MustParse("16.20")
+	doc.MontoTotalSinImpuestos = money.MustParse("90.00")
+	doc.IgvTotal = money.MustParse("16.20")
+	doc.MontoTotal = money.MustParse("106.20")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	descuento := doc.Items[0].CargosDescuentos[0]
+	if descuento.Monto.String() != "10.00" || descuento.MontoBase.String() != "100.00" {
+		t.Errorf("Expected monto 10.00 over base 100.00, got %s over %s", descuento.Monto, descuento.MontoBase)
+	}
+
+	if descuento.EsCargo || !descuento.AfectaBase {
+		t.Errorf("Expected discount affecting base, got esCargo %v afectaBase %v", descuento.EsCargo, descuento.AfectaBase)
+	}
+
+	if doc.TotalDescuentos.String() != "10.00" {
+		t.Errorf("Expected totalDescuentos 10.00, got %s", doc.TotalDescuentos)
+	}
+}
+
+func TestCargosDescuentos_DescuentoGlobalAfectaBase(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "02", Factor: money.MustParse("0.05")}}
+	doc.MontoTotalSinImpuestos = money.MustParse("95.00")
+	doc.IgvTotal = money.MustParse("17.10")
+	doc.MontoTotal = money.MustParse("112.10")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.TotalGravado.String() != "95.00" {
+		t.Errorf("Expected totalGravado 95.00, got %s", doc.TotalGravado)
+	}
+}
+
+func TestCargosDescuentos_GlobalesNoAfectanBase(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.CargosDescuentos = []domain.CargoDescuento{
+		{Codigo: "03", Monto: money.MustParse("10.00")},
+		{Codigo: "46", Monto: money.MustParse("5.00")},
+	}
+	doc.MontoTotal = money.MustParse("113.00")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.TotalDescuentos.String() != "10.00" || doc.TotalCargos.String() != "5.00" {
+		t.Errorf("Expected totalDescuentos 10.00 and totalCargos 5.00, got %s and %s", doc.TotalDescuentos, doc.TotalCargos)
+	}
+
+	doc.MontoTotal = money.MustParse("118.00")
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err == nil {
+		t.Error("Expected error when montoTotal ignores charges and discounts")
+	}
+}
+
+func TestCargosDescuentos_Invalidos(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+	}{
+		{"Código fuera del catálogo 53", func(doc *domain.Document) {
+			doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "99", Monto: money.MustParse("1.00")}}
+		}},
+		{"Motivo global en item", func(doc *domain.Document) {
+			doc.Items[0].CargosDescuentos = []domain.CargoDescuento{{Codigo: "02", Monto: money.MustParse("1.00")}}
+		}},
+		{"Motivo de item en global", func(doc *domain.Document) {
+			doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "00", Monto: money.MustParse("1.00")}}
+		}},
+		{"Factor mayor a 1", func(doc *domain.Document) {
+			doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "03", Factor: money.MustParse("1.5")}}
+		}},
+		{"Sin factor ni monto", func(doc *domain.Document) {
+			doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "03"}}
+		}},
+		{"Monto distinto de montoBase × factor", func(doc *domain.Document) {
+			doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "03", Factor: money.MustParse("0.10"), MontoBase: money.MustParse("100.00"), Monto: money.MustParse("9.00")}}
+			doc.MontoTotal = money.MustParse("109.00")
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			tc.modificar(doc)
+
+			if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/exchange"
+	"ms1-documents/pkg/money"
+	"strings"
+	"time"
+)
+
+func ConProveedorTipoCambio(proveedor exchange.ProveedorTipoCambio) Opcion {
+	return func(v *DocumentValidator) {
+		v.tiposCambio = proveedor
+	}
+}
+
+// validarMoneda normaliza la moneda y resuelve el tipo de cambio. En moneda extranjera, si el
+// cliente omite tipoCambio se usa el registrado para la fecha de emisión; los documentos en soles no
+// guardan tipo de cambio. Solo la falta de cotización es un error del documento; un fallo del
+// proveedor se devuelve tal cual.
+func (v *DocumentValidator) validarMoneda(contexto context.Context, doc *domain.Document) error {
+	doc.Moneda = strings.ToUpper(strings.TrimSpace(doc.Moneda))
+	if doc.Moneda == "" {
+		doc.Moneda = domain.MonedaPEN
+	}
+	if !domain.EsMonedaValida(doc.Moneda) {
+		return violacionEn("/moneda", CodigoFueraDeCatalogo, fmt.Sprintf("moneda inválida. Debe ser %s, %s o %s", domain.MonedaPEN, domain.MonedaUSD, domain.MonedaEUR))
+	}
+
+	if doc.Moneda == domain.MonedaPEN {
+		if !doc.TipoCambio.EsCero() && !doc.TipoCambio.Igual(money.NewFromInt(1)) {
+			return violacionEn("/tipoCambio", CodigoNoPermitido, "tipoCambio solo aplica a documentos en moneda extranjera")
+		}
+		doc.TipoCambio = money.Money{}
+		return nil
+	}
+
+	if !doc.TipoCambio.EsCero() {
+		return validarMontoPositivo(doc.TipoCambio, "/tipoCambio", "tipoCambio")
+	}
+
+	fechaEmision, _ := time.Parse(time.RFC3339, doc.FechaEmision)
+	tipoCambio, err := v.tiposCambio.TipoCambio(contexto, doc.Moneda, fechaEmision)
+	if errors.Is(err, exchange.ErrSinTipoCambio) {
+		return violacionEn("/tipoCambio", CodigoSinDatosVigentes, err.Error()+"; envíe tipoCambio en el documento")
+	}
+	if err != nil {
+		return err
+	}
+	doc.TipoCambio = tipoCambio
+	return nil
+}
+
+// tablaTiposCambioVacia obliga a enviar tipoCambio en moneda extranjera mientras no se configure una tabla
+func tablaTiposCambioVacia() *exchange.TablaTiposCambio {
+	tabla, _ := exchange.NewTablaTiposCambio(nil)
+	return tabla
+}
@@ -0,0 +1,147 @@
+package validator
+
+import (
+	"context"
+	stderrors "errors"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/exchange"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMoneda_SolesPorDefecto(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.TipoCambio = money.NewFromInt(1)
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if doc.Moneda != domain.MonedaPEN || !doc.TipoCambio.EsCero() {
+		t.Errorf("Expected PEN without exchange rate, got %s %s", doc.Moneda, doc.TipoCambio)
+	}
+}
+
+func TestMoneda_TipoCambioDeLaTabla(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Moneda = "usd"
+	doc.FechaEmision = "2026-02-15T10:00:00-05:00"
+	tabla, err := exchange.NewTablaTiposCambio([]exchange.TipoCambioDiario{
+		{Fecha: "2026-02-13", Moneda: domain.MonedaUSD, TipoCambio: money.MustParse("3.715")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if err := NewDocumentValidator(ConProveedorTipoCambio(tabla)).ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if doc.Moneda != domain.MonedaUSD || !doc.TipoCambio.Igual(money.MustParse("3.715")) {
+		t.Errorf("Expected USD at 3.715, got %s at %s", doc.Moneda, doc.TipoCambio)
+	}
+}
+
+func TestMoneda_TipoCambioDelCliente(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Moneda = domain.MonedaEUR
+	doc.TipoCambio = money.MustParse("4.05")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !doc.TipoCambio.Igual(money.MustParse("4.05")) {
+		t.Errorf("Expected client exchange rate 4.05, got %s", doc.TipoCambio)
+	}
+}
+
+func TestMoneda_Invalida(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+		mensaje   string
+	}{
+		{"Moneda no admitida", func(doc *domain.Document) { doc.Moneda = "GBP" }, "moneda inválida"},
+		{"Tipo de cambio en soles", func(doc *domain.Document) { doc.TipoCambio = money.MustParse("3.7") }, "tipoCambio solo aplica"},
+		{"Tipo de cambio negativo", func(doc *domain.Document) {
+			doc.Moneda = domain.MonedaUSD
+			doc.TipoCambio = money.MustParse("-3.7")
+		}, "tipoCambio debe ser positivo"},
+		{"Sin tipo de cambio registrado", func(doc *domain.Document) {
+			doc.Moneda = domain.MonedaUSD
+			doc.FechaEmision = "2026-03-01T10:00:00Z"
+		}, "envíe tipoCambio"},
+	}
+
+	tabla, err := exchange.NewTablaTiposCambio([]exchange.TipoCambioDiario{
+		{Fecha: "2026-02-13", Moneda: domain.MonedaUSD, TipoCambio: money.MustParse("3.715")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	validador := NewDocumentValidator(ConProveedorTipoCambio(tabla))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			tc.modificar(doc)
+
+			err := validador.ValidarDocumento(context.Background(), doc)
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got: %v", tc.mensaje, err)
+			}
+		})
+	}
+}
+
+type proveedorTipoCambioFunc func(contexto context.Context, moneda string, fecha time.Time) (money.Money, error)
+
+func (f proveedorTipoCambioFunc) TipoCambio(contexto context.Context, moneda string, fecha time.Time) (money.Money, error) {
+	return f(contexto, moneda, fecha)
+}
+
+func TestMoneda_FallaDelProveedor(t *testing.T) {
+	caida := errors.ErrorInterno("Error al consultar el tipo de cambio en la base de datos")
+	testCases := []struct {
+		name      string
+		contexto  func() context.Context
+		proveedor proveedorTipoCambioFunc
+		esperado  func(err error) bool
+	}{
+		{
+			"Base de datos caída",
+			context.Background,
+			func(contexto context.Context, moneda string, fecha time.Time) (money.Money, error) {
+				return money.Money{}, caida
+			},
+			func(err error) bool { return err == caida },
+		},
+		{
+			"Solicitud cancelada",
+			func() context.Context {
+				contexto, cancel := context.WithCancel(context.Background())
+				cancel()
+				return contexto
+			},
+			func(contexto context.Context, moneda string, fecha time.Time) (money.Money, error) {
+				return money.Money{}, contexto.Err()
+			},
+			func(err error) bool { return stderrors.Is(err, context.Canceled) },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Moneda = domain.MonedaUSD
+
+			err := NewDocumentValidator(ConProveedorTipoCambio(tc.proveedor)).ValidarDocumento(tc.contexto(), doc)
+			if !tc.esperado(err) {
+				t.Errorf("Expected the provider error unchanged, got: %v", err)
+			}
+			if appErr, ok := err.(*errors.AppError); ok && len(appErr.Errors) > 0 {
+				t.Errorf("Expected no field errors, got %+v", appErr.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"ms1-documents/internal/domain"
+	"net/mail"
+	"strings"
+)
+
+// validarDatosReceptor normaliza el nombre, la dirección y el correo del receptor. Son opcionales, pero
+// para guardar el receptor en la libreta de clientes se necesita al menos su nombre y un documento de identidad.
+func (v *DocumentValidator) validarDatosReceptor(doc *domain.Document) error {
+	if doc.Receptor != nil {
+		doc.Receptor.Nombre = strings.Join(strings.Fields(doc.Receptor.Nombre), " ")
+		doc.Receptor.Direccion = strings.TrimSpace(doc.Receptor.Direccion)
+		doc.Receptor.Email = strings.TrimSpace(doc.Receptor.Email)
+
+		if doc.Receptor.Nombre == "" {
+			return violacionEn("/receptor/nombre", CodigoRequerido, "receptor.nombre es obligatorio cuando se envían los datos del receptor")
+		}
+
+		if doc.Receptor.Email != "" {
+			direccion, err := mail.ParseAddress(doc.Receptor.Email)
+			if err != nil || direccion.Address != doc.Receptor.Email {
+				return violacionEn("/receptor/email", CodigoFormatoInvalido, "receptor.email no es un correo válido")
+			}
+		}
+	}
+
+	if !doc.GuardarCliente {
+		return nil
+	}
+	if doc.Receptor == nil {
+		return violacionEn("/receptor/nombre", CodigoRequerido, "receptor.nombre es obligatorio para guardar el cliente")
+	}
+	if doc.TipoDocumentoReceptor == domain.IdentidadSinDocumento {
+		return violacionEn("/guardarCliente", CodigoNoPermitido, "guardarCliente no aplica a receptores sin documento de identidad")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestDatosReceptor_Normaliza(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Receptor = &domain.DatosReceptor{Nombre: "  CLIENTE   DEMO S.A. ", Email: " compras@cliente.pe "}
+	doc.GuardarCliente = true
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.Receptor.Nombre != "CLIENTE DEMO S.A." {
+		t.Errorf("Expected nombre CLIENTE DEMO S.A., got %q", doc.Receptor.Nombre)
+	}
+
+	if doc.Receptor.Email != "compras@cliente.pe" {
+		t.Errorf("Expected email compras@cliente.pe, got %q", doc.Receptor.Email)
+	}
+}
+
+func TestDatosReceptor_Invalidos(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+		mensaje   string
+	}{
+		{"Sin nombre", func(doc *domain.Document) { doc.Receptor = &domain.DatosReceptor{Direccion: "Jr. Lampa 456"} }, "receptor.nombre es obligatorio"},
+		{"Correo inválido", func(doc *domain.Document) { doc.Receptor = &domain.DatosReceptor{Nombre: "CLIENTE", Email: "compras"} }, "receptor.email"},
+		{"Correo con nombre", func(doc *domain.Document) {
+			doc.Receptor = &domain.DatosReceptor{Nombre: "CLIENTE", Email: "Compras <compras@cliente.pe>"}
+		}, "receptor.email"},
+		{"Guardar sin receptor", func(doc *domain.Document) { doc.GuardarCliente = true }, "para guardar el cliente"},
+		{"Guardar sin documento", func(doc *domain.Document) {
+			doc.IDDocumento = "B001-00000001"
+			doc.TipoDocumento = domain.TipoBoleta
+			doc.TipoDocumentoReceptor = domain.IdentidadSinDocumento
+			doc.RucReceptor = ""
+			doc.Receptor = &domain.DatosReceptor{Nombre: "CLIENTE"}
+			doc.GuardarCliente = true
+		}, "guardarCliente no aplica"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			tc.modificar(doc)
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got %q", tc.mensaje, err.Error())
+			}
+		})
+	}
+}
@@ -2,110 +2,365 @@ package validator
 
 import (
 	"fmt"
+	"ms1-documents/internal/catalog"
 	"ms1-documents/internal/domain"
-	"ms1-documents/pkg/errors"
+	"ms1-documents/internal/exchange"
+	"ms1-documents/internal/tax"
+	"ms1-documents/pkg/money"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 var (
-	idDocumentoRegex = regexp.MustCompile(`^[A-Z]{4}-[0-9]{9}$`)
-	rucRegex         = regexp.MustCompile(`^[0-9]{11}$`)
+	serieFacturaRegex = regexp.MustCompile(`^F[A-Z0-9]{3}-[0-9]{1,8}$`)
+	serieBoletaRegex  = regexp.MustCompile(`^B[A-Z0-9]{3}-[0-9]{1,8}$`)
+	serieNotaRegex    = regexp.MustCompile(`^[FB][A-Z0-9]{3}-[0-9]{1,8}$`)
+	prefijoFactura    = regexp.MustCompile(`^F[A-Z0-9]{3}$`)
+	prefijoBoleta     = regexp.MustCompile(`^B[A-Z0-9]{3}$`)
+	prefijoNota       = regexp.MustCompile(`^[FB][A-Z0-9]{3}$`)
+	rucRegex          = regexp.MustCompile(`^[0-9]{11}$`)
+	dniRegex          = regexp.MustCompile(`^[0-9]{8}$`)
+	alfanumericoRegex = regexp.MustCompile(`^[A-Z0-9]{1,12}$`)
+
+	prefijosRUC = []string{"10", "15", "17", "20"}
+	pesosRUC    = []int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}
 )
 
-type DocumentValidator struct{}
+// longitudMaximaOrdenCompra es el largo que admite SUNAT en cac:OrderReference/cbc:ID
+const longitudMaximaOrdenCompra = 20
 
-func NewDocumentValidator() *DocumentValidator {
-	return &DocumentValidator{}
+// reglaIdentidad es el formato del número de un tipo de documento de identidad; qué tipos existen y
+// cómo se llaman lo dice el catálogo 06
+type reglaIdentidad struct {
+	formato *regexp.Regexp
+	mensaje string
 }
 
-func (v *DocumentValidator) ValidarDocumento(doc *domain.Document) error {
-	if err := v.validarIDDocumento(doc.IDDocumento); err != nil {
-		return err
+var reglasIdentidad = map[string]reglaIdentidad{
+	domain.IdentidadDNI:               {dniRegex, "debe tener 8 dígitos"},
+	domain.IdentidadCarnetExtranjeria: {alfanumericoRegex, "debe tener hasta 12 caracteres alfanuméricos"},
+	domain.IdentidadPasaporte:         {alfanumericoRegex, "debe tener hasta 12 caracteres alfanuméricos"},
+}
+
+// reglaTipoDocumento es el formato de la serie de un tipo de comprobante; qué tipos existen y cómo se
+// llaman lo dice el catálogo 01
+type reglaTipoDocumento struct {
+	nombre       string
+	serieRegex   *regexp.Regexp
+	formatoSerie string
+	prefijoRegex *regexp.Regexp
+}
+
+var reglasPorTipo = map[string]reglaTipoDocumento{
+	domain.TipoFactura:     {serieRegex: serieFacturaRegex, formatoSerie: "F001-00000001", prefijoRegex: prefijoFactura},
+	domain.TipoBoleta:      {serieRegex: serieBoletaRegex, formatoSerie: "B001-00000001", prefijoRegex: prefijoBoleta},
+	domain.TipoNotaCredito: {serieRegex: serieNotaRegex, formatoSerie: "F001-00000001 o B001-00000001", prefijoRegex: prefijoNota},
+	domain.TipoNotaDebito:  {serieRegex: serieNotaRegex, formatoSerie: "F001-00000001 o B001-00000001", prefijoRegex: prefijoNota},
+}
+
+// reglaDelTipo devuelve el formato de serie del tipo de comprobante con su nombre en el catálogo 01
+func reglaDelTipo(tipoDocumento string) (reglaTipoDocumento, bool) {
+	regla, ok := reglasPorTipo[tipoDocumento]
+	if !ok {
+		return reglaTipoDocumento{}, false
 	}
+	descripcion, _ := catalog.Descripcion(catalog.TiposDocumento, tipoDocumento)
+	regla.nombre = strings.ToLower(descripcion)
+	return regla, true
+}
 
-	if err := v.validarRUC(doc.RucEmisor, "rucEmisor"); err != nil {
-		return err
+// DocumentValidator aplica a cada documento un registro de reglas con nombre: las de SUNAT, las que se
+// agregan con ConRegla y las de cada emisor que recibe ValidarDocumento
+type DocumentValidator struct {
+	modoRedondeo   money.ModoRedondeo
+	modoAritmetico ModoAritmetico
+	tasasIGV       tax.ProveedorTasasIGV
+	tiposCambio    exchange.ProveedorTipoCambio
+	reglas         []reglaRegistrada
+}
+
+// Opcion configura un DocumentValidator al construirlo
+type Opcion func(*DocumentValidator)
+
+func ConModoRedondeo(modo money.ModoRedondeo) Opcion {
+	return func(v *DocumentValidator) {
+		v.modoRedondeo = modo
+	}
+}
+
+func NewDocumentValidator(opciones ...Opcion) *DocumentValidator {
+	validador := &DocumentValidator{
+		modoRedondeo:   money.RedondeoMitadArriba,
+		modoAritmetico: ModoAritmeticoEstricto,
+		tasasIGV:       tax.TablaPorDefecto(),
+		tiposCambio:    tablaTiposCambioVacia(),
 	}
+	validador.registrarReglasBase()
+	for _, opcion := range opciones {
+		opcion(validador)
+	}
+	return validador
+}
 
-	if err := v.validarRUC(doc.RucReceptor, "rucReceptor"); err != nil {
-		return err
+func (v *DocumentValidator) validarTipoDocumento(tipoDocumento string) (reglaTipoDocumento, error) {
+	if !catalog.Existe(catalog.TiposDocumento, tipoDocumento) {
+		return reglaTipoDocumento{}, violacionEn("/tipoDocumento", CodigoFueraDeCatalogo, fmt.Sprintf("tipoDocumento inválido. Debe ser un código del catálogo %s de SUNAT", catalog.TiposDocumento))
 	}
 
-	if err := v.validarMontos(doc); err != nil {
-		return err
+	regla, ok := reglaDelTipo(tipoDocumento)
+	if !ok {
+		return reglaTipoDocumento{}, violacionEn("/tipoDocumento", CodigoNoPermitido, fmt.Sprintf("tipoDocumento %s no se emite en este servicio", tipoDocumento))
+	}
+	return regla, nil
+}
+
+func (v *DocumentValidator) validarIDDocumento(id string, regla reglaTipoDocumento) error {
+	if !regla.serieRegex.MatchString(id) {
+		return violacionEn("/idDocumento", CodigoFormatoInvalido, fmt.Sprintf("formato de idDocumento inválido para %s. Debe ser %s", regla.nombre, regla.formatoSerie))
 	}
+	// SUNAT numera cada serie desde 1: el formato admite ceros pero no un correlativo 0
+	if _, correlativo, _ := strings.Cut(id, "-"); strings.Trim(correlativo, "0") == "" {
+		return violacionEn("/idDocumento", CodigoFueraDeRango, fmt.Sprintf("idDocumento debe tener un correlativo entre 1 y %d", domain.CorrelativoMaximo))
+	}
+	return nil
+}
 
-	if err := v.validarItems(doc.Items); err != nil {
-		return err
+// validarSerie acepta un idDocumento completo o solo la serie cuando el correlativo lo asigna MS1.
+// En ambos casos deja la serie en doc.Serie.
+func (v *DocumentValidator) validarSerie(doc *domain.Document, regla reglaTipoDocumento) error {
+	if doc.IDDocumento == "" {
+		if !regla.prefijoRegex.MatchString(doc.Serie) {
+			return violacionEn("/serie", CodigoRequerido, fmt.Sprintf("debe indicar idDocumento o una serie válida para %s (por ejemplo %s)", regla.nombre, strings.Split(regla.formatoSerie, "-")[0]))
+		}
+		return nil
 	}
 
-	if err := v.validarFechaEmision(doc); err != nil {
+	if err := v.validarIDDocumento(doc.IDDocumento, regla); err != nil {
 		return err
 	}
 
+	serie := strings.Split(doc.IDDocumento, "-")[0]
+	if doc.Serie != "" && doc.Serie != serie {
+		return violacionEn("/serie", CodigoNoCoincide, fmt.Sprintf("serie %s no coincide con la de idDocumento %s", doc.Serie, doc.IDDocumento))
+	}
+	doc.Serie = serie
 	return nil
 }
 
-func (v *DocumentValidator) validarIDDocumento(id string) error {
-	if !idDocumentoRegex.MatchString(id) {
-		return errors.ErrorValidacion("formato de idDocumento inválido. Debe ser ABCD-012345678")
+// validarReceptor aplica las reglas del catálogo 06 según el tipo de documento de identidad.
+// Las facturas y sus notas solo pueden emitirse a un RUC.
+func (v *DocumentValidator) validarReceptor(doc *domain.Document) error {
+	if !esSerieDeBoleta(doc.Serie) && doc.TipoDocumentoReceptor != domain.IdentidadRUC {
+		return violacionEn("/tipoDocumentoReceptor", CodigoNoPermitido, "tipoDocumentoReceptor debe ser 6 (RUC) en facturas y sus notas")
+	}
+
+	switch doc.TipoDocumentoReceptor {
+	case domain.IdentidadRUC:
+		return v.validarRUC(doc.RucReceptor, "/rucReceptor", "rucReceptor")
+	case domain.IdentidadSinDocumento:
+		if doc.RucReceptor != "" && doc.RucReceptor != "-" {
+			return violacionEn("/rucReceptor", CodigoNoPermitido, "rucReceptor debe estar vacío o ser '-' cuando tipoDocumentoReceptor es 0 (sin documento)")
+		}
+		doc.RucReceptor = "-"
+		return nil
+	}
+
+	tipoIdentidad, ok := catalog.Descripcion(catalog.TiposIdentidad, doc.TipoDocumentoReceptor)
+	if !ok {
+		return violacionEn("/tipoDocumentoReceptor", CodigoFueraDeCatalogo, fmt.Sprintf("tipoDocumentoReceptor inválido. Debe ser un código del catálogo %s de SUNAT", catalog.TiposIdentidad))
+	}
+
+	regla, ok := reglasIdentidad[doc.TipoDocumentoReceptor]
+	if !ok {
+		if strings.TrimSpace(doc.RucReceptor) == "" {
+			return violacionEn("/rucReceptor", CodigoRequerido, fmt.Sprintf("rucReceptor es obligatorio para %s", tipoIdentidad))
+		}
+		return nil
+	}
+
+	if !regla.formato.MatchString(doc.RucReceptor) {
+		return violacionEn("/rucReceptor", CodigoFormatoInvalido, fmt.Sprintf("rucReceptor de tipo %s %s", tipoIdentidad, regla.mensaje))
 	}
 	return nil
 }
 
-func (v *DocumentValidator) validarRUC(ruc, nombreCampo string) error {
-	if !rucRegex.MatchString(ruc) {
-		return errors.ErrorValidacion(fmt.Sprintf("%s debe tener 11 dígitos", nombreCampo))
+// validarReferencia exige que las notas apunten a una factura o boleta de su misma serie
+func (v *DocumentValidator) validarReferencia(doc *domain.Document) error {
+	if !domain.EsNota(doc.TipoDocumento) {
+		if doc.Referencia != nil {
+			return violacionEn("/referencia", CodigoNoPermitido, "solo las notas de crédito o débito pueden tener referencia")
+		}
+		return nil
+	}
+
+	if doc.Referencia == nil {
+		return violacionEn("/referencia", CodigoRequerido, "las notas de crédito o débito deben referenciar un documento")
+	}
+
+	tipoEsperado := domain.TipoFactura
+	if esSerieDeBoleta(doc.Serie) {
+		tipoEsperado = domain.TipoBoleta
+	}
+
+	if doc.Referencia.TipoDocumento != tipoEsperado {
+		return violacionEn("/referencia/tipoDocumento", CodigoNoCoincide, fmt.Sprintf("referencia.tipoDocumento debe ser %s para la serie %s", tipoEsperado, doc.Serie))
+	}
+
+	reglaReferencia, _ := reglaDelTipo(tipoEsperado)
+	if err := v.validarIDDocumento(doc.Referencia.IDDocumento, reglaReferencia); err != nil {
+		return violacionEn("/referencia/idDocumento", CodigoFormatoInvalido, "referencia."+err.Error())
+	}
+
+	return v.validarMotivoNota(doc)
+}
+
+// validarOrdenCompra limita el número de la orden de compra al largo que admite SUNAT
+func validarOrdenCompra(doc *domain.Document) error {
+	doc.OrdenCompra = strings.TrimSpace(doc.OrdenCompra)
+	if utf8.RuneCountInString(doc.OrdenCompra) > longitudMaximaOrdenCompra {
+		return violacionEn("/ordenCompra", CodigoFueraDeRango, fmt.Sprintf("ordenCompra no puede tener más de %d caracteres", longitudMaximaOrdenCompra))
 	}
 	return nil
 }
 
-func (v *DocumentValidator) validarMontos(doc *domain.Document) error {
-	if err := validarMontoPositivo(doc.MontoTotalSinImpuestos, "montoTotalSinImpuestos"); err != nil {
-		return err
+// validarMotivoNota exige un código del catálogo 09 en notas de crédito o del 10 en notas de débito;
+// si no hay sustento se usa la descripción del catálogo
+func (v *DocumentValidator) validarMotivoNota(doc *domain.Document) error {
+	motivos := catalog.MotivosNotaCredito
+	if doc.TipoDocumento == domain.TipoNotaDebito {
+		motivos = catalog.MotivosNotaDebito
 	}
 
-	if err := validarMontoPositivo(doc.IgvTotal, "igvTotal"); err != nil {
-		return err
+	doc.Referencia.CodigoMotivo = strings.TrimSpace(doc.Referencia.CodigoMotivo)
+	descripcion, ok := catalog.Descripcion(motivos, doc.Referencia.CodigoMotivo)
+	if !ok {
+		tipo, _ := catalog.Descripcion(catalog.TiposDocumento, doc.TipoDocumento)
+		return violacionEn("/referencia/codigoMotivo", CodigoFueraDeCatalogo, fmt.Sprintf("referencia.codigoMotivo inválido para %s. Debe ser un código del catálogo %s de SUNAT", strings.ToLower(tipo), motivos))
 	}
 
-	if err := validarMontoPositivo(doc.MontoTotal, "montoTotal"); err != nil {
-		return err
+	doc.Referencia.Sustento = strings.TrimSpace(doc.Referencia.Sustento)
+	if doc.Referencia.Sustento == "" {
+		doc.Referencia.Sustento = descripcion
+	}
+	return nil
+}
+
+func (v *DocumentValidator) validarRUC(ruc, ruta, nombreCampo string) error {
+	if !rucRegex.MatchString(ruc) {
+		return violacionEn(ruta, CodigoFormatoInvalido, fmt.Sprintf("%s debe tener 11 dígitos", nombreCampo))
+	}
+
+	if !tienePrefijoRUCValido(ruc) {
+		return violacionEn(ruta, CodigoFormatoInvalido, fmt.Sprintf("%s debe empezar con 10, 15, 17 o 20", nombreCampo))
+	}
+
+	if digitoVerificadorRUC(ruc) != int(ruc[10]-'0') {
+		return violacionEn(ruta, CodigoDigitoVerificador, fmt.Sprintf("%s tiene un dígito verificador inválido", nombreCampo))
 	}
 
 	return nil
 }
 
+func (v *DocumentValidator) validarMontos(doc *domain.Document) error {
+	var errores violaciones
+	montoTotalPositivo := !errores.agregar(validarMontoPositivo(doc.MontoTotal, "/montoTotal", "montoTotal"))
+
+	montos := []struct {
+		valor  money.Money
+		nombre string
+	}{
+		{doc.TotalGravado, "totalGravado"},
+		{doc.TotalExonerado, "totalExonerado"},
+		{doc.TotalInafecto, "totalInafecto"},
+		{doc.TotalGratuito, "totalGratuito"},
+		{doc.MontoTotalSinImpuestos, "montoTotalSinImpuestos"},
+		{doc.IscTotal, "iscTotal"},
+		{doc.IgvTotal, "igvTotal"},
+		{doc.IcbperTotal, "icbperTotal"},
+		{doc.TotalDescuentos, "totalDescuentos"},
+		{doc.TotalCargos, "totalCargos"},
+		{doc.MontoTotal, "montoTotal"},
+	}
+
+	for _, monto := range montos {
+		if monto.nombre == "montoTotal" && !montoTotalPositivo {
+			continue
+		}
+		if !errores.agregar(validarMontoNoNegativo(monto.valor, "/"+monto.nombre, monto.nombre)) {
+			errores.agregar(v.validarPrecisionMonto(monto.valor, "/"+monto.nombre, monto.nombre))
+		}
+	}
+
+	return errores.error()
+}
+
 func (v *DocumentValidator) validarItems(items []domain.Item) error {
 	if len(items) == 0 {
-		return errors.ErrorValidacion("debe haber al menos 1 item")
+		return violacionEn("/items", CodigoRequerido, "debe haber al menos 1 item")
 	}
 
-	for indice, item := range items {
-		if err := v.validarItem(indice, item); err != nil {
-			return err
-		}
+	var errores violaciones
+	for indice := range items {
+		errores.agregar(v.validarItem(indice, &items[indice]))
 	}
 
-	return nil
+	return errores.error()
 }
 
-func (v *DocumentValidator) validarItem(indice int, item domain.Item) error {
-	if err := validarMontoPositivoEnItem(item.PrecioUnitario, "precioUnitario", indice); err != nil {
-		return err
+func (v *DocumentValidator) validarItem(indice int, item *domain.Item) error {
+	var errores violaciones
+	errores.agregar(validarMontoPositivoEnItem(item.PrecioUnitario, "precioUnitario", indice))
+
+	if !item.Cantidad.EsPositivo() {
+		errores.agregar(violacionEn(rutaItem(indice, "cantidad"), CodigoFueraDeRango, fmt.Sprintf("cantidad del item %d debe ser positiva", indice)))
 	}
 
-	if err := validarEnteroPositivoEnItem(item.Cantidad, "cantidad", indice); err != nil {
-		return err
+	errores.agregar(validarProductoItem(indice, item))
+	precioTotalPositivo := !errores.agregar(validarMontoPositivoEnItem(item.PrecioTotal, "precioTotal", indice))
+	errores.agregar(v.validarAfectacionItem(indice, item))
+	errores.agregar(v.validarCargosDescuentos(item.CargosDescuentos, false, fmt.Sprintf("item %d ", indice), "/items/"+strconv.Itoa(indice)))
+
+	montos := []struct {
+		valor  money.Money
+		nombre string
+	}{
+		{item.PrecioTotal, "precioTotal"},
+		{item.IscTotal, "iscTotal"},
+		{item.IgvTotal, "igvTotal"},
+		{item.IcbperTotal, "icbperTotal"},
 	}
 
-	if err := validarMontoPositivoEnItem(item.PrecioTotal, "precioTotal", indice); err != nil {
-		return err
+	for _, monto := range montos {
+		if monto.nombre == "precioTotal" && !precioTotalPositivo {
+			continue
+		}
+		if monto.valor.EsNegativo() {
+			errores.agregar(violacionEn(rutaItem(indice, monto.nombre), CodigoFueraDeRango, fmt.Sprintf("%s del item %d no puede ser negativo", monto.nombre, indice)))
+			continue
+		}
+		errores.agregar(v.validarPrecisionMonto(monto.valor, rutaItem(indice, monto.nombre), fmt.Sprintf("%s del item %d", monto.nombre, indice)))
 	}
 
-	if err := validarMontoPositivoEnItem(item.IgvTotal, "igvTotal", indice); err != nil {
-		return err
+	return errores.error()
+}
+
+// validarAfectacionItem asigna gravado oneroso cuando se omite el código y
+// exige que el ISC solo se declare en operaciones gravadas
+func (v *DocumentValidator) validarAfectacionItem(indice int, item *domain.Item) error {
+	if item.TipoAfectacionIgv == "" {
+		item.TipoAfectacionIgv = domain.AfectacionGravadoOneroso
+	}
+
+	categoria, _, ok := domain.CategoriaAfectacion(item.TipoAfectacionIgv)
+	if !ok {
+		return violacionEn(rutaItem(indice, "tipoAfectacionIgv"), CodigoFueraDeCatalogo, fmt.Sprintf("tipoAfectacionIgv del item %d no existe en el catálogo 07", indice))
+	}
+
+	if categoria != domain.CategoriaGravado && !item.IscTotal.EsCero() {
+		return violacionEn(rutaItem(indice, "iscTotal"), CodigoNoPermitido, fmt.Sprintf("iscTotal del item %d solo aplica a operaciones gravadas", indice))
 	}
 
 	return nil
@@ -113,35 +368,73 @@ func (v *DocumentValidator) validarItem(indice int, item domain.Item) error {
 
 func (v *DocumentValidator) validarFechaEmision(doc *domain.Document) error {
 	if doc.FechaEmision == "" {
-		doc.FechaEmision = time.Now().Format(time.RFC3339)
-		return nil
+		doc.FechaEmision = time.Now().In(domain.ZonaHorariaPeru).Format(time.RFC3339)
 	}
 
-	_, err := time.Parse(time.RFC3339, doc.FechaEmision)
+	emision, err := time.Parse(time.RFC3339, doc.FechaEmision)
 	if err != nil {
-		return errors.ErrorValidacion("fechaEmision debe estar en formato ISO 8601")
+		return violacionEn("/fechaEmision", CodigoFormatoInvalido, "fechaEmision debe estar en formato ISO 8601")
 	}
 
+	doc.FechaEmisionLocal = emision.In(domain.ZonaHorariaPeru).Format("2006-01-02")
+	return nil
+}
+
+// validarPrecisionMonto rechaza importes que cambiarían al redondearlos a DecimalesMonto
+func (v *DocumentValidator) validarPrecisionMonto(valor money.Money, ruta, nombreCampo string) error {
+	if !valor.Redondear(money.DecimalesMonto, v.modoRedondeo).Igual(valor) {
+		return violacionEn(ruta, CodigoDecimalesExcedidos, fmt.Sprintf("%s no puede tener más de %d decimales", nombreCampo, money.DecimalesMonto))
+	}
 	return nil
 }
 
-func validarMontoPositivo(valor float64, nombreCampo string) error {
-	if valor <= 0 {
-		return errors.ErrorValidacion(fmt.Sprintf("%s debe ser positivo", nombreCampo))
+func tienePrefijoRUCValido(ruc string) bool {
+	for _, prefijo := range prefijosRUC {
+		if ruc[:2] == prefijo {
+			return true
+		}
+	}
+	return false
+}
+
+// digitoVerificadorRUC calcula el dígito de control módulo 11 sobre los 10 primeros dígitos del RUC
+func digitoVerificadorRUC(ruc string) int {
+	suma := 0
+	for indice, peso := range pesosRUC {
+		suma += int(ruc[indice]-'0') * peso
+	}
+
+	digito := 11 - suma%11
+	switch digito {
+	case 10:
+		return 0
+	case 11:
+		return 1
+	}
+	return digito
+}
+
+func esSerieDeBoleta(serie string) bool {
+	return len(serie) > 0 && serie[0] == 'B'
+}
+
+func validarMontoPositivo(valor money.Money, ruta, nombreCampo string) error {
+	if !valor.EsPositivo() {
+		return violacionEn(ruta, CodigoFueraDeRango, fmt.Sprintf("%s debe ser positivo", nombreCampo))
 	}
 	return nil
 }
 
-func validarMontoPositivoEnItem(valor float64, nombreCampo string, indice int) error {
-	if valor <= 0 {
-		return errors.ErrorValidacion(fmt.Sprintf("%s del item %d debe ser positivo", nombreCampo, indice))
+func validarMontoNoNegativo(valor money.Money, ruta, nombreCampo string) error {
+	if valor.EsNegativo() {
+		return violacionEn(ruta, CodigoFueraDeRango, fmt.Sprintf("%s no puede ser negativo", nombreCampo))
 	}
 	return nil
 }
 
-func validarEnteroPositivoEnItem(valor int, nombreCampo string, indice int) error {
-	if valor <= 0 {
-		return errors.ErrorValidacion(fmt.Sprintf("%s del item %d debe ser positiva", nombreCampo, indice))
+func validarMontoPositivoEnItem(valor money.Money, nombreCampo string, indice int) error {
+	if !valor.EsPositivo() {
+		return violacionEn(rutaItem(indice, nombreCampo), CodigoFueraDeRango, fmt.Sprintf("%s del item %d debe ser positivo", nombreCampo, indice))
 	}
 	return nil
 }
@@ -1,32 +1,39 @@
 package validator
 
 import (
+	"context"
+	"ms1-documents/internal/catalog"
 	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestDocument_Validate_Success(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
-		RucReceptor:            "20987654321",
-		MontoTotalSinImpuestos: 100.0,
-		IgvTotal:               18.0,
-		MontoTotal:             118.0,
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
 		Items: []domain.Item{
 			{
 				Descripcion:    "Item 1",
-				PrecioUnitario: 50.0,
-				Cantidad:       2,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 		},
 		FechaEmision: time.Now().Format(time.RFC3339),
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -34,25 +41,27 @@ func TestDocument_Validate_Success(t *testing.T) {
 
 func TestDocument_Validate_EmptyFechaEmision(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
-		RucReceptor:            "20987654321",
-		MontoTotalSinImpuestos: 100.0,
-		IgvTotal:               18.0,
-		MontoTotal:             118.0,
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
 		Items: []domain.Item{
 			{
 				Descripcion:    "Item 1",
-				PrecioUnitario: 50.0,
-				Cantidad:       2,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 		},
 		FechaEmision: "",
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err != nil {
 		t.Errorf("Expected no error (fecha should be set automatically), got: %v", err)
 	}
@@ -60,6 +69,33 @@ func TestDocument_Validate_EmptyFechaEmision(t *testing.T) {
 	if doc.FechaEmision == "" {
 		t.Error("Expected FechaEmision to be set automatically")
 	}
+
+	if !strings.HasSuffix(doc.FechaEmision, "-05:00") || doc.FechaEmisionLocal != time.Now().In(domain.ZonaHorariaPeru).Format("2006-01-02") {
+		t.Errorf("Expected FechaEmision in Peru time, got %s (%s)", doc.FechaEmision, doc.FechaEmisionLocal)
+	}
+}
+
+func TestDocument_Validate_FechaEmisionLocal(t *testing.T) {
+	testCases := []struct {
+		fechaEmision string
+		esperado     string
+	}{
+		{"2026-02-12T22:00:00-05:00", "2026-02-12"},
+		{"2026-02-13T03:00:00Z", "2026-02-12"},
+		{"2026-02-13T05:00:00Z", "2026-02-13"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fechaEmision, func(t *testing.T) {
+			doc := &domain.Document{FechaEmision: tc.fechaEmision}
+			if err := NewDocumentValidator().validarFechaEmision(doc); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if doc.FechaEmisionLocal != tc.esperado {
+				t.Errorf("Expected %s, got %s", tc.esperado, doc.FechaEmisionLocal)
+			}
+		})
+	}
 }
 
 func TestDocument_Validate_InvalidIDDocumento(t *testing.T) {
@@ -67,35 +103,40 @@ func TestDocument_Validate_InvalidIDDocumento(t *testing.T) {
 		name        string
 		idDocumento string
 	}{
-		{"Sin guion", "FACT123456789"},
-		{"Menos letras", "FAC-123456789"},
-		{"Menos números", "FACT-12345678"},
-		{"Más números", "FACT-1234567890"},
-		{"Minúsculas", "fact-123456789"},
+		{"Sin guion", "F00100000001"},
+		{"Serie corta", "F01-00000001"},
+		{"Formato anterior", "FACT-123456789"},
+		{"Más números", "F001-000000010"},
+		{"Serie de boleta", "B001-00000001"},
+		{"Minúsculas", "f001-00000001"},
 		{"Vacío", ""},
+		{"Correlativo cero", "F001-0"},
+		{"Correlativo cero con 8 dígitos", "F001-00000000"},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			doc := &domain.Document{
 				IDDocumento:            tc.idDocumento,
-				RucEmisor:              "20123456789",
-				RucReceptor:            "20987654321",
-				MontoTotalSinImpuestos: 100.0,
-				IgvTotal:               18.0,
-				MontoTotal:             118.0,
+				TipoDocumento:          domain.TipoFactura,
+				RucEmisor:              "20123456786",
+				TipoDocumentoReceptor:  domain.IdentidadRUC,
+				RucReceptor:            "20987654326",
+				MontoTotalSinImpuestos: money.MustParse("100.00"),
+				IgvTotal:               money.MustParse("18.00"),
+				MontoTotal:             money.MustParse("118.00"),
 				Items: []domain.Item{
 					{
 						Descripcion:    "Item 1",
-						PrecioUnitario: 50.0,
-						Cantidad:       2,
-						PrecioTotal:    100.0,
-						IgvTotal:       18.0,
+						PrecioUnitario: money.MustParse("50.00"),
+						Cantidad:       money.NewFromInt(2),
+						PrecioTotal:    money.MustParse("100.00"),
+						IgvTotal:       money.MustParse("18.00"),
 					},
 				},
 			}
 
-			err := NewDocumentValidator().ValidarDocumento(doc)
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 			if err == nil {
 				t.Errorf("Expected error for invalid idDocumento: %s", tc.idDocumento)
 			}
@@ -103,6 +144,21 @@ func TestDocument_Validate_InvalidIDDocumento(t *testing.T) {
 	}
 }
 
+func TestDocument_Validate_CorrelativoCero(t *testing.T) {
+	for _, idDocumento := range []string{"F001-0", "F001-00000000"} {
+		err := NewDocumentValidator().ValidarDocumento(context.Background(), documentoBase(domain.TipoFactura, idDocumento))
+
+		appErr, ok := err.(*errors.AppError)
+		if !ok || len(appErr.Errors) != 1 || appErr.Errors[0].Path != "/idDocumento" || appErr.Errors[0].Code != CodigoFueraDeRango {
+			t.Errorf("Expected %s at /idDocumento for %s, got: %v", CodigoFueraDeRango, idDocumento, err)
+		}
+	}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), documentoBase(domain.TipoFactura, "F001-00000010")); err != nil {
+		t.Errorf("Expected zeros inside the correlativo to be accepted, got: %v", err)
+	}
+}
+
 func TestDocument_Validate_InvalidRucEmisor(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -111,30 +167,34 @@ func TestDocument_Validate_InvalidRucEmisor(t *testing.T) {
 		{"Menos dígitos", "2012345678"},
 		{"Más dígitos", "201234567890"},
 		{"Con letras", "2012345678A"},
+		{"Dígito verificador inválido", "20123456789"},
+		{"Prefijo inválido", "30123456781"},
 		{"Vacío", ""},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			doc := &domain.Document{
-				IDDocumento:            "FACT-123456789",
+				IDDocumento:            "F001-00000001",
+				TipoDocumento:          domain.TipoFactura,
 				RucEmisor:              tc.rucEmisor,
-				RucReceptor:            "20987654321",
-				MontoTotalSinImpuestos: 100.0,
-				IgvTotal:               18.0,
-				MontoTotal:             118.0,
+				TipoDocumentoReceptor:  domain.IdentidadRUC,
+				RucReceptor:            "20987654326",
+				MontoTotalSinImpuestos: money.MustParse("100.00"),
+				IgvTotal:               money.MustParse("18.00"),
+				MontoTotal:             money.MustParse("118.00"),
 				Items: []domain.Item{
 					{
 						Descripcion:    "Item 1",
-						PrecioUnitario: 50.0,
-						Cantidad:       2,
-						PrecioTotal:    100.0,
-						IgvTotal:       18.0,
+						PrecioUnitario: money.MustParse("50.00"),
+						Cantidad:       money.NewFromInt(2),
+						PrecioTotal:    money.MustParse("100.00"),
+						IgvTotal:       money.MustParse("18.00"),
 					},
 				},
 			}
 
-			err := NewDocumentValidator().ValidarDocumento(doc)
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 			if err == nil {
 				t.Errorf("Expected error for invalid rucEmisor: %s", tc.rucEmisor)
 			}
@@ -144,24 +204,26 @@ func TestDocument_Validate_InvalidRucEmisor(t *testing.T) {
 
 func TestDocument_Validate_InvalidRucReceptor(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
 		RucReceptor:            "123",
-		MontoTotalSinImpuestos: 100.0,
-		IgvTotal:               18.0,
-		MontoTotal:             118.0,
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
 		Items: []domain.Item{
 			{
 				Descripcion:    "Item 1",
-				PrecioUnitario: 50.0,
-				Cantidad:       2,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 		},
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err == nil {
 		t.Error("Expected error for invalid rucReceptor")
 	}
@@ -170,37 +232,39 @@ func TestDocument_Validate_InvalidRucReceptor(t *testing.T) {
 func TestDocument_Validate_InvalidMontos(t *testing.T) {
 	testCases := []struct {
 		name                   string
-		montoTotalSinImpuestos float64
-		igvTotal               float64
-		montoTotal             float64
+		montoTotalSinImpuestos money.Money
+		igvTotal               money.Money
+		montoTotal             money.Money
 	}{
-		{"MontoTotal negativo", 100.0, 18.0, -118.0},
-		{"MontoTotal cero", 100.0, 18.0, 0},
-		{"IgvTotal negativo", 100.0, -18.0, 118.0},
-		{"MontoSinImpuestos negativo", -100.0, 18.0, 118.0},
+		{"MontoTotal negativo", money.MustParse("100.00"), money.MustParse("18.00"), money.MustParse("-118.00")},
+		{"MontoTotal cero", money.MustParse("100.00"), money.MustParse("18.00"), money.MustParse("0.00")},
+		{"IgvTotal negativo", money.MustParse("100.00"), money.MustParse("-18.00"), money.MustParse("118.00")},
+		{"MontoSinImpuestos negativo", money.MustParse("-100.00"), money.MustParse("18.00"), money.MustParse("118.00")},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			doc := &domain.Document{
-				IDDocumento:            "FACT-123456789",
-				RucEmisor:              "20123456789",
-				RucReceptor:            "20987654321",
+				IDDocumento:            "F001-00000001",
+				TipoDocumento:          domain.TipoFactura,
+				RucEmisor:              "20123456786",
+				TipoDocumentoReceptor:  domain.IdentidadRUC,
+				RucReceptor:            "20987654326",
 				MontoTotalSinImpuestos: tc.montoTotalSinImpuestos,
 				IgvTotal:               tc.igvTotal,
 				MontoTotal:             tc.montoTotal,
 				Items: []domain.Item{
 					{
 						Descripcion:    "Item 1",
-						PrecioUnitario: 50.0,
-						Cantidad:       2,
-						PrecioTotal:    100.0,
-						IgvTotal:       18.0,
+						PrecioUnitario: money.MustParse("50.00"),
+						Cantidad:       money.NewFromInt(2),
+						PrecioTotal:    money.MustParse("100.00"),
+						IgvTotal:       money.MustParse("18.00"),
 					},
 				},
 			}
 
-			err := NewDocumentValidator().ValidarDocumento(doc)
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 			if err == nil {
 				t.Errorf("Expected error for %s", tc.name)
 			}
@@ -210,16 +274,18 @@ func TestDocument_Validate_InvalidMontos(t *testing.T) {
 
 func TestDocument_Validate_EmptyItems(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
-		RucReceptor:            "20987654321",
-		MontoTotalSinImpuestos: 100.0,
-		IgvTotal:               18.0,
-		MontoTotal:             118.0,
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
 		Items:                  []domain.Item{},
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err == nil {
 		t.Error("Expected error for empty items")
 	}
@@ -228,30 +294,32 @@ func TestDocument_Validate_EmptyItems(t *testing.T) {
 func TestDocument_Validate_InvalidItemFields(t *testing.T) {
 	testCases := []struct {
 		name           string
-		precioUnitario float64
-		cantidad       int
-		precioTotal    float64
-		igvTotal       float64
+		precioUnitario money.Money
+		cantidad       money.Money
+		precioTotal    money.Money
+		igvTotal       money.Money
 	}{
-		{"PrecioUnitario negativo", -50.0, 2, 100.0, 18.0},
-		{"PrecioUnitario cero", 0, 2, 100.0, 18.0},
-		{"Cantidad negativa", 50.0, -2, 100.0, 18.0},
-		{"Cantidad cero", 50.0, 0, 100.0, 18.0},
-		{"PrecioTotal negativo", 50.0, 2, -100.0, 18.0},
-		{"PrecioTotal cero", 50.0, 2, 0, 18.0},
-		{"IgvTotal negativo", 50.0, 2, 100.0, -18.0},
-		{"IgvTotal cero", 50.0, 2, 100.0, 0},
+		{"PrecioUnitario negativo", money.MustParse("-50.00"), money.MustParse("2"), money.MustParse("100.00"), money.MustParse("18.00")},
+		{"PrecioUnitario cero", money.MustParse("0.00"), money.MustParse("2"), money.MustParse("100.00"), money.MustParse("18.00")},
+		{"Cantidad negativa", money.MustParse("50.00"), money.MustParse("-2"), money.MustParse("100.00"), money.MustParse("18.00")},
+		{"Cantidad cero", money.MustParse("50.00"), money.MustParse("0"), money.MustParse("100.00"), money.MustParse("18.00")},
+		{"PrecioTotal negativo", money.MustParse("50.00"), money.MustParse("2"), money.MustParse("-100.00"), money.MustParse("18.00")},
+		{"PrecioTotal cero", money.MustParse("50.00"), money.MustParse("2"), money.MustParse("0.00"), money.MustParse("18.00")},
+		{"IgvTotal negativo", money.MustParse("50.00"), money.MustParse("2"), money.MustParse("100.00"), money.MustParse("-18.00")},
+		{"IgvTotal cero", money.MustParse("50.00"), money.MustParse("2"), money.MustParse("100.00"), money.MustParse("0.00")},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			doc := &domain.Document{
-				IDDocumento:            "FACT-123456789",
-				RucEmisor:              "20123456789",
-				RucReceptor:            "20987654321",
-				MontoTotalSinImpuestos: 100.0,
-				IgvTotal:               18.0,
-				MontoTotal:             118.0,
+				IDDocumento:            "F001-00000001",
+				TipoDocumento:          domain.TipoFactura,
+				RucEmisor:              "20123456786",
+				TipoDocumentoReceptor:  domain.IdentidadRUC,
+				RucReceptor:            "20987654326",
+				MontoTotalSinImpuestos: money.MustParse("100.00"),
+				IgvTotal:               money.MustParse("18.00"),
+				MontoTotal:             money.MustParse("118.00"),
 				Items: []domain.Item{
 					{
 						Descripcion:    "Item 1",
@@ -263,7 +331,7 @@ func TestDocument_Validate_InvalidItemFields(t *testing.T) {
 				},
 			}
 
-			err := NewDocumentValidator().ValidarDocumento(doc)
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 			if err == nil {
 				t.Errorf("Expected error for %s", tc.name)
 			}
@@ -273,25 +341,27 @@ func TestDocument_Validate_InvalidItemFields(t *testing.T) {
 
 func TestDocument_Validate_InvalidFechaEmision(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
-		RucReceptor:            "20987654321",
-		MontoTotalSinImpuestos: 100.0,
-		IgvTotal:               18.0,
-		MontoTotal:             118.0,
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
 		Items: []domain.Item{
 			{
 				Descripcion:    "Item 1",
-				PrecioUnitario: 50.0,
-				Cantidad:       2,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 		},
 		FechaEmision: "2024-13-45",
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err == nil {
 		t.Error("Expected error for invalid fechaEmision format")
 	}
@@ -299,31 +369,33 @@ func TestDocument_Validate_InvalidFechaEmision(t *testing.T) {
 
 func TestDocument_Validate_MultipleItems(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
-		RucReceptor:            "20987654321",
-		MontoTotalSinImpuestos: 200.0,
-		IgvTotal:               36.0,
-		MontoTotal:             236.0,
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("200.00"),
+		IgvTotal:               money.MustParse("36.00"),
+		MontoTotal:             money.MustParse("236.00"),
 		Items: []domain.Item{
 			{
 				Descripcion:    "Item 1",
-				PrecioUnitario: 50.0,
-				Cantidad:       2,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 			{
 				Descripcion:    "Item 2",
-				PrecioUnitario: 100.0,
-				Cantidad:       1,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("100.00"),
+				Cantidad:       money.NewFromInt(1),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 		},
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err != nil {
 		t.Errorf("Expected no error for multiple valid items, got: %v", err)
 	}
@@ -331,31 +403,33 @@ func TestDocument_Validate_MultipleItems(t *testing.T) {
 
 func TestDocument_Validate_ErrorMessageContainsItemIndex(t *testing.T) {
 	doc := &domain.Document{
-		IDDocumento:            "FACT-123456789",
-		RucEmisor:              "20123456789",
-		RucReceptor:            "20987654321",
-		MontoTotalSinImpuestos: 100.0,
-		IgvTotal:               18.0,
-		MontoTotal:             118.0,
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
 		Items: []domain.Item{
 			{
 				Descripcion:    "Item 1",
-				PrecioUnitario: 50.0,
-				Cantidad:       2,
-				PrecioTotal:    100.0,
-				IgvTotal:       18.0,
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
 			},
 			{
 				Descripcion:    "Item 2",
-				PrecioUnitario: -10.0,
-				Cantidad:       1,
-				PrecioTotal:    -10.0,
-				IgvTotal:       -1.8,
+				PrecioUnitario: money.MustParse("-10.00"),
+				Cantidad:       money.NewFromInt(1),
+				PrecioTotal:    money.MustParse("-10.00"),
+				IgvTotal:       money.MustParse("-1.80"),
 			},
 		},
 	}
 
-	err := NewDocumentValidator().ValidarDocumento(doc)
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
 	if err == nil {
 		t.Error("Expected error for invalid item")
 	}
@@ -365,3 +439,283 @@ func TestDocument_Validate_ErrorMessageContainsItemIndex(t *testing.T) {
 		t.Error("Error message should not be empty")
 	}
 }
+
+func TestDocument_Validate_MontoConMasDeDosDecimales(t *testing.T) {
+	doc := &domain.Document{
+		IDDocumento:            "F001-00000001",
+		TipoDocumento:          domain.TipoFactura,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.005"),
+		MontoTotal:             money.MustParse("118.00"),
+		Items: []domain.Item{
+			{
+				Descripcion:    "Item 1",
+				PrecioUnitario: money.MustParse("50.125"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.25"),
+				IgvTotal:       money.MustParse("18.05"),
+			},
+		},
+	}
+
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+	if err == nil {
+		t.Error("Expected error for igvTotal with more than 2 decimals")
+	}
+}
+
+func documentoBase(tipoDocumento, idDocumento string) *domain.Document {
+	return &domain.Document{
+		IDDocumento:            idDocumento,
+		TipoDocumento:          tipoDocumento,
+		RucEmisor:              "20123456786",
+		TipoDocumentoReceptor:  domain.IdentidadRUC,
+		RucReceptor:            "20987654326",
+		MontoTotalSinImpuestos: money.MustParse("100.00"),
+		IgvTotal:               money.MustParse("18.00"),
+		MontoTotal:             money.MustParse("118.00"),
+		Items: []domain.Item{
+			{
+				Descripcion:    "Item 1",
+				PrecioUnitario: money.MustParse("50.00"),
+				Cantidad:       money.NewFromInt(2),
+				PrecioTotal:    money.MustParse("100.00"),
+				IgvTotal:       money.MustParse("18.00"),
+			},
+		},
+	}
+}
+
+func TestDocument_Validate_TipoDocumentoInvalido(t *testing.T) {
+	for _, tipo := range []string{"", "02", "FACTURA"} {
+		err := NewDocumentValidator().ValidarDocumento(context.Background(), documentoBase(tipo, "F001-00000001"))
+		if err == nil {
+			t.Errorf("Expected error for tipoDocumento %q", tipo)
+		}
+	}
+}
+
+func TestDocument_Validate_BoletaConDNI(t *testing.T) {
+	doc := documentoBase(domain.TipoBoleta, "B001-00000123")
+	doc.TipoDocumentoReceptor = domain.IdentidadDNI
+	doc.RucReceptor = "45678912"
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Errorf("Expected no error for boleta issued to DNI, got: %v", err)
+	}
+}
+
+func TestDocument_Validate_FacturaConDNI(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000123")
+	doc.TipoDocumentoReceptor = domain.IdentidadDNI
+	doc.RucReceptor = "45678912"
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err == nil {
+		t.Error("Expected error for factura issued to DNI")
+	}
+}
+
+func TestDocument_Validate_NotaCredito(t *testing.T) {
+	testCases := []struct {
+		name        string
+		idDocumento string
+		referencia  *domain.DocumentoReferencia
+		esValido    bool
+	}{
+		{"Sin referencia", "F001-00000010", nil, false},
+		{"Referencia a factura", "F001-00000010", &domain.DocumentoReferencia{IDDocumento: "F001-00000001", TipoDocumento: domain.TipoFactura, CodigoMotivo: "01"}, true},
+		{"Referencia a boleta", "B001-00000010", &domain.DocumentoReferencia{IDDocumento: "B001-00000001", TipoDocumento: domain.TipoBoleta, CodigoMotivo: "01"}, true},
+		{"Serie F referencia boleta", "F001-00000010", &domain.DocumentoReferencia{IDDocumento: "B001-00000001", TipoDocumento: domain.TipoBoleta, CodigoMotivo: "01"}, false},
+		{"Referencia con formato inválido", "F001-00000010", &domain.DocumentoReferencia{IDDocumento: "FACT-123456789", TipoDocumento: domain.TipoFactura, CodigoMotivo: "01"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoNotaCredito, tc.idDocumento)
+			doc.Referencia = tc.referencia
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if tc.esValido && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if !tc.esValido && err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestDocument_Validate_MotivoNota(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tipo         string
+		codigoMotivo string
+		esValido     bool
+	}{
+		{"Crédito por descuento global", domain.TipoNotaCredito, "04", true},
+		{"Crédito sin motivo", domain.TipoNotaCredito, "", false},
+		{"Crédito con motivo fuera del catálogo 09", domain.TipoNotaCredito, "14", false},
+		{"Débito por intereses", domain.TipoNotaDebito, "01", true},
+		{"Débito con motivo solo de crédito", domain.TipoNotaDebito, "04", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(tc.tipo, "F001-00000010")
+			doc.Referencia = &domain.DocumentoReferencia{IDDocumento: "F001-00000001", TipoDocumento: domain.TipoFactura, CodigoMotivo: tc.codigoMotivo}
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if tc.esValido && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if !tc.esValido && (err == nil || !strings.Contains(err.Error(), "referencia.codigoMotivo")) {
+				t.Errorf("Expected codigoMotivo error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestDocument_Validate_SustentoPorDefecto(t *testing.T) {
+	doc := documentoBase(domain.TipoNotaCredito, "F001-00000010")
+	doc.Referencia = &domain.DocumentoReferencia{IDDocumento: "F001-00000001", TipoDocumento: domain.TipoFactura, CodigoMotivo: "06"}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if doc.Referencia.Sustento != "Devolución total" {
+		t.Errorf("Expected sustento from catalog 09, got %q", doc.Referencia.Sustento)
+	}
+}
+
+func TestDocument_Validate_FacturaConReferencia(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Referencia = &domain.DocumentoReferencia{IDDocumento: "F001-00000002", TipoDocumento: domain.TipoFactura, CodigoMotivo: "01"}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err == nil {
+		t.Error("Expected error for factura with referencia")
+	}
+}
+
+func TestDocument_Validate_RUCPrefijosValidos(t *testing.T) {
+	for _, ruc := range []string{"10456789124", "15123456782", "17123456785", "20100070970"} {
+		doc := documentoBase(domain.TipoFactura, "F001-00000001")
+		doc.RucReceptor = ruc
+
+		if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+			t.Errorf("Expected no error for RUC %s, got: %v", ruc, err)
+		}
+	}
+}
+
+func TestDocument_Validate_IdentidadReceptorEnBoleta(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tipo        string
+		numero      string
+		esValido    bool
+		numeroFinal string
+	}{
+		{"DNI válido", domain.IdentidadDNI, "45678912", true, "45678912"},
+		{"DNI corto", domain.IdentidadDNI, "4567891", false, ""},
+		{"Carnet de extranjería", domain.IdentidadCarnetExtranjeria, "001234567", true, "001234567"},
+		{"Pasaporte", domain.IdentidadPasaporte, "AB1234567", true, "AB1234567"},
+		{"Pasaporte demasiado largo", domain.IdentidadPasaporte, "AB12345678901", false, ""},
+		{"Sin documento vacío", domain.IdentidadSinDocumento, "", true, "-"},
+		{"Sin documento con número", domain.IdentidadSinDocumento, "45678912", false, ""},
+		{"RUC con dígito inválido", domain.IdentidadRUC, "20987654321", false, ""},
+		{"Tipo desconocido", "9", "45678912", false, ""},
+		{"Tipo vacío", "", "45678912", false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoBoleta, "B001-00000001")
+			doc.TipoDocumentoReceptor = tc.tipo
+			doc.RucReceptor = tc.numero
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if tc.esValido && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if !tc.esValido && err == nil {
+				t.Error("Expected validation error")
+			}
+			if tc.esValido && doc.RucReceptor != tc.numeroFinal {
+				t.Errorf("Expected rucReceptor %q, got %q", tc.numeroFinal, doc.RucReceptor)
+			}
+		})
+	}
+}
+
+func TestDocument_Validate_Serie(t *testing.T) {
+	testCases := []struct {
+		name      string
+		id        string
+		serie     string
+		esperaErr bool
+	}{
+		{"Solo serie para numeración automática", "", "F001", false},
+		{"Serie de boleta en factura", "", "B001", true},
+		{"Sin serie ni idDocumento", "", "", true},
+		{"Serie distinta a la del idDocumento", "F001-00000001", "F002", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, tc.id)
+			doc.Serie = tc.serie
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if (err != nil) != tc.esperaErr {
+				t.Errorf("Expected error %v, got: %v", tc.esperaErr, err)
+			}
+		})
+	}
+
+	doc := documentoBase(domain.TipoFactura, "F002-00000007")
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil || doc.Serie != "F002" {
+		t.Errorf("Expected serie F002 derived from idDocumento, got %q (%v)", doc.Serie, err)
+	}
+}
+
+func TestDocument_Validate_NombresDelCatalogo(t *testing.T) {
+	dniCorto := documentoBase(domain.TipoBoleta, "B001-00000001")
+	dniCorto.TipoDocumentoReceptor = domain.IdentidadDNI
+	dniCorto.RucReceptor = "4567891"
+
+	testCases := []struct {
+		name    string
+		doc     *domain.Document
+		mensaje string
+	}{
+		{"Tipo de comprobante", documentoBase(domain.TipoBoleta, "F001-00000001"), "formato de idDocumento inválido para boleta de venta"},
+		{"Tipo de identidad", dniCorto, "rucReceptor de tipo Documento Nacional de Identidad (DNI) debe tener 8 dígitos"},
+		{"Fuera del catálogo 01", documentoBase("02", "F001-00000001"), "Debe ser un código del catálogo 01 de SUNAT"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), tc.doc)
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got: %v", tc.mensaje, err)
+			}
+		})
+	}
+}
+
+func TestReglas_CodigosDelCatalogo(t *testing.T) {
+	for tipo := range reglasPorTipo {
+		if !catalog.Existe(catalog.TiposDocumento, tipo) {
+			t.Errorf("Expected tipoDocumento %s to exist in catalog 01", tipo)
+		}
+	}
+
+	for tipo := range reglasIdentidad {
+		if !catalog.Existe(catalog.TiposIdentidad, tipo) {
+			t.Errorf("Expected tipoDocumentoReceptor %s to exist in catalog 06", tipo)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/tax"
+	"strings"
+	"time"
+)
+
+func ConProveedorTasasIGV(proveedor tax.ProveedorTasasIGV) Opcion {
+	return func(v *DocumentValidator) {
+		v.tasasIGV = proveedor
+	}
+}
+
+// validarTasaIGV resuelve la tasa vigente a la fecha de emisión según el régimen y la guarda en el
+// documento. Si el cliente envía tasaIgv debe coincidir con la de la tabla.
+func (v *DocumentValidator) validarTasaIGV(doc *domain.Document) error {
+	doc.RegimenIgv = strings.ToUpper(strings.TrimSpace(doc.RegimenIgv))
+	if doc.RegimenIgv == "" {
+		doc.RegimenIgv = tax.RegimenGeneral
+	}
+	if !tax.EsRegimenValido(doc.RegimenIgv) {
+		return violacionEn("/regimenIgv", CodigoFueraDeCatalogo, fmt.Sprintf("regimenIgv inválido. Debe ser %s o %s", tax.RegimenGeneral, tax.RegimenMypeRestauranteHotel))
+	}
+
+	fechaEmision, _ := time.Parse(time.RFC3339, doc.FechaEmision)
+	tasa, err := v.tasasIGV.TasaIGV(doc.RegimenIgv, fechaEmision)
+	if err != nil {
+		return violacionEn("/tasaIgv", CodigoSinDatosVigentes, err.Error())
+	}
+
+	if doc.TasaIgv.EsCero() {
+		doc.TasaIgv = tasa
+		return nil
+	}
+
+	if !doc.TasaIgv.Igual(tasa) {
+		return violacionEn("/tasaIgv", CodigoNoCoincide, fmt.Sprintf("tasaIgv no corresponde al régimen %s en la fecha de emisión. Esperado: %s, recibido: %s", doc.RegimenIgv, tasa, doc.TasaIgv))
+	}
+	return nil
+}
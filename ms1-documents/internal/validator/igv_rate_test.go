@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/tax"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func documentoMype() *domain.Document {
+	doc := documentoBase(domain.TipoBoleta, "B001-00000001")
+	doc.TipoDocumentoReceptor = domain.IdentidadDNI
+	doc.RucReceptor = "12345678"
+	doc.FechaEmision = "2024-05-10T10:00:00Z"
+	doc.RegimenIgv = tax.RegimenMypeRestauranteHotel
+	doc.Items[0].IgvTotal = money.MustParse("10.00")
+	doc.IgvTotal = money.MustParse("10.00")
+	doc.MontoTotal = money.MustParse("110.00")
+	return doc
+}
+
+func TestTasaIGV_SeGuardaEnDocumento(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.RegimenIgv != tax.RegimenGeneral || doc.TasaIgv.String() != "0.18" {
+		t.Errorf("Expected GENERAL at 0.18, got %s at %s", doc.RegimenIgv, doc.TasaIgv)
+	}
+}
+
+func TestTasaIGV_MypeRestauranteHotel(t *testing.T) {
+	doc := documentoMype()
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.TasaIgv.String() != "0.10" {
+		t.Errorf("Expected tasaIgv 0.10, got %s", doc.TasaIgv)
+	}
+}
+
+func TestTasaIGV_MypeConTasaGeneral(t *testing.T) {
+	doc := documentoMype()
+	doc.Items[0].IgvTotal = money.MustParse("18.00")
+	doc.IgvTotal = money.MustParse("18.00")
+	doc.MontoTotal = money.MustParse("118.00")
+
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+	if err == nil || !strings.Contains(err.Error(), "× 10%") {
+		t.Errorf("Expected IGV mismatch at 10%%, got: %v", err)
+	}
+}
+
+func TestTasaIGV_Invalida(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+	}{
+		{"Régimen desconocido", func(doc *domain.Document) { doc.RegimenIgv = "AGRARIO" }},
+		{"Tasa distinta a la vigente", func(doc *domain.Document) { doc.TasaIgv = money.MustParse("0.18") }},
+		{"Fecha sin tasa vigente", func(doc *domain.Document) { doc.FechaEmision = "2001-01-01T10:00:00Z" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoMype()
+			tc.modificar(doc)
+
+			if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/internal/tax"
+	"ms1-documents/pkg/errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ubigeoRegex                = regexp.MustCompile(`^[0-9]{6}$`)
+	codigoEstablecimientoRegex = regexp.MustCompile(`^[0-9]{4}$`)
+)
+
+// ValidarEmisor comprueba los datos del emisor y su configuración, y normaliza el estado, el código de
+// establecimiento, las series, la moneda y el régimen de IGV
+func (v *DocumentValidator) ValidarEmisor(emisor *domain.Emisor) error {
+	emisor.RUC = strings.TrimSpace(emisor.RUC)
+	if err := v.validarRUC(emisor.RUC, "/ruc", "ruc"); err != nil {
+		return err
+	}
+
+	emisor.RazonSocial = strings.TrimSpace(emisor.RazonSocial)
+	if emisor.RazonSocial == "" {
+		return errors.ErrorValidacion("razonSocial es obligatoria")
+	}
+	emisor.NombreComercial = strings.TrimSpace(emisor.NombreComercial)
+
+	emisor.Direccion = strings.TrimSpace(emisor.Direccion)
+	if emisor.Direccion == "" {
+		return errors.ErrorValidacion("direccion es obligatoria")
+	}
+
+	if !ubigeoRegex.MatchString(emisor.Ubigeo) {
+		return errors.ErrorValidacion("ubigeo debe tener 6 dígitos")
+	}
+
+	if emisor.CodigoEstablecimiento == "" {
+		emisor.CodigoEstablecimiento = domain.CodigoEstablecimientoPrincipal
+	}
+	if !codigoEstablecimientoRegex.MatchString(emisor.CodigoEstablecimiento) {
+		return errors.ErrorValidacion("codigoEstablecimiento debe tener 4 dígitos")
+	}
+
+	emisor.Estado = strings.ToUpper(strings.TrimSpace(emisor.Estado))
+	switch emisor.Estado {
+	case "":
+		emisor.Estado = domain.EstadoEmisorActivo
+	case domain.EstadoEmisorActivo, domain.EstadoEmisorInactivo:
+	default:
+		return errors.ErrorValidacion(fmt.Sprintf("estado inválido. Debe ser %s o %s", domain.EstadoEmisorActivo, domain.EstadoEmisorInactivo))
+	}
+
+	return validarConfiguracionEmisor(&emisor.Configuracion)
+}
+
+func validarConfiguracionEmisor(configuracion *domain.ConfiguracionEmisor) error {
+	vistas := make(map[string]bool, len(configuracion.Series))
+	for indice, serie := range configuracion.Series {
+		serie = strings.ToUpper(strings.TrimSpace(serie))
+		if !prefijoNota.MatchString(serie) {
+			return errors.ErrorValidacion(fmt.Sprintf("configuracion.series %d debe tener el formato F001 o B001, se recibió %q", indice, serie))
+		}
+		if vistas[serie] {
+			return errors.ErrorValidacion(fmt.Sprintf("configuracion.series %d repite la serie %s", indice, serie))
+		}
+		vistas[serie] = true
+		configuracion.Series[indice] = serie
+	}
+
+	configuracion.MonedaPorDefecto = strings.ToUpper(strings.TrimSpace(configuracion.MonedaPorDefecto))
+	if configuracion.MonedaPorDefecto != "" && !domain.EsMonedaValida(configuracion.MonedaPorDefecto) {
+		return errors.ErrorValidacion(fmt.Sprintf("configuracion.monedaPorDefecto inválida. Debe ser %s, %s o %s", domain.MonedaPEN, domain.MonedaUSD, domain.MonedaEUR))
+	}
+
+	configuracion.RegimenIgv = strings.ToUpper(strings.TrimSpace(configuracion.RegimenIgv))
+	if configuracion.RegimenIgv != "" && !tax.EsRegimenValido(configuracion.RegimenIgv) {
+		return errors.ErrorValidacion(fmt.Sprintf("configuracion.regimenIgv inválido. Debe ser %s o %s", tax.RegimenGeneral, tax.RegimenMypeRestauranteHotel))
+	}
+
+	configuracion.Logo = strings.TrimSpace(configuracion.Logo)
+	if configuracion.Logo != "" {
+		direccion, err := url.Parse(configuracion.Logo)
+		if err != nil || (direccion.Scheme != "http" && direccion.Scheme != "https") || direccion.Host == "" {
+			return errors.ErrorValidacion("configuracion.logo debe ser una URL http o https")
+		}
+	}
+
+	return nil
+}
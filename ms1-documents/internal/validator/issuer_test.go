@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"ms1-documents/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestValidarEmisor_Normaliza(t *testing.T) {
+	emisor := &domain.Emisor{
+		RUC:         "20123456786",
+		RazonSocial: "EMPRESA DEMO S.A.C.",
+		Direccion:   "Av. Arequipa 123, Lima",
+		Ubigeo:      "150101",
+		Configuracion: domain.ConfiguracionEmisor{
+			Series:           []string{"f001", "B001"},
+			MonedaPorDefecto: "usd",
+			RegimenIgv:       "general",
+		},
+	}
+
+	if err := NewDocumentValidator().ValidarEmisor(emisor); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if emisor.Estado != domain.EstadoEmisorActivo {
+		t.Errorf("Expected estado %s, got %s", domain.EstadoEmisorActivo, emisor.Estado)
+	}
+
+	if emisor.CodigoEstablecimiento != domain.CodigoEstablecimientoPrincipal {
+		t.Errorf("Expected codigoEstablecimiento %s, got %s", domain.CodigoEstablecimientoPrincipal, emisor.CodigoEstablecimiento)
+	}
+
+	if emisor.Configuracion.Series[0] != "F001" {
+		t.Errorf("Expected serie F001, got %s", emisor.Configuracion.Series[0])
+	}
+
+	if emisor.Configuracion.MonedaPorDefecto != domain.MonedaUSD {
+		t.Errorf("Expected moneda %s, got %s", domain.MonedaUSD, emisor.Configuracion.MonedaPorDefecto)
+	}
+
+	if emisor.Configuracion.RegimenIgv != "GENERAL" {
+		t.Errorf("Expected regimen GENERAL, got %s", emisor.Configuracion.RegimenIgv)
+	}
+}
+
+func TestValidarEmisor_Invalido(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(emisor *domain.Emisor)
+		mensaje   string
+	}{
+		{"RUC inválido", func(emisor *domain.Emisor) { emisor.RUC = "20123456780" }, "ruc"},
+		{"Sin razón social", func(emisor *domain.Emisor) { emisor.RazonSocial = " " }, "razonSocial es obligatoria"},
+		{"Sin dirección", func(emisor *domain.Emisor) { emisor.Direccion = "" }, "direccion es obligatoria"},
+		{"Ubigeo corto", func(emisor *domain.Emisor) { emisor.Ubigeo = "1501" }, "ubigeo debe tener 6 dígitos"},
+		{"Establecimiento inválido", func(emisor *domain.Emisor) { emisor.CodigoEstablecimiento = "A1" }, "codigoEstablecimiento"},
+		{"Estado desconocido", func(emisor *domain.Emisor) { emisor.Estado = "SUSPENDIDO" }, "estado inválido"},
+		{"Serie inválida", func(emisor *domain.Emisor) { emisor.Configuracion.Series = []string{"X1"} }, "configuracion.series 0"},
+		{"Serie repetida", func(emisor *domain.Emisor) { emisor.Configuracion.Series = []string{"F001", "f001"} }, "repite la serie F001"},
+		{"Moneda inválida", func(emisor *domain.Emisor) { emisor.Configuracion.MonedaPorDefecto = "GBP" }, "configuracion.monedaPorDefecto"},
+		{"Régimen inválido", func(emisor *domain.Emisor) { emisor.Configuracion.RegimenIgv = "RER" }, "configuracion.regimenIgv"},
+		{"Logo sin esquema", func(emisor *domain.Emisor) { emisor.Configuracion.Logo = "cdn.example.com/logo.png" }, "configuracion.logo"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			emisor := &domain.Emisor{
+				RUC:         "20123456786",
+				RazonSocial: "EMPRESA DEMO S.A.C.",
+				Direccion:   "Av. Arequipa 123, Lima",
+				Ubigeo:      "150101",
+				Configuracion: domain.ConfiguracionEmisor{
+					Series:           []string{"f001", "B001"},
+					MonedaPorDefecto: "usd",
+					RegimenIgv:       "general",
+				},
+			}
+			tc.modificar(emisor)
+
+			err := NewDocumentValidator().ValidarEmisor(emisor)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got %q", tc.mensaje, err.Error())
+			}
+		})
+	}
+}
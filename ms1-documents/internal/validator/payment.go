@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const formatoFechaVencimiento = "2006-01-02"
+
+// validarFormaPago normaliza el tipo de pago y comprueba las cuotas. Una venta al crédito detalla al
+// menos una cuota, las cuotas vencen después de la emisión y suman el neto pendiente de pago, que se
+// completa si el cliente lo omite. Una venta al contado no lleva cuotas.
+func (v *DocumentValidator) validarFormaPago(doc *domain.Document) error {
+	formaPago := doc.FormaPago
+	if formaPago == nil {
+		return nil
+	}
+
+	switch tipo := strings.TrimSpace(formaPago.Tipo); {
+	case tipo == "" || strings.EqualFold(tipo, domain.FormaPagoContado):
+		formaPago.Tipo = domain.FormaPagoContado
+	case strings.EqualFold(tipo, domain.FormaPagoCredito) || strings.EqualFold(tipo, "Crédito"):
+		formaPago.Tipo = domain.FormaPagoCredito
+	default:
+		return violacionEn("/formaPago/tipo", CodigoFueraDeCatalogo, fmt.Sprintf("formaPago.tipo inválido. Debe ser %s o %s", domain.FormaPagoContado, domain.FormaPagoCredito))
+	}
+
+	if formaPago.Tipo == domain.FormaPagoContado {
+		if len(formaPago.Cuotas) > 0 {
+			return violacionEn("/formaPago/cuotas", CodigoNoPermitido, "formaPago.cuotas no aplica a ventas al contado")
+		}
+		if !formaPago.MontoPendiente.EsCero() {
+			return violacionEn("/formaPago/montoPendiente", CodigoNoPermitido, "formaPago.montoPendiente no aplica a ventas al contado")
+		}
+		return nil
+	}
+
+	netoPendiente := doc.MontoNetoPendiente()
+	if formaPago.MontoPendiente.EsCero() {
+		formaPago.MontoPendiente = netoPendiente
+	} else if !formaPago.MontoPendiente.Igual(netoPendiente) {
+		return violacionEn("/formaPago/montoPendiente", CodigoNoCoincide, fmt.Sprintf("formaPago.montoPendiente debe ser el neto pendiente de pago %s, se recibió %s",
+			netoPendiente.StringFijo(money.DecimalesMonto), formaPago.MontoPendiente.StringFijo(money.DecimalesMonto)))
+	}
+
+	if len(formaPago.Cuotas) == 0 {
+		return violacionEn("/formaPago/cuotas", CodigoRequerido, "formaPago.cuotas debe tener al menos una cuota en ventas al crédito")
+	}
+
+	emision, _ := time.Parse(time.RFC3339, doc.FechaEmision)
+	fechaEmision := emision.In(domain.ZonaHorariaPeru).Format(formatoFechaVencimiento)
+
+	var errores violaciones
+	suma := money.Cero()
+	for indice, cuota := range formaPago.Cuotas {
+		campo := fmt.Sprintf("formaPago.cuotas %d", indice)
+		ruta := "/formaPago/cuotas/" + strconv.Itoa(indice)
+		if !errores.agregar(validarMontoPositivo(cuota.Monto, ruta+"/monto", "monto de "+campo)) {
+			errores.agregar(v.validarPrecisionMonto(cuota.Monto, ruta+"/monto", "monto de "+campo))
+		}
+
+		if _, err := time.Parse(formatoFechaVencimiento, cuota.FechaVencimiento); err != nil {
+			errores.agregar(violacionEn(ruta+"/fechaVencimiento", CodigoFormatoInvalido, fmt.Sprintf("fechaVencimiento de %s debe tener el formato AAAA-MM-DD", campo)))
+		} else if cuota.FechaVencimiento <= fechaEmision {
+			errores.agregar(violacionEn(ruta+"/fechaVencimiento", CodigoFueraDeRango, fmt.Sprintf("fechaVencimiento de %s debe ser posterior a la fecha de emisión %s", campo, fechaEmision)))
+		}
+		suma = suma.Sumar(cuota.Monto)
+	}
+	if !errores.vacio() {
+		return errores.error()
+	}
+
+	if !suma.Igual(formaPago.MontoPendiente) {
+		return violacionEn("/formaPago/cuotas", CodigoNoCoincide, fmt.Sprintf("formaPago.cuotas suman %s y deben sumar el neto pendiente de pago %s",
+			suma.StringFijo(money.DecimalesMonto), formaPago.MontoPendiente.StringFijo(money.DecimalesMonto)))
+	}
+
+	return nil
+}
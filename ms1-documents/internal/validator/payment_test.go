@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func TestFormaPago_Credito(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.FechaEmision = "2026-02-12T10:00:00-05:00"
+	doc.FormaPago = &domain.FormaPago{
+		Tipo: "credito",
+		Cuotas: []domain.Cuota{
+			{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-03-14"},
+			{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-04-13"},
+		},
+	}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.FormaPago.Tipo != domain.FormaPagoCredito {
+		t.Errorf("Expected tipo %s, got %s", domain.FormaPagoCredito, doc.FormaPago.Tipo)
+	}
+
+	if !doc.FormaPago.MontoPendiente.Igual(money.MustParse("118.00")) {
+		t.Errorf("Expected montoPendiente 118.00, got %s", doc.FormaPago.MontoPendiente)
+	}
+}
+
+func TestFormaPago_Contado(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.FormaPago = &domain.FormaPago{}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.FormaPago.Tipo != domain.FormaPagoContado {
+		t.Errorf("Expected tipo %s, got %s", domain.FormaPagoContado, doc.FormaPago.Tipo)
+	}
+}
+
+func TestFormaPago_Invalida(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+		mensaje   string
+	}{
+		{"Tipo desconocido", func(doc *domain.Document) { doc.FormaPago.Tipo = "Letra" }, "formaPago.tipo inválido"},
+		{"Contado con cuotas", func(doc *domain.Document) { doc.FormaPago.Tipo = domain.FormaPagoContado }, "formaPago.cuotas no aplica"},
+		{"Crédito sin cuotas", func(doc *domain.Document) { doc.FormaPago.Cuotas = nil }, "al menos una cuota"},
+		{"Cuotas que no suman el neto", func(doc *domain.Document) {
+			doc.FormaPago.Cuotas[1].Monto = money.MustParse("50.00")
+		}, "formaPago.cuotas suman 109.00 y deben sumar el neto pendiente de pago 118.00"},
+		{"Monto pendiente distinto del neto", func(doc *domain.Document) {
+			doc.FormaPago.MontoPendiente = money.MustParse("100.00")
+		}, "formaPago.montoPendiente debe ser el neto pendiente de pago 118.00"},
+		{"Cuota sin monto", func(doc *domain.Document) {
+			doc.FormaPago.Cuotas[0].Monto = money.Cero()
+		}, "monto de formaPago.cuotas 0 debe ser positivo"},
+		{"Vencimiento el día de emisión", func(doc *domain.Document) {
+			doc.FormaPago.Cuotas[1].FechaVencimiento = "2026-02-12"
+		}, "fechaVencimiento de formaPago.cuotas 1 debe ser posterior a la fecha de emisión 2026-02-12"},
+		{"Vencimiento con formato inválido", func(doc *domain.Document) {
+			doc.FormaPago.Cuotas[0].FechaVencimiento = "14/03/2026"
+		}, "formato AAAA-MM-DD"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.FechaEmision = "2026-02-12T10:00:00-05:00"
+			doc.FormaPago = &domain.FormaPago{
+				Tipo: "credito",
+				Cuotas: []domain.Cuota{
+					{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-03-14"},
+					{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-04-13"},
+				},
+			}
+			tc.modificar(doc)
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got: %v", tc.mensaje, err)
+			}
+		})
+	}
+}
+
+func TestFormaPago_OmitidaSiFallaRetencionODetraccion(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+		regla     string
+	}{
+		{"Retención inválida", func(doc *domain.Document) {
+			doc.Retencion = &domain.Retencion{Monto: money.MustParse("1.00")}
+		}, ReglaRetencion},
+		{"Detracción inválida", func(doc *domain.Document) {
+			doc.Detraccion = &domain.Detraccion{Codigo: "999", CuentaBancoNacion: "00741123456"}
+		}, ReglaDetraccion},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+			doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+			doc.FechaEmision = "2026-02-12T10:00:00-05:00"
+			doc.FormaPago = &domain.FormaPago{
+				Tipo:   domain.FormaPagoCredito,
+				Cuotas: []domain.Cuota{{Monto: money.MustParse("1180.00"), FechaVencimiento: "2026-03-14"}},
+			}
+			tc.modificar(doc)
+
+			verificaciones, err := NewDocumentValidator().EvaluarDocumento(context.Background(), doc)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			resultados := make(map[string]domain.Verificacion)
+			for _, verificacion := range verificaciones {
+				resultados[verificacion.Nombre] = verificacion
+			}
+
+			if resultados[tc.regla].Resultado != domain.ResultadoIncumplida {
+				t.Errorf("Expected %s to fail, got %s", tc.regla, resultados[tc.regla].Resultado)
+			}
+			if resultados[ReglaFormaPago].Resultado != domain.ResultadoOmitida {
+				t.Errorf("Expected formaPago to be skipped, got %s with %v", resultados[ReglaFormaPago].Resultado, resultados[ReglaFormaPago].Errores)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/catalog"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"regexp"
+	"strings"
+)
+
+var codigoProductoSunatRegex = regexp.MustCompile(`^[0-9]{8}$`)
+
+// validarProductoItem asigna la unidad por defecto y valida la unidad del catálogo 03 y el código UNSPSC
+func validarProductoItem(indice int, item *domain.Item) error {
+	item.UnidadMedida = strings.ToUpper(strings.TrimSpace(item.UnidadMedida))
+	if item.UnidadMedida == "" {
+		item.UnidadMedida = domain.UnidadMedidaPorDefecto
+	}
+	if !catalog.Existe(catalog.UnidadesMedida, item.UnidadMedida) {
+		return violacionEn(rutaItem(indice, "unidadMedida"), CodigoFueraDeCatalogo, fmt.Sprintf("unidadMedida del item %d no existe en el catálogo 03", indice))
+	}
+
+	item.CodigoProductoSunat = strings.TrimSpace(item.CodigoProductoSunat)
+	if item.CodigoProductoSunat != "" && !codigoProductoSunatRegex.MatchString(item.CodigoProductoSunat) {
+		return violacionEn(rutaItem(indice, "codigoProductoSunat"), CodigoFormatoInvalido, fmt.Sprintf("codigoProductoSunat del item %d debe tener 8 dígitos (UNSPSC)", indice))
+	}
+	return nil
+}
+
+// ValidarProducto comprueba una entrada del catálogo de productos y normaliza la unidad y la afectación
+func (v *DocumentValidator) ValidarProducto(producto *domain.Producto) error {
+	if err := v.validarRUC(producto.RucEmisor, "/rucEmisor", "rucEmisor"); err != nil {
+		return err
+	}
+
+	producto.Codigo = strings.TrimSpace(producto.Codigo)
+	if producto.Codigo == "" {
+		return errors.ErrorValidacion("codigo es obligatorio")
+	}
+	if len(producto.Codigo) > 30 {
+		return errors.ErrorValidacion("codigo no puede tener más de 30 caracteres")
+	}
+
+	producto.Descripcion = strings.TrimSpace(producto.Descripcion)
+	if producto.Descripcion == "" {
+		return errors.ErrorValidacion("descripcion es obligatoria")
+	}
+
+	producto.CodigoProductoSunat = strings.TrimSpace(producto.CodigoProductoSunat)
+	if producto.CodigoProductoSunat != "" && !codigoProductoSunatRegex.MatchString(producto.CodigoProductoSunat) {
+		return errors.ErrorValidacion("codigoProductoSunat debe tener 8 dígitos (UNSPSC)")
+	}
+
+	producto.UnidadMedida = strings.ToUpper(strings.TrimSpace(producto.UnidadMedida))
+	if producto.UnidadMedida == "" {
+		producto.UnidadMedida = domain.UnidadMedidaPorDefecto
+	}
+	if !catalog.Existe(catalog.UnidadesMedida, producto.UnidadMedida) {
+		return errors.ErrorValidacion("unidadMedida no existe en el catálogo 03")
+	}
+
+	if err := validarMontoNoNegativo(producto.PrecioUnitario, "/precioUnitario", "precioUnitario"); err != nil {
+		return err
+	}
+
+	if producto.TipoAfectacionIgv == "" {
+		producto.TipoAfectacionIgv = domain.AfectacionGravadoOneroso
+	}
+	if _, _, ok := domain.CategoriaAfectacion(producto.TipoAfectacionIgv); !ok {
+		return errors.ErrorValidacion("tipoAfectacionIgv no existe en el catálogo 07")
+	}
+	return nil
+}
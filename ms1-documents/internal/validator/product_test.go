@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func TestValidarProducto_Normaliza(t *testing.T) {
+	producto := &domain.Producto{
+		RucEmisor:           "20123456786",
+		Codigo:              " P-001 ",
+		Descripcion:         "Arroz extra",
+		CodigoProductoSunat: "50221101",
+		UnidadMedida:        "kgm",
+		PrecioUnitario:      money.MustParse("4.20"),
+	}
+
+	if err := NewDocumentValidator().ValidarProducto(producto); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if producto.Codigo != "P-001" {
+		t.Errorf("Expected codigo P-001, got %q", producto.Codigo)
+	}
+
+	if producto.UnidadMedida != "KGM" {
+		t.Errorf("Expected unidadMedida KGM, got %s", producto.UnidadMedida)
+	}
+
+	if producto.TipoAfectacionIgv != domain.AfectacionGravadoOneroso {
+		t.Errorf("Expected tipoAfectacionIgv %s, got %s", domain.AfectacionGravadoOneroso, producto.TipoAfectacionIgv)
+	}
+}
+
+func TestValidarProducto_UnidadPorDefecto(t *testing.T) {
+	producto := &domain.Producto{RucEmisor: "20123456786", Codigo: "P-001", Descripcion: "Arroz extra", PrecioUnitario: money.MustParse("4.20")}
+
+	if err := NewDocumentValidator().ValidarProducto(producto); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if producto.UnidadMedida != domain.UnidadMedidaPorDefecto {
+		t.Errorf("Expected unidadMedida %s, got %s", domain.UnidadMedidaPorDefecto, producto.UnidadMedida)
+	}
+}
+
+func TestValidarProducto_Invalido(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(producto *domain.Producto)
+		mensaje   string
+	}{
+		{"RUC inválido", func(producto *domain.Producto) { producto.RucEmisor = "20123456780" }, "rucEmisor"},
+		{"Sin código", func(producto *domain.Producto) { producto.Codigo = " " }, "codigo es obligatorio"},
+		{"Código largo", func(producto *domain.Producto) { producto.Codigo = strings.Repeat("X", 31) }, "codigo no puede tener más de 30 caracteres"},
+		{"Sin descripción", func(producto *domain.Producto) { producto.Descripcion = "" }, "descripcion es obligatoria"},
+		{"UNSPSC corto", func(producto *domain.Producto) { producto.CodigoProductoSunat = "5022" }, "codigoProductoSunat debe tener 8 dígitos"},
+		{"Unidad fuera del catálogo", func(producto *domain.Producto) { producto.UnidadMedida = "XYZ" }, "unidadMedida no existe en el catálogo 03"},
+		{"Precio negativo", func(producto *domain.Producto) { producto.PrecioUnitario = money.MustParse("-1.00") }, "precioUnitario"},
+		{"Afectación inválida", func(producto *domain.Producto) { producto.TipoAfectacionIgv = "99" }, "tipoAfectacionIgv no existe en el catálogo 07"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			producto := &domain.Producto{
+				RucEmisor:           "20123456786",
+				Codigo:              " P-001 ",
+				Descripcion:         "Arroz extra",
+				CodigoProductoSunat: "50221101",
+				UnidadMedida:        "kgm",
+				PrecioUnitario:      money.MustParse("4.20"),
+			}
+			tc.modificar(producto)
+
+			err := NewDocumentValidator().ValidarProducto(producto)
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got %v", tc.mensaje, err)
+			}
+		})
+	}
+}
+
+func TestDocument_Validate_CantidadDecimal(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Items[0] = domain.Item{
+		Descripcion:    "Arroz extra",
+		PrecioUnitario: money.MustParse("4.20"),
+		Cantidad:       money.MustParse("2.5"),
+		UnidadMedida:   "kgm",
+		PrecioTotal:    money.MustParse("10.50"),
+		IgvTotal:       money.MustParse("1.89"),
+	}
+	doc.MontoTotalSinImpuestos = money.MustParse("10.50")
+	doc.IgvTotal = money.MustParse("1.89")
+	doc.MontoTotal = money.MustParse("12.39")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error for 2.5 KGM, got: %v", err)
+	}
+
+	if doc.Items[0].UnidadMedida != "KGM" {
+		t.Errorf("Expected unidadMedida KGM, got %s", doc.Items[0].UnidadMedida)
+	}
+}
+
+func TestDocument_Validate_ProductoItem(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(item *domain.Item)
+		mensaje   string
+	}{
+		{"Cantidad cero", func(item *domain.Item) { item.Cantidad = money.Money{} }, "cantidad del item 0 debe ser positiva"},
+		{"Unidad fuera del catálogo", func(item *domain.Item) { item.UnidadMedida = "CAJA" }, "unidadMedida del item 0 no existe en el catálogo 03"},
+		{"UNSPSC con letras", func(item *domain.Item) { item.CodigoProductoSunat = "5022110A" }, "codigoProductoSunat del item 0 debe tener 8 dígitos"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			tc.modificar(&doc.Items[0])
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got %v", tc.mensaje, err)
+			}
+		})
+	}
+}
+
+func TestDocument_Validate_UnidadPorDefecto(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.Items[0].UnidadMedida != domain.UnidadMedidaPorDefecto {
+		t.Errorf("Expected unidadMedida %s, got %s", domain.UnidadMedidaPorDefecto, doc.Items[0].UnidadMedida)
+	}
+}
@@ -0,0 +1,220 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"net/http"
+	"strings"
+)
+
+// Nombres de las reglas base, en el orden en que ValidarDocumento las aplica
+const (
+	ReglaTipoDocumento    = "tipoDocumento"
+	ReglaSerie            = "serie"
+	ReglaRucEmisor        = "rucEmisor"
+	ReglaReceptor         = "receptor"
+	ReglaDatosReceptor    = "datosReceptor"
+	ReglaReferencia       = "referencia"
+	ReglaOrdenCompra      = "ordenCompra"
+	ReglaMontos           = "montos"
+	ReglaItems            = "items"
+	ReglaCargosDescuentos = "cargosDescuentos"
+	ReglaFechaEmision     = "fechaEmision"
+	ReglaMoneda           = "moneda"
+	ReglaTasaIGV          = "tasaIgv"
+	ReglaAritmetica       = "aritmetica"
+	ReglaDetraccion       = "detraccion"
+	ReglaPercepcion       = "percepcion"
+	ReglaRetencion        = "retencion"
+	ReglaFormaPago        = "formaPago"
+)
+
+// Regla es una validación con nombre que ValidarDocumento aplica al documento. Validar devuelve los
+// incumplimientos como error de validación y puede completar los campos que se calculan si se omiten.
+// Cualquier otro error, como una consulta fallida, detiene la validación.
+type Regla interface {
+	Nombre() string
+	Validar(contexto context.Context, doc *domain.Document) error
+}
+
+// reglaRegistrada es una regla con las que deben cumplirse antes; si alguna falla o se omite, la regla
+// también se omite para no informar errores derivados
+type reglaRegistrada struct {
+	regla    Regla
+	requiere []string
+}
+
+type reglaFuncion struct {
+	nombre  string
+	validar func(contexto context.Context, doc *domain.Document) error
+}
+
+func (r reglaFuncion) Nombre() string {
+	return r.nombre
+}
+
+func (r reglaFuncion) Validar(contexto context.Context, doc *domain.Document) error {
+	return r.validar(contexto, doc)
+}
+
+// NuevaRegla crea una regla a partir de una función que no necesita el contexto
+func NuevaRegla(nombre string, validar func(doc *domain.Document) error) Regla {
+	return reglaFuncion{nombre: nombre, validar: func(_ context.Context, doc *domain.Document) error {
+		return validar(doc)
+	}}
+}
+
+// ConRegla agrega al registro una regla que se aplica a todos los documentos después de las ya
+// registradas. requiere nombra las reglas que deben cumplirse antes (por ejemplo ReglaAritmetica).
+func ConRegla(regla Regla, requiere ...string) Opcion {
+	return func(v *DocumentValidator) {
+		v.registrar(regla, requiere...)
+	}
+}
+
+// registrar falla al iniciar si el nombre se repite o si requiere una regla que no está registrada antes
+func (v *DocumentValidator) registrar(regla Regla, requiere ...string) {
+	registradas := make(map[string]bool, len(v.reglas))
+	for _, registrada := range v.reglas {
+		registradas[registrada.regla.Nombre()] = true
+	}
+
+	if registradas[regla.Nombre()] {
+		panic(fmt.Sprintf("la regla %s ya está registrada", regla.Nombre()))
+	}
+	for _, nombre := range requiere {
+		if !registradas[nombre] {
+			panic(fmt.Sprintf("la regla %s requiere %s, que no está registrada antes", regla.Nombre(), nombre))
+		}
+	}
+	v.reglas = append(v.reglas, reglaRegistrada{regla: regla, requiere: requiere})
+}
+
+// Reglas devuelve los nombres de las reglas registradas en el orden en que se aplican
+func (v *DocumentValidator) Reglas() []string {
+	nombres := make([]string, len(v.reglas))
+	for indice, registrada := range v.reglas {
+		nombres[indice] = registrada.regla.Nombre()
+	}
+	return nombres
+}
+
+// registrarReglasBase registra las reglas de SUNAT. La aritmética requiere que todas las anteriores se
+// cumplan y la detracción, percepción, retención y forma de pago necesitan importes que cuadren.
+func (v *DocumentValidator) registrarReglasBase() {
+	v.registrar(NuevaRegla(ReglaTipoDocumento, func(doc *domain.Document) error {
+		_, err := v.validarTipoDocumento(doc.TipoDocumento)
+		return err
+	}))
+	v.registrar(NuevaRegla(ReglaSerie, func(doc *domain.Document) error {
+		regla, _ := reglaDelTipo(doc.TipoDocumento)
+		return v.validarSerie(doc, regla)
+	}), ReglaTipoDocumento)
+	v.registrar(NuevaRegla(ReglaRucEmisor, func(doc *domain.Document) error {
+		return v.validarRUC(doc.RucEmisor, "/rucEmisor", "rucEmisor")
+	}))
+	v.registrar(NuevaRegla(ReglaReceptor, v.validarReceptor), ReglaSerie)
+	v.registrar(NuevaRegla(ReglaDatosReceptor, v.validarDatosReceptor))
+	v.registrar(NuevaRegla(ReglaReferencia, v.validarReferencia), ReglaSerie)
+	v.registrar(NuevaRegla(ReglaOrdenCompra, validarOrdenCompra))
+	v.registrar(NuevaRegla(ReglaMontos, v.validarMontos))
+	v.registrar(NuevaRegla(ReglaItems, func(doc *domain.Document) error {
+		return v.validarItems(doc.Items)
+	}))
+	v.registrar(NuevaRegla(ReglaCargosDescuentos, func(doc *domain.Document) error {
+		return v.validarCargosDescuentos(doc.CargosDescuentos, true, "", "")
+	}))
+	v.registrar(NuevaRegla(ReglaFechaEmision, v.validarFechaEmision))
+	v.registrar(reglaFuncion{nombre: ReglaMoneda, validar: v.validarMoneda}, ReglaFechaEmision)
+	v.registrar(NuevaRegla(ReglaTasaIGV, v.validarTasaIGV), ReglaFechaEmision)
+
+	v.registrar(NuevaRegla(ReglaAritmetica, v.validarAritmetica), v.Reglas()...)
+
+	v.registrar(NuevaRegla(ReglaDetraccion, v.validarDetraccion), ReglaAritmetica)
+	v.registrar(NuevaRegla(ReglaPercepcion, v.validarPercepcion), ReglaAritmetica)
+	v.registrar(NuevaRegla(ReglaRetencion, v.validarRetencion), ReglaAritmetica)
+	v.registrar(NuevaRegla(ReglaFormaPago, v.validarFormaPago), ReglaAritmetica, ReglaDetraccion, ReglaRetencion)
+}
+
+// ValidarDocumento aplica las reglas registradas y devuelve juntas las que el documento incumple, cada
+// una con su ruta y código. adicionales son reglas propias de este documento, como las del emisor, y
+// se aplican al final si la aritmética cuadra.
+func (v *DocumentValidator) ValidarDocumento(contexto context.Context, doc *domain.Document, adicionales ...Regla) error {
+	verificaciones, err := v.EvaluarDocumento(contexto, doc, adicionales...)
+	if err != nil {
+		return err
+	}
+
+	var errores violaciones
+	for _, verificacion := range verificaciones {
+		errores.errores = append(errores.errores, verificacion.Errores...)
+	}
+	return errores.error()
+}
+
+// EvaluarDocumento aplica las mismas reglas que ValidarDocumento e informa el resultado de cada una,
+// incluidas las que se omiten porque falló una regla de la que dependen. Solo devuelve error si una regla
+// no se pudo aplicar.
+func (v *DocumentValidator) EvaluarDocumento(contexto context.Context, doc *domain.Document, adicionales ...Regla) ([]domain.Verificacion, error) {
+	reglas := make([]reglaRegistrada, 0, len(v.reglas)+len(adicionales))
+	reglas = append(reglas, v.reglas...)
+	for _, adicional := range adicionales {
+		reglas = append(reglas, reglaRegistrada{regla: adicional, requiere: []string{ReglaAritmetica}})
+	}
+
+	verificaciones := make([]domain.Verificacion, 0, len(reglas))
+	fallidas := make(map[string]bool, len(reglas))
+	for _, registrada := range reglas {
+		nombre := registrada.regla.Nombre()
+		verificacion := domain.Verificacion{Nombre: nombre, Etapa: etapaDeRegla(nombre), Resultado: domain.ResultadoCumplida}
+
+		if requisitoFallido(registrada.requiere, fallidas) {
+			verificacion.Resultado = domain.ResultadoOmitida
+			fallidas[nombre] = true
+			verificaciones = append(verificaciones, verificacion)
+			continue
+		}
+
+		err := registrada.regla.Validar(contexto, doc)
+		if err != nil && !esErrorValidacion(err) {
+			return nil, err
+		}
+
+		var errores violaciones
+		if errores.agregar(err) {
+			verificacion.Resultado = domain.ResultadoIncumplida
+			verificacion.Errores = errores.errores
+			fallidas[nombre] = true
+		}
+		verificaciones = append(verificaciones, verificacion)
+	}
+	return verificaciones, nil
+}
+
+func esErrorValidacion(err error) bool {
+	appErr, ok := err.(*errors.AppError)
+	return ok && appErr.Code == http.StatusBadRequest
+}
+
+func requisitoFallido(requiere []string, fallidas map[string]bool) bool {
+	for _, nombre := range requiere {
+		if fallidas[nombre] {
+			return true
+		}
+	}
+	return false
+}
+
+// etapaDeRegla agrupa las reglas en el reporte de validación: la aritmética, las del emisor y las de formato
+func etapaDeRegla(nombre string) string {
+	switch {
+	case nombre == ReglaAritmetica:
+		return domain.EtapaAritmetica
+	case strings.HasPrefix(nombre, prefijoReglaNegocio):
+		return domain.EtapaReglasNegocio
+	default:
+		return domain.EtapaFormato
+	}
+}
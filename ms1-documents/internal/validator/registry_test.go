@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"testing"
+)
+
+func TestReglas_OrdenBase(t *testing.T) {
+	reglas := NewDocumentValidator().Reglas()
+
+	posiciones := make(map[string]int, len(reglas))
+	for posicion, nombre := range reglas {
+		posiciones[nombre] = posicion
+	}
+
+	for _, nombre := range []string{ReglaTipoDocumento, ReglaItems, ReglaFechaEmision, ReglaTasaIGV} {
+		if posiciones[nombre] >= posiciones[ReglaAritmetica] {
+			t.Errorf("Expected %s to run before %s, got %v", nombre, ReglaAritmetica, reglas)
+		}
+	}
+
+	if posiciones[ReglaFormaPago] <= posiciones[ReglaAritmetica] {
+		t.Errorf("Expected %s to run after %s, got %v", ReglaFormaPago, ReglaAritmetica, reglas)
+	}
+}
+
+func TestConRegla(t *testing.T) {
+	aplicada := false
+	sinDescuentos := NuevaRegla("sinDescuentos", func(doc *domain.Document) error {
+		aplicada = true
+		if !doc.TotalDescuentos.EsCero() {
+			return violacionEn("/totalDescuentos", CodigoNoPermitido, "totalDescuentos no está permitido")
+		}
+		return nil
+	})
+	validador := NewDocumentValidator(ConRegla(sinDescuentos, ReglaAritmetica))
+
+	if reglas := validador.Reglas(); reglas[len(reglas)-1] != "sinDescuentos" {
+		t.Errorf("Expected sinDescuentos to be registered last, got %v", reglas)
+	}
+
+	if err := validador.ValidarDocumento(context.Background(), documentoBase(domain.TipoFactura, "F001-00000001")); err != nil || !aplicada {
+		t.Errorf("Expected the rule to run without errors, got applied=%v err=%v", aplicada, err)
+	}
+
+	aplicada = false
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.MontoTotal = money.MustParse("120.00")
+	err := validador.ValidarDocumento(context.Background(), doc)
+	if err == nil || aplicada {
+		t.Errorf("Expected the rule to be skipped when arithmetic fails, got applied=%v err=%v", aplicada, err)
+	}
+}
+
+func TestValidarDocumento_ReglasAdicionales(t *testing.T) {
+	adicional := NuevaRegla("negocio:prueba", func(doc *domain.Document) error {
+		return violacionEn("/montoTotal", CodigoReglaNegocio, "montoTotal incumple la regla prueba")
+	})
+
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), documentoBase(domain.TipoFactura, "F001-00000001"), adicional)
+	appErr, ok := err.(*errors.AppError)
+	if !ok || len(appErr.Errors) != 1 || appErr.Errors[0].Code != CodigoReglaNegocio {
+		t.Fatalf("Expected one REGLA_NEGOCIO error, got: %v", err)
+	}
+
+	if NewDocumentValidator().ValidarDocumento(context.Background(), documentoBase(domain.TipoFactura, "F001-00000001")) != nil {
+		t.Error("Expected additional rules to apply only to the call that receives them")
+	}
+}
+
+func TestConRegla_RegistroInvalido(t *testing.T) {
+	testCases := []struct {
+		name     string
+		regla    Regla
+		requiere []string
+	}{
+		{"Nombre repetido", NuevaRegla(ReglaMontos, func(*domain.Document) error { return nil }), nil},
+		{"Requisito desconocido", NuevaRegla("nueva", func(*domain.Document) error { return nil }), []string{"inexistente"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected registration to panic")
+				}
+			}()
+			NewDocumentValidator(ConRegla(tc.regla, tc.requiere...))
+		})
+	}
+}
+
+func TestValidarDocumento_OrdenCompra(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.OrdenCompra = "  OC-2026-0042 "
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if doc.OrdenCompra != "OC-2026-0042" {
+		t.Errorf("Expected trimmed ordenCompra, got %q", doc.OrdenCompra)
+	}
+
+	doc.OrdenCompra = "OC-2026-0042-ANEXO-001"
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Errors[0].Path != "/ordenCompra" {
+		t.Errorf("Expected error at /ordenCompra, got: %v", err)
+	}
+}
+
+func TestEvaluarDocumento(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.RucEmisor = "20123456780"
+	doc.MontoTotal = money.MustParse("120.00")
+	adicional := NuevaRegla("negocio:prueba", func(doc *domain.Document) error { return nil })
+
+	verificaciones, err := NewDocumentValidator().EvaluarDocumento(context.Background(), doc, adicional)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	resultados := make(map[string]domain.Verificacion)
+	for _, verificacion := range verificaciones {
+		resultados[verificacion.Nombre] = verificacion
+	}
+
+	testCases := []struct {
+		nombre    string
+		etapa     string
+		resultado string
+		errores   int
+	}{
+		{ReglaRucEmisor, domain.EtapaFormato, domain.ResultadoIncumplida, 1},
+		{ReglaItems, domain.EtapaFormato, domain.ResultadoCumplida, 0},
+		{ReglaAritmetica, domain.EtapaAritmetica, domain.ResultadoOmitida, 0},
+		{ReglaFormaPago, domain.EtapaFormato, domain.ResultadoOmitida, 0},
+		{"negocio:prueba", domain.EtapaReglasNegocio, domain.ResultadoOmitida, 0},
+	}
+
+	for _, tc := range testCases {
+		verificacion, ok := resultados[tc.nombre]
+		if !ok {
+			t.Errorf("Expected a result for %s", tc.nombre)
+			continue
+		}
+		if verificacion.Etapa != tc.etapa || verificacion.Resultado != tc.resultado || len(verificacion.Errores) != tc.errores {
+			t.Errorf("Expected %s to be %s %s with %d errors, got %s %s with %v", tc.nombre, tc.etapa, tc.resultado, tc.errores,
+				verificacion.Etapa, verificacion.Resultado, verificacion.Errores)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"ms1-documents/pkg/errors"
+	"strconv"
+)
+
+// Códigos de las reglas incumplidas que se informan en errors[].code
+const (
+	CodigoRequerido          = "REQUERIDO"
+	CodigoFormatoInvalido    = "FORMATO_INVALIDO"
+	CodigoDigitoVerificador  = "DIGITO_VERIFICADOR_INVALIDO"
+	CodigoFueraDeCatalogo    = "FUERA_DE_CATALOGO"
+	CodigoFueraDeRango       = "FUERA_DE_RANGO"
+	CodigoDecimalesExcedidos = "DECIMALES_EXCEDIDOS"
+	CodigoNoCoincide         = "NO_COINCIDE"
+	CodigoNoPermitido        = "NO_PERMITIDO"
+	CodigoSinDatosVigentes   = "SIN_DATOS_VIGENTES"
+	CodigoReglaNegocio       = "REGLA_NEGOCIO"
+	CodigoDuplicado          = "DUPLICADO"
+	CodigoInvalido           = "INVALIDO"
+)
+
+// violacionEn crea el error de una regla incumplida en el campo que indica ruta, un JSON pointer al
+// cuerpo de la solicitud con los mismos índices base 0 de los mensajes
+func violacionEn(ruta, codigo, mensaje string) error {
+	return errors.ErrorValidacionCampos([]errors.FieldError{{Path: ruta, Code: codigo, Message: mensaje}})
+}
+
+// rutaItem es el JSON pointer de un campo del item indice
+func rutaItem(indice int, campo string) string {
+	return "/items/" + strconv.Itoa(indice) + "/" + campo
+}
+
+// violaciones acumula los errores de varias reglas para devolverlos en una sola respuesta
+type violaciones struct {
+	errores []errors.FieldError
+}
+
+// agregar incorpora el error de una regla e indica si la regla falló. Un error sin campos se informa
+// en la raíz del documento.
+func (v *violaciones) agregar(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok && len(appErr.Errors) > 0 {
+		v.errores = append(v.errores, appErr.Errors...)
+		return true
+	}
+	v.errores = append(v.errores, errors.FieldError{Code: CodigoInvalido, Message: err.Error()})
+	return true
+}
+
+func (v *violaciones) vacio() bool {
+	return len(v.errores) == 0
+}
+
+func (v *violaciones) error() error {
+	if v.vacio() {
+		return nil
+	}
+	return errors.ErrorValidacionCampos(v.errores)
+}
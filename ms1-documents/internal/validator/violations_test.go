@@ -0,0 +1,239 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/errors"
+	"ms1-documents/pkg/money"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidarDocumento_AcumulaViolaciones(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.RucEmisor = "20123456780"
+	doc.Items[0].Cantidad = money.Money{}
+	doc.Items[0].CargosDescuentos = []domain.CargoDescuento{{Codigo: "99", Monto: money.MustParse("5.00")}}
+	doc.Items = append(doc.Items, domain.Item{
+		Descripcion:    "Item 2",
+		PrecioUnitario: money.MustParse("10.00"),
+		Cantidad:       money.NewFromInt(1),
+		PrecioTotal:    money.MustParse("10.001"),
+	})
+
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		t.Fatalf("Expected AppError, got: %v", err)
+	}
+
+	if appErr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, appErr.Code)
+	}
+
+	esperados := []errors.FieldError{
+		{Path: "/rucEmisor", Code: CodigoDigitoVerificador},
+		{Path: "/items/0/cantidad", Code: CodigoFueraDeRango},
+		{Path: "/items/0/cargosDescuentos/0/codigo", Code: CodigoFueraDeCatalogo},
+		{Path: "/items/1/precioTotal", Code: CodigoDecimalesExcedidos},
+	}
+
+	if len(appErr.Errors) != len(esperados) {
+		t.Fatalf("Expected %d errors, got %d: %+v", len(esperados), len(appErr.Errors), appErr.Errors)
+	}
+
+	for indice, esperado := range esperados {
+		recibido := appErr.Errors[indice]
+		if recibido.Path != esperado.Path || recibido.Code != esperado.Code {
+			t.Errorf("Expected %s %s, got %s %s (%s)", esperado.Path, esperado.Code, recibido.Path, recibido.Code, recibido.Message)
+		}
+	}
+}
+
+func TestValidarDocumento_AcumulaDescuadres(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.Items[0].IgvTotal = money.MustParse("17.50")
+	doc.MontoTotal = money.MustParse("120.00")
+
+	err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		t.Fatalf("Expected AppError, got: %v", err)
+	}
+
+	rutas := map[string]bool{}
+	for _, violacion := range appErr.Errors {
+		if violacion.Code != CodigoNoCoincide {
+			t.Errorf("Expected code %s, got %s for %s", CodigoNoCoincide, violacion.Code, violacion.Path)
+		}
+		rutas[violacion.Path] = true
+	}
+
+	for _, ruta := range []string{"/items/0/igvTotal", "/montoTotal"} {
+		if !rutas[ruta] {
+			t.Errorf("Expected error at %s, got %+v", ruta, appErr.Errors)
+		}
+	}
+}
+
+// TestValidarDocumento_RutaPorRegla comprueba la ruta y el código de cada regla sin mirar el mensaje
+func TestValidarDocumento_RutaPorRegla(t *testing.T) {
+	testCases := []struct {
+		name      string
+		documento func() *domain.Document
+		ruta      string
+		codigo    string
+	}{
+		{"tipoDocumento", func() *domain.Document { return documentoBase("99", "F001-00000001") }, "/tipoDocumento", CodigoFueraDeCatalogo},
+		{"idDocumento", func() *domain.Document { return documentoBase(domain.TipoFactura, "B001-00000001") }, "/idDocumento", CodigoFormatoInvalido},
+		{"serie", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Serie = "F002"
+			return doc
+		}, "/serie", CodigoNoCoincide},
+		{"rucEmisor", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.RucEmisor = "2012345678"
+			return doc
+		}, "/rucEmisor", CodigoFormatoInvalido},
+		{"tipoDocumentoReceptor", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.TipoDocumentoReceptor = domain.IdentidadDNI
+			return doc
+		}, "/tipoDocumentoReceptor", CodigoNoPermitido},
+		{"rucReceptor", func() *domain.Document {
+			doc := documentoBase(domain.TipoBoleta, "B001-00000001")
+			doc.TipoDocumentoReceptor = domain.IdentidadDNI
+			doc.RucReceptor = "123"
+			return doc
+		}, "/rucReceptor", CodigoFormatoInvalido},
+		{"receptor.email", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Receptor = &domain.DatosReceptor{Nombre: "Cliente", Email: "sin-arroba"}
+			return doc
+		}, "/receptor/email", CodigoFormatoInvalido},
+		{"referencia", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Referencia = &domain.DocumentoReferencia{}
+			return doc
+		}, "/referencia", CodigoNoPermitido},
+		{"referencia.codigoMotivo", func() *domain.Document {
+			doc := documentoBase(domain.TipoNotaCredito, "F001-00000002")
+			doc.Referencia = &domain.DocumentoReferencia{TipoDocumento: domain.TipoFactura, IDDocumento: "F001-00000001", CodigoMotivo: "99"}
+			return doc
+		}, "/referencia/codigoMotivo", CodigoFueraDeCatalogo},
+		{"ordenCompra", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.OrdenCompra = strings.Repeat("9", longitudMaximaOrdenCompra+1)
+			return doc
+		}, "/ordenCompra", CodigoFueraDeRango},
+		{"montoTotal", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.MontoTotal = money.Money{}
+			return doc
+		}, "/montoTotal", CodigoFueraDeRango},
+		{"igvTotal con decimales", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.IgvTotal = money.MustParse("18.001")
+			return doc
+		}, "/igvTotal", CodigoDecimalesExcedidos},
+		{"items vacío", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Items = nil
+			return doc
+		}, "/items", CodigoRequerido},
+		{"unidadMedida del item", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Items[0].UnidadMedida = "XYZ"
+			return doc
+		}, "/items/0/unidadMedida", CodigoFueraDeCatalogo},
+		{"tipoAfectacionIgv del item", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Items[0].TipoAfectacionIgv = "99"
+			return doc
+		}, "/items/0/tipoAfectacionIgv", CodigoFueraDeCatalogo},
+		{"factor de cargo global", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.CargosDescuentos = []domain.CargoDescuento{{Codigo: "02", Factor: money.MustParse("1.5")}}
+			return doc
+		}, "/cargosDescuentos/0/factor", CodigoFueraDeRango},
+		{"fechaEmision", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.FechaEmision = "12/02/2026"
+			return doc
+		}, "/fechaEmision", CodigoFormatoInvalido},
+		{"moneda", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Moneda = "XXX"
+			return doc
+		}, "/moneda", CodigoFueraDeCatalogo},
+		{"regimenIgv", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.RegimenIgv = "OTRO"
+			return doc
+		}, "/regimenIgv", CodigoFueraDeCatalogo},
+		{"precioTotal del item", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Items[0].PrecioTotal = money.MustParse("90.00")
+			return doc
+		}, "/items/0/precioTotal", CodigoNoCoincide},
+		{"igvTotal", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.IgvTotal = money.MustParse("17.00")
+			doc.MontoTotal = money.MustParse("117.00")
+			return doc
+		}, "/igvTotal", CodigoNoCoincide},
+		{"detraccion.cuentaBancoNacion", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+			doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "123"}
+			return doc
+		}, "/detraccion/cuentaBancoNacion", CodigoFormatoInvalido},
+		{"percepcion.codigo", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+			doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+			doc.Percepcion = &domain.Percepcion{Codigo: "99"}
+			return doc
+		}, "/percepcion/codigo", CodigoFueraDeCatalogo},
+		{"retencion.monto", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+			doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+			doc.Retencion = &domain.Retencion{Monto: money.MustParse("1.00")}
+			return doc
+		}, "/retencion/monto", CodigoNoCoincide},
+		{"fechaVencimiento de cuota", func() *domain.Document {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.FechaEmision = "2026-02-12T10:00:00-05:00"
+			doc.FormaPago = &domain.FormaPago{
+				Tipo: "credito",
+				Cuotas: []domain.Cuota{
+					{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-03-14"},
+					{Monto: money.MustParse("59.00"), FechaVencimiento: "2026-04-13"},
+				},
+			}
+			doc.FormaPago.Cuotas[1].FechaVencimiento = "13/04/2026"
+			return doc
+		}, "/formaPago/cuotas/1/fechaVencimiento", CodigoFormatoInvalido},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), tc.documento())
+			appErr, ok := err.(*errors.AppError)
+			if !ok || len(appErr.Errors) == 0 {
+				t.Fatalf("Expected field errors, got: %v", err)
+			}
+
+			for _, recibido := range appErr.Errors {
+				if recibido.Path == tc.ruta && recibido.Code == tc.codigo {
+					return
+				}
+			}
+			t.Errorf("Expected %s %s, got %+v", tc.ruta, tc.codigo, appErr.Errors)
+		})
+	}
+}
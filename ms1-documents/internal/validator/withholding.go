@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"fmt"
+	"ms1-documents/internal/catalog"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"regexp"
+	"strings"
+)
+
+var cuentaBancoNacionRegex = regexp.MustCompile(`^[0-9]{11}$`)
+
+// validarDetraccion comprueba el código del catálogo 54 y la cuenta del Banco de la Nación, exige que el
+// importe de la operación en soles supere el umbral y calcula el monto a depositar, redondeado sin decimales.
+// El porcentaje y el monto se completan si el cliente los omite.
+func (v *DocumentValidator) validarDetraccion(doc *domain.Document) error {
+	detraccion := doc.Detraccion
+	if detraccion == nil {
+		return nil
+	}
+
+	if doc.TipoDocumento != domain.TipoFactura {
+		return violacionEn("/detraccion", CodigoNoPermitido, "detraccion solo aplica a facturas")
+	}
+
+	detraccion.Codigo = strings.TrimSpace(detraccion.Codigo)
+	bienServicio, ok := catalog.Detraccion(detraccion.Codigo)
+	if !ok {
+		return violacionEn("/detraccion/codigo", CodigoFueraDeCatalogo, fmt.Sprintf("detraccion.codigo %q no existe en el catálogo 54", detraccion.Codigo))
+	}
+
+	if err := completarPorcentaje(&detraccion.Porcentaje, bienServicio.Porcentaje, "/detraccion/porcentaje", "detraccion.porcentaje", "para el código "+detraccion.Codigo); err != nil {
+		return err
+	}
+
+	importe := doc.ConvertirASoles(doc.MontoTotal)
+	umbral := bienServicio.UmbralAplicable()
+	if importe.Comparar(umbral) <= 0 {
+		return violacionEn("/detraccion", CodigoNoPermitido, fmt.Sprintf("detraccion no aplica: el importe de la operación S/ %s no supera S/ %s",
+			importe.StringFijo(money.DecimalesMonto), umbral.StringFijo(money.DecimalesMonto)))
+	}
+
+	esperado := importe.MultiplicarRedondeado(detraccion.Porcentaje, 0, money.RedondeoMitadArriba)
+	if err := completarMonto(&detraccion.Monto, esperado, "/detraccion/monto", "detraccion.monto"); err != nil {
+		return err
+	}
+
+	cuenta := strings.ReplaceAll(strings.TrimSpace(detraccion.CuentaBancoNacion), "-", "")
+	if !cuentaBancoNacionRegex.MatchString(cuenta) {
+		return violacionEn("/detraccion/cuentaBancoNacion", CodigoFormatoInvalido, "detraccion.cuentaBancoNacion debe tener 11 dígitos")
+	}
+	detraccion.CuentaBancoNacion = cuenta
+
+	return nil
+}
+
+// validarPercepcion calcula la percepción sobre montoTotal según el régimen del catálogo 53 y el total
+// cobrado. La percepción solo se liquida en soles y no se combina con la detracción.
+func (v *DocumentValidator) validarPercepcion(doc *domain.Document) error {
+	percepcion := doc.Percepcion
+	if percepcion == nil {
+		return nil
+	}
+
+	if doc.TipoDocumento != domain.TipoFactura && doc.TipoDocumento != domain.TipoBoleta {
+		return violacionEn("/percepcion", CodigoNoPermitido, "percepcion solo aplica a facturas y boletas")
+	}
+	if doc.CodigoMoneda() != domain.MonedaPEN {
+		return violacionEn("/percepcion", CodigoNoPermitido, "percepcion solo aplica a operaciones en soles")
+	}
+	if doc.Detraccion != nil {
+		return violacionEn("/percepcion", CodigoNoPermitido, "percepcion no aplica a operaciones sujetas a detracción")
+	}
+
+	percepcion.Codigo = strings.TrimSpace(percepcion.Codigo)
+	porcentaje, ok := domain.PorcentajesPercepcion[percepcion.Codigo]
+	if !ok {
+		return violacionEn("/percepcion/codigo", CodigoFueraDeCatalogo, "percepcion.codigo inválido. Debe ser 51, 52 o 53 del catálogo 53")
+	}
+
+	if err := completarPorcentaje(&percepcion.Porcentaje, porcentaje, "/percepcion/porcentaje", "percepcion.porcentaje", "para el código "+percepcion.Codigo); err != nil {
+		return err
+	}
+	if err := completarMonto(&percepcion.MontoBase, doc.MontoTotal, "/percepcion/montoBase", "percepcion.montoBase"); err != nil {
+		return err
+	}
+
+	esperado := percepcion.MontoBase.MultiplicarRedondeado(percepcion.Porcentaje, money.DecimalesMonto, money.RedondeoMitadArriba)
+	if err := completarMonto(&percepcion.Monto, esperado, "/percepcion/monto", "percepcion.monto"); err != nil {
+		return err
+	}
+
+	return completarMonto(&percepcion.MontoTotalCobrado, percepcion.MontoBase.Sumar(percepcion.Monto), "/percepcion/montoTotalCobrado", "percepcion.montoTotalCobrado")
+}
+
+// validarRetencion calcula la retención del IGV que aplica un cliente agente de retención. Solo procede en
+// facturas a un RUC cuyo importe en soles supere el umbral, y no en operaciones sujetas a detracción.
+func (v *DocumentValidator) validarRetencion(doc *domain.Document) error {
+	retencion := doc.Retencion
+	if retencion == nil {
+		return nil
+	}
+
+	if doc.TipoDocumento != domain.TipoFactura {
+		return violacionEn("/retencion", CodigoNoPermitido, "retencion solo aplica a facturas")
+	}
+	if doc.Detraccion != nil {
+		return violacionEn("/retencion", CodigoNoPermitido, "retencion no aplica a operaciones sujetas a detracción")
+	}
+
+	if err := completarPorcentaje(&retencion.Porcentaje, domain.PorcentajeRetencion, "/retencion/porcentaje", "retencion.porcentaje", "en el régimen de retenciones del IGV"); err != nil {
+		return err
+	}
+
+	importe := doc.ConvertirASoles(doc.MontoTotal)
+	if importe.Comparar(domain.UmbralRetencion) <= 0 {
+		return violacionEn("/retencion", CodigoNoPermitido, fmt.Sprintf("retencion no aplica: el importe de la operación S/ %s no supera S/ %s",
+			importe.StringFijo(money.DecimalesMonto), domain.UmbralRetencion.StringFijo(money.DecimalesMonto)))
+	}
+	if err := completarMonto(&retencion.MontoBase, importe, "/retencion/montoBase", "retencion.montoBase"); err != nil {
+		return err
+	}
+
+	esperado := retencion.MontoBase.MultiplicarRedondeado(retencion.Porcentaje, money.DecimalesMonto, money.RedondeoMitadArriba)
+	return completarMonto(&retencion.Monto, esperado, "/retencion/monto", "retencion.monto")
+}
+
+// completarPorcentaje asigna el porcentaje vigente si se omite y rechaza uno distinto
+func completarPorcentaje(valor *money.Money, vigente money.Money, ruta, nombreCampo, contexto string) error {
+	if valor.EsCero() {
+		*valor = vigente
+		return nil
+	}
+	if !valor.Igual(vigente) {
+		return violacionEn(ruta, CodigoNoCoincide, fmt.Sprintf("%s debe ser %s %s, se recibió %s", nombreCampo, vigente, contexto, valor))
+	}
+	return nil
+}
+
+// completarMonto asigna el importe calculado si se omite y rechaza uno distinto
+func completarMonto(valor *money.Money, esperado money.Money, ruta, nombreCampo string) error {
+	if valor.EsCero() {
+		*valor = esperado
+		return nil
+	}
+	if !valor.Igual(esperado) {
+		return violacionEn(ruta, CodigoNoCoincide, fmt.Sprintf("%s debe ser %s, se recibió %s", nombreCampo,
+			esperado.StringFijo(money.DecimalesMonto), valor.StringFijo(money.DecimalesMonto)))
+	}
+	return nil
+}
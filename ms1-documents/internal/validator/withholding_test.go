@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"context"
+	"ms1-documents/internal/domain"
+	"ms1-documents/pkg/money"
+	"strings"
+	"testing"
+)
+
+func TestDetraccion_CompletaPorcentajeYMonto(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+	doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+	doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "00-741-123456"}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !doc.Detraccion.Porcentaje.Igual(money.MustParse("0.12")) {
+		t.Errorf("Expected porcentaje 0.12, got %s", doc.Detraccion.Porcentaje)
+	}
+
+	if !doc.Detraccion.Monto.Igual(money.MustParse("142")) {
+		t.Errorf("Expected monto 142 (141.60 rounded), got %s", doc.Detraccion.Monto)
+	}
+
+	if doc.Detraccion.CuentaBancoNacion != "00741123456" {
+		t.Errorf("Expected account without hyphens, got %s", doc.Detraccion.CuentaBancoNacion)
+	}
+}
+
+func TestDetraccion_MonedaExtranjera(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+	doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+	doc.Moneda = domain.MonedaUSD
+	doc.TipoCambio = money.MustParse("3.700")
+	doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "00741123456"}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// 1180.00 USD × 3.700 = 4366.00 soles; 12% = 523.92
+	if !doc.Detraccion.Monto.Igual(money.MustParse("524")) {
+		t.Errorf("Expected monto 524 soles, got %s", doc.Detraccion.Monto)
+	}
+}
+
+func TestPercepcion_CalculaTotalCobrado(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+	doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+	doc.Percepcion = &domain.Percepcion{Codigo: "51"}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !doc.Percepcion.Monto.Igual(money.MustParse("23.60")) {
+		t.Errorf("Expected monto 23.60, got %s", doc.Percepcion.Monto)
+	}
+
+	if !doc.Percepcion.MontoTotalCobrado.Igual(money.MustParse("1203.60")) {
+		t.Errorf("Expected montoTotalCobrado 1203.60, got %s", doc.Percepcion.MontoTotalCobrado)
+	}
+}
+
+func TestRetencion_DescuentaDelNetoPendiente(t *testing.T) {
+	doc := documentoBase(domain.TipoFactura, "F001-00000001")
+	doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+	doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+	doc.FechaEmision = "2026-02-12T10:00:00-05:00"
+	doc.Retencion = &domain.Retencion{}
+	doc.FormaPago = &domain.FormaPago{
+		Tipo:   domain.FormaPagoCredito,
+		Cuotas: []domain.Cuota{{Monto: money.MustParse("1144.60"), FechaVencimiento: "2026-03-14"}},
+	}
+
+	if err := NewDocumentValidator().ValidarDocumento(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !doc.Retencion.Monto.Igual(money.MustParse("35.40")) {
+		t.Errorf("Expected monto 35.40, got %s", doc.Retencion.Monto)
+	}
+
+	if !doc.FormaPago.MontoPendiente.Igual(money.MustParse("1144.60")) {
+		t.Errorf("Expected montoPendiente 1144.60, got %s", doc.FormaPago.MontoPendiente)
+	}
+}
+
+func TestDetraccionPercepcionRetencion_Invalidas(t *testing.T) {
+	testCases := []struct {
+		name      string
+		modificar func(doc *domain.Document)
+		mensaje   string
+	}{
+		{"Detracción bajo el umbral", func(doc *domain.Document) {
+			*doc = *documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "00741123456"}
+		}, "detraccion no aplica: el importe de la operación S/ 118.00 no supera S/ 700.00"},
+		{"Detracción en boleta", func(doc *domain.Document) {
+			doc.TipoDocumento, doc.IDDocumento = domain.TipoBoleta, "B001-00000001"
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "00741123456"}
+		}, "detraccion solo aplica a facturas"},
+		{"Código fuera del catálogo 54", func(doc *domain.Document) {
+			doc.Detraccion = &domain.Detraccion{Codigo: "999", CuentaBancoNacion: "00741123456"}
+		}, `detraccion.codigo "999" no existe en el catálogo 54`},
+		{"Porcentaje distinto del vigente", func(doc *domain.Document) {
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", Porcentaje: money.MustParse("0.10"), CuentaBancoNacion: "00741123456"}
+		}, "detraccion.porcentaje debe ser 0.12 para el código 037"},
+		{"Monto de detracción distinto", func(doc *domain.Document) {
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", Monto: money.MustParse("141.60"), CuentaBancoNacion: "00741123456"}
+		}, "detraccion.monto debe ser 142.00, se recibió 141.60"},
+		{"Cuenta inválida", func(doc *domain.Document) {
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "1234"}
+		}, "detraccion.cuentaBancoNacion debe tener 11 dígitos"},
+		{"Percepción en dólares", func(doc *domain.Document) {
+			doc.Moneda, doc.TipoCambio = domain.MonedaUSD, money.MustParse("3.700")
+			doc.Percepcion = &domain.Percepcion{Codigo: "51"}
+		}, "percepcion solo aplica a operaciones en soles"},
+		{"Percepción con régimen inválido", func(doc *domain.Document) {
+			doc.Percepcion = &domain.Percepcion{Codigo: "62"}
+		}, "percepcion.codigo inválido"},
+		{"Percepción con detracción", func(doc *domain.Document) {
+			doc.Detraccion = &domain.Detraccion{Codigo: "037", CuentaBancoNacion: "00741123456"}
+			doc.Percepcion = &domain.Percepcion{Codigo: "51"}
+		}, "percepcion no aplica a operaciones sujetas a detracción"},
+		{"Retención bajo el umbral", func(doc *domain.Document) {
+			*doc = *documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.Retencion = &domain.Retencion{}
+		}, "retencion no aplica"},
+		{"Retención con monto distinto", func(doc *domain.Document) {
+			doc.Retencion = &domain.Retencion{Monto: money.MustParse("35.00")}
+		}, "retencion.monto debe ser 35.40, se recibió 35.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := documentoBase(domain.TipoFactura, "F001-00000001")
+			doc.MontoTotalSinImpuestos, doc.IgvTotal, doc.MontoTotal = money.MustParse("1000.00"), money.MustParse("180.00"), money.MustParse("1180.00")
+			doc.Items[0].Cantidad, doc.Items[0].PrecioTotal, doc.Items[0].IgvTotal = money.NewFromInt(20), money.MustParse("1000.00"), money.MustParse("180.00")
+			tc.modificar(doc)
+
+			err := NewDocumentValidator().ValidarDocumento(context.Background(), doc)
+			if err == nil || !strings.Contains(err.Error(), tc.mensaje) {
+				t.Errorf("Expected error containing %q, got: %v", tc.mensaje, err)
+			}
+		})
+	}
+}
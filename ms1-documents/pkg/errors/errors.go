@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // AppError representa un error de aplicación
@@ -10,6 +11,16 @@ type AppError struct {
 	Code      int    `json:"status" example:"400"`
 	ErrorType string `json:"error" example:"Bad Request"`
 	Message   string `json:"message" example:"Error en la solicitud"`
+	// Errors detalla cada regla incumplida en los errores de validación del documento
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError es una regla de validación incumplida. Path es un JSON pointer (RFC 6901) al campo del
+// cuerpo de la solicitud y Code un identificador estable para que los clientes no dependan del mensaje.
+type FieldError struct {
+	Path    string `json:"path" example:"/items/3/precioTotal"`
+	Code    string `json:"code" example:"NO_COINCIDE"`
+	Message string `json:"message" example:"precioTotal del item 3 no coincide con precioUnitario × cantidad. Esperado: 100.00, recibido: 90.00"`
 }
 
 func (e *AppError) Error() string {
@@ -17,11 +28,15 @@ func (e *AppError) Error() string {
 }
 
 func (e *AppError) AJson() map[string]interface{} {
-	return map[string]interface{}{
+	respuesta := map[string]interface{}{
 		"status":  e.Code,
 		"error":   e.ErrorType,
 		"message": e.Message,
 	}
+	if len(e.Errors) > 0 {
+		respuesta["errors"] = e.Errors
+	}
+	return respuesta
 }
 
 func ErrorValidacion(mensaje string) *AppError {
@@ -32,6 +47,23 @@ func ErrorValidacion(mensaje string) *AppError {
 	}
 }
 
+// ErrorValidacionCampos agrupa las reglas incumplidas en un solo 400. Con un único error el mensaje es
+// el de esa regla; con varios, el mensaje los enumera para los clientes que solo leen message.
+func ErrorValidacionCampos(errores []FieldError) *AppError {
+	mensaje := errores[0].Message
+	if len(errores) > 1 {
+		mensajes := make([]string, len(errores))
+		for indice, err := range errores {
+			mensajes[indice] = err.Message
+		}
+		mensaje = fmt.Sprintf("%d errores de validación: %s", len(errores), strings.Join(mensajes, "; "))
+	}
+
+	appErr := ErrorValidacion(mensaje)
+	appErr.Errors = errores
+	return appErr
+}
+
 func ErrorNoEncontrado(mensaje string) *AppError {
 	return &AppError{
 		Code:      http.StatusNotFound,
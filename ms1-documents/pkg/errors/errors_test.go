@@ -192,3 +192,28 @@ func TestAppError_ToJSON_HasAllFields(t *testing.T) {
 		t.Errorf("ToJSON() should have exactly %d fields, got %d", len(requiredFields), len(json))
 	}
 }
+
+func TestErrorValidacionCampos(t *testing.T) {
+	unico := ErrorValidacionCampos([]FieldError{{Path: "/rucEmisor", Code: "FORMATO_INVALIDO", Message: "rucEmisor debe tener 11 dígitos"}})
+	if unico.Code != http.StatusBadRequest || unico.Message != "rucEmisor debe tener 11 dígitos" {
+		t.Errorf("Expected 400 with the only message, got %d '%s'", unico.Code, unico.Message)
+	}
+
+	varios := ErrorValidacionCampos([]FieldError{
+		{Path: "/rucEmisor", Code: "FORMATO_INVALIDO", Message: "rucEmisor debe tener 11 dígitos"},
+		{Path: "/items/0/precioTotal", Code: "NO_COINCIDE", Message: "precioTotal del item 0 no coincide"},
+	})
+	esperado := "2 errores de validación: rucEmisor debe tener 11 dígitos; precioTotal del item 0 no coincide"
+	if varios.Message != esperado {
+		t.Errorf("Expected message '%s', got '%s'", esperado, varios.Message)
+	}
+
+	json := varios.AJson()
+	if errores, ok := json["errors"].([]FieldError); !ok || len(errores) != 2 || errores[1].Path != "/items/0/precioTotal" {
+		t.Errorf("Expected errors in JSON response, got %v", json["errors"])
+	}
+
+	if _, ok := ErrorValidacion("simple").AJson()["errors"]; ok {
+		t.Error("Expected no errors key for a plain validation error")
+	}
+}
@@ -0,0 +1,132 @@
+package letras
+
+import (
+	"fmt"
+	"ms1-documents/pkg/money"
+	"strconv"
+	"strings"
+)
+
+var (
+	unidades = [...]string{
+		"CERO", "UNO", "DOS", "TRES", "CUATRO", "CINCO", "SEIS", "SIETE", "OCHO", "NUEVE",
+		"DIEZ", "ONCE", "DOCE", "TRECE", "CATORCE", "QUINCE", "DIECISEIS", "DIECISIETE", "DIECIOCHO", "DIECINUEVE",
+		"VEINTE", "VEINTIUNO", "VEINTIDOS", "VEINTITRES", "VEINTICUATRO", "VEINTICINCO", "VEINTISEIS", "VEINTISIETE", "VEINTIOCHO", "VEINTINUEVE",
+	}
+	decenas  = [...]string{"", "", "", "TREINTA", "CUARENTA", "CINCUENTA", "SESENTA", "SETENTA", "OCHENTA", "NOVENTA"}
+	centenas = [...]string{"", "CIENTO", "DOSCIENTOS", "TRESCIENTOS", "CUATROCIENTOS", "QUINIENTOS", "SEISCIENTOS", "SETECIENTOS", "OCHOCIENTOS", "NOVECIENTOS"}
+)
+
+const (
+	millon = 1_000_000
+	billon = 1_000_000 * millon
+)
+
+// Monedas con nombre en las leyendas de monto en letras
+const (
+	MonedaPEN = "PEN"
+	MonedaUSD = "USD"
+	MonedaEUR = "EUR"
+)
+
+var nombresMoneda = map[string]string{
+	MonedaPEN: "SOLES",
+	MonedaUSD: "DOLARES AMERICANOS",
+	MonedaEUR: "EUROS",
+}
+
+// Numero escribe un entero en palabras, en mayúsculas y sin tildes como en las leyendas de SUNAT
+func Numero(n int64) string {
+	if n < 0 {
+		return "MENOS " + Numero(-n)
+	}
+	if n == 0 {
+		return unidades[0]
+	}
+	return strings.Join(palabras(n, false), " ")
+}
+
+// MontoEnLetras escribe un importe con los céntimos en fracción y el nombre de la moneda, como lo pide
+// la leyenda 1000 de SUNAT: "MIL CIENTO OCHENTA CON 00/100 SOLES"
+func MontoEnLetras(monto money.Money, moneda string) (string, error) {
+	nombre, ok := nombresMoneda[moneda]
+	if !ok {
+		return "", fmt.Errorf("moneda %q no soportada para el monto en letras", moneda)
+	}
+
+	texto := monto.Redondear(money.DecimalesMonto, money.RedondeoMitadArriba).StringFijo(money.DecimalesMonto)
+	entero, centimos, _ := strings.Cut(strings.TrimPrefix(texto, "-"), ".")
+	valor, err := strconv.ParseInt(entero, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	resultado := fmt.Sprintf("%s CON %s/100 %s", Numero(valor), centimos, nombre)
+	if monto.EsNegativo() {
+		resultado = "MENOS " + resultado
+	}
+	return resultado, nil
+}
+
+// palabras descompone en billones, millones y miles; apocope indica que el número precede a un
+// sustantivo ("VEINTIUN MIL", "UN MILLON") y por eso "UNO" pierde la última vocal
+func palabras(n int64, apocope bool) []string {
+	var resultado []string
+	if b := n / billon; b > 0 {
+		resultado = append(resultado, escala(b, "BILLON", "BILLONES")...)
+	}
+	if m := n / millon % millon; m > 0 {
+		resultado = append(resultado, escala(m, "MILLON", "MILLONES")...)
+	}
+	return append(resultado, miles(int(n%millon), apocope)...)
+}
+
+func escala(n int64, singular, plural string) []string {
+	if n == 1 {
+		return []string{"UN", singular}
+	}
+	return append(miles(int(n), true), plural)
+}
+
+func miles(n int, apocope bool) []string {
+	var resultado []string
+	switch mil := n / 1000; {
+	case mil == 1:
+		resultado = append(resultado, "MIL")
+	case mil > 1:
+		resultado = append(append(resultado, centena(mil, true)...), "MIL")
+	}
+	if resto := n % 1000; resto > 0 {
+		resultado = append(resultado, centena(resto, apocope)...)
+	}
+	return resultado
+}
+
+func centena(n int, apocope bool) []string {
+	if n == 100 {
+		return []string{"CIEN"}
+	}
+
+	var resultado []string
+	if c := n / 100; c > 0 {
+		resultado = append(resultado, centenas[c])
+	}
+
+	switch r := n % 100; {
+	case r == 0:
+	case r < 30:
+		resultado = append(resultado, unidad(r, apocope))
+	case r%10 == 0:
+		resultado = append(resultado, decenas[r/10])
+	default:
+		resultado = append(resultado, decenas[r/10], "Y", unidad(r%10, apocope))
+	}
+	return resultado
+}
+
+func unidad(n int, apocope bool) string {
+	if apocope && n%10 == 1 && n != 11 {
+		return strings.TrimSuffix(unidades[n], "O")
+	}
+	return unidades[n]
+}
@@ -0,0 +1,73 @@
+package letras
+
+import (
+	"ms1-documents/pkg/money"
+	"testing"
+)
+
+func TestNumero(t *testing.T) {
+	testCases := []struct {
+		numero   int64
+		esperado string
+	}{
+		{0, "CERO"},
+		{1, "UNO"},
+		{15, "QUINCE"},
+		{21, "VEINTIUNO"},
+		{31, "TREINTA Y UNO"},
+		{100, "CIEN"},
+		{101, "CIENTO UNO"},
+		{580, "QUINIENTOS OCHENTA"},
+		{1000, "MIL"},
+		{1180, "MIL CIENTO OCHENTA"},
+		{21000, "VEINTIUN MIL"},
+		{100000, "CIEN MIL"},
+		{131001, "CIENTO TREINTA Y UN MIL UNO"},
+		{1000000, "UN MILLON"},
+		{2500000, "DOS MILLONES QUINIENTOS MIL"},
+		{21000021, "VEINTIUN MILLONES VEINTIUNO"},
+		{1000000000, "MIL MILLONES"},
+		{3250000000, "TRES MIL DOSCIENTOS CINCUENTA MILLONES"},
+		{1000000000000, "UN BILLON"},
+	}
+
+	for _, tc := range testCases {
+		if resultado := Numero(tc.numero); resultado != tc.esperado {
+			t.Errorf("Expected %q for %d, got %q", tc.esperado, tc.numero, resultado)
+		}
+	}
+}
+
+func TestMontoEnLetras(t *testing.T) {
+	testCases := []struct {
+		monto    string
+		moneda   string
+		esperado string
+	}{
+		{"1180", MonedaPEN, "MIL CIENTO OCHENTA CON 00/100 SOLES"},
+		{"0.5", MonedaPEN, "CERO CON 50/100 SOLES"},
+		{"1.005", MonedaPEN, "UNO CON 01/100 SOLES"},
+		{"99.999", MonedaPEN, "CIEN CON 00/100 SOLES"},
+		{"21.10", MonedaUSD, "VEINTIUNO CON 10/100 DOLARES AMERICANOS"},
+		{"1234567890.12", MonedaPEN, "MIL DOSCIENTOS TREINTA Y CUATRO MILLONES QUINIENTOS SESENTA Y SIETE MIL OCHOCIENTOS NOVENTA CON 12/100 SOLES"},
+		{"999999999999.99", MonedaUSD, "NOVECIENTOS NOVENTA Y NUEVE MIL NOVECIENTOS NOVENTA Y NUEVE MILLONES NOVECIENTOS NOVENTA Y NUEVE MIL NOVECIENTOS NOVENTA Y NUEVE CON 99/100 DOLARES AMERICANOS"},
+		{"-50", MonedaPEN, "MENOS CINCUENTA CON 00/100 SOLES"},
+		{"250.75", MonedaEUR, "DOSCIENTOS CINCUENTA CON 75/100 EUROS"},
+	}
+
+	for _, tc := range testCases {
+		resultado, err := MontoEnLetras(money.MustParse(tc.monto), tc.moneda)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if resultado != tc.esperado {
+			t.Errorf("Expected %q for %s %s, got %q", tc.esperado, tc.monto, tc.moneda, resultado)
+		}
+	}
+}
+
+func TestMontoEnLetras_MonedaNoSoportada(t *testing.T) {
+	if _, err := MontoEnLetras(money.MustParse("10"), "JPY"); err == nil {
+		t.Error("Expected error for unsupported currency")
+	}
+}
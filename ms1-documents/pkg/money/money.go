@@ -0,0 +1,358 @@
+package money
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// Decimales es la precisión interna con la que se almacena un Money
+	Decimales = 6
+	// DecimalesMonto es la precisión de los importes que se reportan a SUNAT
+	DecimalesMonto = 2
+)
+
+// MaximoAbsoluto es el mayor valor absoluto que admite Parse. Con él, los productos y las sumas de los
+// importes de un documento caben en el Decimal128 con que se guardan en MongoDB.
+const MaximoAbsoluto = 1_000_000_000_000
+
+var (
+	escala = big.NewInt(1_000_000)
+	// cero es el valor de un Money sin inicializar; ninguna operación lo modifica
+	cero   = new(big.Int)
+	maximo = new(big.Int).Mul(big.NewInt(MaximoAbsoluto), escala)
+	// formatoDecimal deja fuera fracciones, exponentes, hexadecimales y separadores que big.Rat sí acepta
+	formatoDecimal = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+)
+
+// ModoRedondeo define cómo se descartan los decimales sobrantes
+type ModoRedondeo string
+
+const (
+	RedondeoMitadArriba ModoRedondeo = "HALF_UP"
+	RedondeoMitadPar    ModoRedondeo = "HALF_EVEN"
+	RedondeoAbajo       ModoRedondeo = "DOWN"
+	RedondeoArriba      ModoRedondeo = "UP"
+)
+
+func ParseModoRedondeo(valor string) (ModoRedondeo, error) {
+	modo := ModoRedondeo(strings.ToUpper(strings.TrimSpace(valor)))
+	switch modo {
+	case RedondeoMitadArriba, RedondeoMitadPar, RedondeoAbajo, RedondeoArriba:
+		return modo, nil
+	}
+	return "", fmt.Errorf("modo de redondeo desconocido: %s", valor)
+}
+
+// Money es un importe decimal de punto fijo con Decimales posiciones. Las operaciones son exactas y no
+// se desbordan; el valor cero no necesita inicializarse.
+type Money struct {
+	// unidades es el importe multiplicado por 10^Decimales; nil equivale a cero
+	unidades *big.Int
+}
+
+func Cero() Money {
+	return Money{}
+}
+
+func NewFromInt(valor int64) Money {
+	return Money{unidades: new(big.Int).Mul(big.NewInt(valor), escala)}
+}
+
+func (m Money) entero() *big.Int {
+	if m.unidades == nil {
+		return cero
+	}
+	return m.unidades
+}
+
+// NewFromFloat convierte un float64 redondeando a Decimales posiciones.
+// Solo debe usarse para leer datos heredados almacenados como double.
+func NewFromFloat(valor float64) Money {
+	m, err := Parse(strconv.FormatFloat(valor, 'f', Decimales, 64))
+	if err != nil {
+		return Money{}
+	}
+	return m
+}
+
+// Parse interpreta un número decimal exacto, sin pasar por float64
+func Parse(texto string) (Money, error) {
+	texto = strings.TrimSpace(texto)
+	if !formatoDecimal.MatchString(texto) {
+		return Money{}, fmt.Errorf("importe inválido: %q", texto)
+	}
+	racional, _ := new(big.Rat).SetString(texto)
+	return desdeRacional(racional, texto)
+}
+
+func desdeRacional(racional *big.Rat, texto string) (Money, error) {
+	racional.Mul(racional, new(big.Rat).SetInt(escala))
+	if !racional.IsInt() {
+		return Money{}, fmt.Errorf("importe %q excede %d decimales", texto, Decimales)
+	}
+
+	unidades := new(big.Int).Set(racional.Num())
+	if new(big.Int).Abs(unidades).Cmp(maximo) > 0 {
+		return Money{}, fmt.Errorf("importe %q excede el máximo permitido de %d", texto, MaximoAbsoluto)
+	}
+	return Money{unidades: unidades}, nil
+}
+
+func MustParse(texto string) Money {
+	m, err := Parse(texto)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m Money) Sumar(otro Money) Money {
+	return Money{unidades: new(big.Int).Add(m.entero(), otro.entero())}
+}
+
+func (m Money) Restar(otro Money) Money {
+	return Money{unidades: new(big.Int).Sub(m.entero(), otro.entero())}
+}
+
+func (m Money) MultiplicarEntero(factor int64) Money {
+	return Money{unidades: new(big.Int).Mul(m.entero(), big.NewInt(factor))}
+}
+
+// DividirEntero devuelve m / divisor redondeado a Decimales posiciones con el modo indicado
+func (m Money) DividirEntero(divisor int64, modo ModoRedondeo) Money {
+	if divisor == 0 {
+		return Money{}
+	}
+	return Money{unidades: dividirRedondeando(m.entero(), big.NewInt(divisor), modo)}
+}
+
+// Dividir devuelve m / divisor redondeado a Decimales posiciones con el modo indicado
+func (m Money) Dividir(divisor Money, modo ModoRedondeo) Money {
+	if divisor.EsCero() {
+		return Money{}
+	}
+	numerador := new(big.Int).Mul(m.entero(), escala)
+	return Money{unidades: dividirRedondeando(numerador, divisor.entero(), modo)}
+}
+
+// Multiplicar devuelve m × factor, redondeando el producto a Decimales posiciones con el modo indicado
+func (m Money) Multiplicar(factor Money, modo ModoRedondeo) Money {
+	producto := new(big.Int).Mul(m.entero(), factor.entero())
+	return Money{unidades: dividirRedondeando(producto, escala, modo)}
+}
+
+// MultiplicarRedondeado devuelve m × factor redondeado una sola vez a la cantidad de decimales indicada.
+// Multiplicar y luego Redondear redondea dos veces y puede pasar de céntimo: 1.2349995 se vuelve
+// 1.235000 y luego 1.24 en lugar de 1.23.
+func (m Money) MultiplicarRedondeado(factor Money, decimales int, modo ModoRedondeo) Money {
+	if decimales >= Decimales {
+		return m.Multiplicar(factor, modo)
+	}
+	if decimales < 0 {
+		decimales = 0
+	}
+
+	producto := new(big.Int).Mul(m.entero(), factor.entero())
+	factorDescarte := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(2*Decimales-decimales)), nil)
+	cociente := dividirRedondeando(producto, factorDescarte, modo)
+	factorEscala := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Decimales-decimales)), nil)
+	return Money{unidades: cociente.Mul(cociente, factorEscala)}
+}
+
+// Redondear deja el importe con la cantidad de decimales indicada usando el modo dado
+func (m Money) Redondear(decimales int, modo ModoRedondeo) Money {
+	if decimales >= Decimales {
+		return m
+	}
+	if decimales < 0 {
+		decimales = 0
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Decimales-decimales)), nil)
+	cociente := dividirRedondeando(m.entero(), factor, modo)
+	return Money{unidades: cociente.Mul(cociente, factor)}
+}
+
+func dividirRedondeando(numerador, divisor *big.Int, modo ModoRedondeo) *big.Int {
+	cociente, resto := new(big.Int).QuoRem(numerador, divisor, new(big.Int))
+	if resto.Sign() == 0 {
+		return cociente
+	}
+
+	signo := big.NewInt(int64(numerador.Sign()))
+	dobleResto := new(big.Int).Abs(resto)
+	dobleResto.Lsh(dobleResto, 1)
+	comparacion := dobleResto.Cmp(divisor)
+
+	alejarDeCero := false
+	switch modo {
+	case RedondeoAbajo:
+		alejarDeCero = false
+	case RedondeoArriba:
+		alejarDeCero = true
+	case RedondeoMitadPar:
+		alejarDeCero = comparacion > 0 || (comparacion == 0 && cociente.Bit(0) == 1)
+	default:
+		alejarDeCero = comparacion >= 0
+	}
+
+	if alejarDeCero {
+		cociente.Add(cociente, signo)
+	}
+	return cociente
+}
+
+func (m Money) Comparar(otro Money) int {
+	return m.entero().Cmp(otro.entero())
+}
+
+func (m Money) Igual(otro Money) bool {
+	return m.Comparar(otro) == 0
+}
+
+func (m Money) EsCero() bool {
+	return m.entero().Sign() == 0
+}
+
+// IsZero permite omitir el importe con las etiquetas omitzero (JSON) y omitempty (BSON)
+func (m Money) IsZero() bool {
+	return m.EsCero()
+}
+
+func (m Money) EsPositivo() bool {
+	return m.entero().Sign() > 0
+}
+
+func (m Money) EsNegativo() bool {
+	return m.entero().Sign() < 0
+}
+
+func (m Money) Float64() float64 {
+	valor, _ := new(big.Rat).SetFrac(m.entero(), escala).Float64()
+	return valor
+}
+
+// String devuelve el importe con al menos DecimalesMonto decimales y sin ceros sobrantes
+func (m Money) String() string {
+	texto := m.StringFijo(Decimales)
+	punto := strings.IndexByte(texto, '.')
+	minimo := punto + 1 + DecimalesMonto
+	fin := len(texto)
+	for fin > minimo && texto[fin-1] == '0' {
+		fin--
+	}
+	return texto[:fin]
+}
+
+// StringCantidad devuelve el valor sin ceros decimales sobrantes, como se escriben las cantidades
+func (m Money) StringCantidad() string {
+	texto := strings.TrimRight(m.StringFijo(Decimales), "0")
+	return strings.TrimSuffix(texto, ".")
+}
+
+// StringFijo devuelve el importe con exactamente la cantidad de decimales indicada, truncando el resto
+func (m Money) StringFijo(decimales int) string {
+	signo := ""
+	absoluto := new(big.Int).Abs(m.entero())
+	if m.EsNegativo() {
+		signo = "-"
+	}
+
+	entero, fraccion := new(big.Int).QuoRem(absoluto, escala, new(big.Int))
+	if decimales <= 0 {
+		return signo + entero.String()
+	}
+
+	digitos := fmt.Sprintf("%0*s", Decimales, fraccion.String())
+	if decimales < Decimales {
+		digitos = digitos[:decimales]
+	} else {
+		digitos += strings.Repeat("0", decimales-Decimales)
+	}
+	return signo + entero.String() + "." + digitos
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(datos []byte) error {
+	datos = bytes.TrimSpace(datos)
+	if string(datos) == "null" {
+		return nil
+	}
+
+	texto := string(datos)
+	if len(datos) > 0 && datos[0] == '"' {
+		var err error
+		if texto, err = strconv.Unquote(texto); err != nil {
+			return fmt.Errorf("importe inválido: %s", datos)
+		}
+	}
+
+	valor, err := Parse(texto)
+	if err != nil {
+		return err
+	}
+	*m = valor
+	return nil
+}
+
+// MarshalBSONValue persiste el importe como Decimal128 para conservarlo exacto en MongoDB
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	decimal, err := primitive.ParseDecimal128(m.String())
+	if err != nil {
+		return 0, nil, err
+	}
+	return bson.MarshalValue(decimal)
+}
+
+// UnmarshalBSONValue acepta Decimal128 y los tipos numéricos de documentos antiguos guardados como double
+func (m *Money) UnmarshalBSONValue(tipo bsontype.Type, datos []byte) error {
+	valorCrudo := bson.RawValue{Type: tipo, Value: datos}
+
+	switch tipo {
+	case bsontype.Decimal128:
+		decimal := valorCrudo.Decimal128()
+		if decimal.IsNaN() || decimal.IsInf() != 0 {
+			return fmt.Errorf("importe no numérico: %s", decimal.String())
+		}
+		// Decimal128 puede expresarse con exponente (1.18E+2), así que se convierte desde su coeficiente
+		coeficiente, exponente, err := decimal.BigInt()
+		if err != nil {
+			return fmt.Errorf("importe inválido: %s", decimal.String())
+		}
+		racional, _ := new(big.Rat).SetString(fmt.Sprintf("%se%d", coeficiente, exponente))
+		valor, err := desdeRacional(racional, decimal.String())
+		if err != nil {
+			return err
+		}
+		*m = valor
+	case bsontype.Double:
+		*m = NewFromFloat(valorCrudo.Double())
+	case bsontype.Int32:
+		*m = NewFromInt(int64(valorCrudo.Int32()))
+	case bsontype.Int64:
+		*m = NewFromInt(valorCrudo.Int64())
+	case bsontype.String:
+		valor, err := Parse(valorCrudo.StringValue())
+		if err != nil {
+			return err
+		}
+		*m = valor
+	case bsontype.Null, bsontype.Undefined:
+		*m = Money{}
+	default:
+		return fmt.Errorf("no se puede decodificar %s como importe", tipo)
+	}
+	return nil
+}
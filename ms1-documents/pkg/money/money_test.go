@@ -0,0 +1,276 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParse_Exacto(t *testing.T) {
+	testCases := []struct {
+		entrada  string
+		esperado string
+	}{
+		{"118", "118.00"},
+		{"118.5", "118.50"},
+		{"0.1", "0.10"},
+		{"1.123456", "1.123456"},
+		{"-20.30", "-20.30"},
+		{" 007.50 ", "7.50"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.entrada, func(t *testing.T) {
+			m, err := Parse(tc.entrada)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if m.String() != tc.esperado {
+				t.Errorf("Expected %s, got %s", tc.esperado, m.String())
+			}
+		})
+	}
+}
+
+func TestParse_Invalido(t *testing.T) {
+	for _, entrada := range []string{"", "abc", "1.1234567", "1,50", "1000000000000.01", "-1e13", "1.18e2", "1/2", "0x1p-2", "1_000", "+1", ".5", "1.", "- 1"} {
+		if _, err := Parse(entrada); err == nil {
+			t.Errorf("Expected error for %q", entrada)
+		}
+	}
+}
+
+func TestSumar_SinErrorBinario(t *testing.T) {
+	total := MustParse("0.1").Sumar(MustParse("0.2"))
+	if !total.Igual(MustParse("0.3")) {
+		t.Errorf("Expected 0.30, got %s", total)
+	}
+}
+
+func TestOperaciones_SinDesbordamiento(t *testing.T) {
+	maximo := MustParse("999999999999.999999")
+	testCases := []struct {
+		name     string
+		valor    Money
+		esperado string
+	}{
+		{"Suma", NewFromInt(9_000_000_000_000).Sumar(NewFromInt(9_000_000_000_000)), "18000000000000"},
+		{"Resta", maximo.Restar(maximo).Restar(maximo), "-999999999999.999999"},
+		{"Multiplicación por entero", maximo.MultiplicarEntero(1_000_000), "999999999999999999"},
+		{"Multiplicación", maximo.Multiplicar(maximo, RedondeoMitadArriba), "999999999999999998000000"},
+		{"División", maximo.Dividir(MustParse("0.000001"), RedondeoMitadArriba), "999999999999999999"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.valor.StringCantidad() != tc.esperado {
+				t.Errorf("Expected %s, got %s", tc.esperado, tc.valor.StringCantidad())
+			}
+		})
+	}
+}
+
+func TestRedondear_Modos(t *testing.T) {
+	testCases := []struct {
+		valor    string
+		modo     ModoRedondeo
+		esperado string
+	}{
+		{"2.345", RedondeoMitadArriba, "2.35"},
+		{"2.345", RedondeoMitadPar, "2.34"},
+		{"2.355", RedondeoMitadPar, "2.36"},
+		{"2.349", RedondeoAbajo, "2.34"},
+		{"2.341", RedondeoArriba, "2.35"},
+		{"-2.345", RedondeoMitadArriba, "-2.35"},
+		{"-2.341", RedondeoAbajo, "-2.34"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.modo)+" "+tc.valor, func(t *testing.T) {
+			resultado := MustParse(tc.valor).Redondear(DecimalesMonto, tc.modo)
+			if resultado.String() != tc.esperado {
+				t.Errorf("Expected %s, got %s", tc.esperado, resultado)
+			}
+		})
+	}
+}
+
+func TestMultiplicar(t *testing.T) {
+	igv := MustParse("100.55").Multiplicar(MustParse("0.18"), RedondeoMitadArriba)
+	if !igv.Igual(MustParse("18.099")) {
+		t.Errorf("Expected 18.099, got %s", igv)
+	}
+
+	if redondeado := igv.Redondear(DecimalesMonto, RedondeoMitadArriba); redondeado.String() != "18.10" {
+		t.Errorf("Expected 18.10, got %s", redondeado)
+	}
+}
+
+func TestMultiplicarRedondeado(t *testing.T) {
+	testCases := []struct {
+		name      string
+		valor     string
+		factor    string
+		decimales int
+		modo      ModoRedondeo
+		esperado  string
+	}{
+		{"Debajo del medio céntimo", "2.469999", "0.50", DecimalesMonto, RedondeoMitadArriba, "1.23"},
+		{"Medio céntimo exacto", "0.25", "0.18", DecimalesMonto, RedondeoMitadArriba, "0.05"},
+		{"Medio céntimo exacto a par", "0.25", "0.18", DecimalesMonto, RedondeoMitadPar, "0.04"},
+		{"Negativo", "-2.469999", "0.50", DecimalesMonto, RedondeoMitadArriba, "-1.23"},
+		{"Sin decimales", "1234.50", "0.12", 0, RedondeoMitadArriba, "148"},
+		{"Precisión interna", "100.55", "0.18", Decimales, RedondeoMitadArriba, "18.099"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resultado := MustParse(tc.valor).MultiplicarRedondeado(MustParse(tc.factor), tc.decimales, tc.modo)
+			if !resultado.Igual(MustParse(tc.esperado)) {
+				t.Errorf("Expected %s, got %s", tc.esperado, resultado)
+			}
+		})
+	}
+
+	// Redondear primero a la precisión interna lleva 1.2349995 a 1.235000 y luego a 1.24
+	dobleRedondeo := MustParse("2.469999").Multiplicar(MustParse("0.50"), RedondeoMitadArriba).Redondear(DecimalesMonto, RedondeoMitadArriba)
+	if !dobleRedondeo.Igual(MustParse("1.24")) {
+		t.Errorf("Expected rounding twice to give 1.24, got %s", dobleRedondeo)
+	}
+}
+
+func TestDividirEntero(t *testing.T) {
+	if tercio := MustParse("100").DividirEntero(3, RedondeoMitadArriba); tercio.String() != "33.333333" {
+		t.Errorf("Expected 33.333333, got %s", tercio)
+	}
+
+	if dosTercios := MustParse("2").DividirEntero(3, RedondeoAbajo); dosTercios.String() != "0.666666" {
+		t.Errorf("Expected 0.666666, got %s", dosTercios)
+	}
+}
+
+func TestDividir(t *testing.T) {
+	if precio := MustParse("10").Dividir(MustParse("2.5"), RedondeoMitadArriba); precio.String() != "4.00" {
+		t.Errorf("Expected 4.00, got %s", precio)
+	}
+
+	if tercio := MustParse("1").Dividir(MustParse("0.75"), RedondeoMitadArriba); tercio.String() != "1.333333" {
+		t.Errorf("Expected 1.333333, got %s", tercio)
+	}
+
+	if cero := MustParse("1").Dividir(Cero(), RedondeoMitadArriba); !cero.EsCero() {
+		t.Errorf("Expected 0 when dividing by zero, got %s", cero)
+	}
+}
+
+func TestStringCantidad(t *testing.T) {
+	testCases := map[string]string{"2": "2", "2.50": "2.5", "0.75": "0.75", "-1.000100": "-1.0001", "0": "0"}
+	for entrada, esperado := range testCases {
+		if texto := MustParse(entrada).StringCantidad(); texto != esperado {
+			t.Errorf("Expected %s for %s, got %s", esperado, entrada, texto)
+		}
+	}
+}
+
+func TestParseModoRedondeo(t *testing.T) {
+	modo, err := ParseModoRedondeo("half_even")
+	if err != nil || modo != RedondeoMitadPar {
+		t.Errorf("Expected HALF_EVEN, got %s (%v)", modo, err)
+	}
+
+	if _, err := ParseModoRedondeo("CEILING"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func TestJSON_IdaYVuelta(t *testing.T) {
+	var contenedor struct {
+		Monto Money `json:"monto"`
+		Texto Money `json:"texto"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"monto": 1180.10, "texto": "0.30"}`), &contenedor); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	salida, err := json.Marshal(contenedor)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if string(salida) != `{"monto":1180.10,"texto":0.30}` {
+		t.Errorf("Unexpected JSON: %s", salida)
+	}
+}
+
+func TestBSON_Decimal128(t *testing.T) {
+	original := struct {
+		Monto Money `bson:"monto"`
+	}{Monto: MustParse("1180.35")}
+
+	datos, err := bson.Marshal(original)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if tipo := bson.Raw(datos).Lookup("monto").Type; tipo != bson.TypeDecimal128 {
+		t.Errorf("Expected Decimal128, got %s", tipo)
+	}
+
+	var leido struct {
+		Monto Money `bson:"monto"`
+	}
+	if err := bson.Unmarshal(datos, &leido); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !leido.Monto.Igual(original.Monto) {
+		t.Errorf("Expected %s, got %s", original.Monto, leido.Monto)
+	}
+}
+
+func TestBSON_Decimal128ConExponente(t *testing.T) {
+	for texto, esperado := range map[string]string{"1.18E+2": "118.00", "5E-7": "", "12345E-4": "1.2345"} {
+		decimal, _ := primitive.ParseDecimal128(texto)
+		datos, _ := bson.Marshal(bson.M{"monto": decimal})
+
+		var leido struct {
+			Monto Money `bson:"monto"`
+		}
+		err := bson.Unmarshal(datos, &leido)
+		if esperado == "" {
+			if err == nil {
+				t.Errorf("Expected error for %s, got %s", texto, leido.Monto)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got: %v", texto, err)
+		}
+		if !leido.Monto.Igual(MustParse(esperado)) {
+			t.Errorf("Expected %s for %s, got %s", esperado, texto, leido.Monto.StringCantidad())
+		}
+	}
+}
+
+func TestBSON_DocumentoAntiguoConDouble(t *testing.T) {
+	datos, _ := bson.Marshal(bson.M{"monto": 118.1, "cantidad": int32(5)})
+
+	var leido struct {
+		Monto    Money `bson:"monto"`
+		Cantidad Money `bson:"cantidad"`
+	}
+	if err := bson.Unmarshal(datos, &leido); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !leido.Monto.Igual(MustParse("118.10")) {
+		t.Errorf("Expected 118.10, got %s", leido.Monto)
+	}
+
+	if !leido.Cantidad.Igual(NewFromInt(5)) {
+		t.Errorf("Expected 5.00, got %s", leido.Cantidad)
+	}
+}
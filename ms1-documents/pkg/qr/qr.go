@@ -0,0 +1,86 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// NivelCorreccion es el nivel de corrección de errores del símbolo
+type NivelCorreccion int
+
+const (
+	NivelL NivelCorreccion = iota
+	NivelM
+	NivelQ
+	NivelH
+)
+
+// ErrDatosMuyLargos se devuelve cuando los datos no caben en la versión 40 del nivel elegido
+var ErrDatosMuyLargos = errors.New("los datos exceden la capacidad de un código QR")
+
+var nivelesBiblioteca = [...]qrcode.RecoveryLevel{
+	NivelL: qrcode.Low,
+	NivelM: qrcode.Medium,
+	NivelQ: qrcode.High,
+	NivelH: qrcode.Highest,
+}
+
+// Codigo es un símbolo QR ya codificado; los módulos oscuros valen true
+type Codigo struct {
+	version int
+	tamano  int
+	modulos [][]bool
+}
+
+// Codificar genera el símbolo QR más pequeño que contiene los datos
+func Codificar(datos []byte, nivel NivelCorreccion) (*Codigo, error) {
+	if nivel < NivelL || nivel > NivelH {
+		return nil, fmt.Errorf("nivel de corrección inválido: %d", nivel)
+	}
+
+	simbolo, err := qrcode.New(string(datos), nivelesBiblioteca[nivel])
+	if err != nil {
+		return nil, ErrDatosMuyLargos
+	}
+	simbolo.DisableBorder = true
+
+	modulos := simbolo.Bitmap()
+	return &Codigo{version: simbolo.VersionNumber, tamano: len(modulos), modulos: modulos}, nil
+}
+
+// Tamano es el número de módulos por lado, sin contar la zona de silencio
+func (c *Codigo) Tamano() int {
+	return c.tamano
+}
+
+// Version es la versión (1 a 40) elegida para los datos
+func (c *Codigo) Version() int {
+	return c.version
+}
+
+// Modulo indica si el módulo de la columna x y fila y es oscuro; fuera del símbolo siempre es claro
+func (c *Codigo) Modulo(x, y int) bool {
+	return x >= 0 && x < c.tamano && y >= 0 && y < c.tamano && c.modulos[y][x]
+}
+
+// SVG dibuja el símbolo con una zona de silencio de 4 módulos; cada módulo mide una unidad del viewBox
+func (c *Codigo) SVG(tamanoPx int) string {
+	const margen = 4
+	lado := c.tamano + 2*margen
+
+	var ruta strings.Builder
+	for y := 0; y < c.tamano; y++ {
+		for x := 0; x < c.tamano; x++ {
+			if c.modulos[y][x] {
+				fmt.Fprintf(&ruta, "M%d,%dh1v1h-1z", x+margen, y+margen)
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+		`<rect width="100%%" height="100%%" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		tamanoPx, tamanoPx, lado, lado, ruta.String())
+}
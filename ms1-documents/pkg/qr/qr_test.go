@@ -0,0 +1,53 @@
+package qr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCodificar_EligeVersionMinima(t *testing.T) {
+	testCases := []struct {
+		longitud int
+		nivel    NivelCorreccion
+		version  int
+	}{
+		{11, NivelQ, 1},
+		{12, NivelQ, 2},
+		{17, NivelL, 1},
+		{152, NivelM, 8},
+		{153, NivelM, 9},
+		{2953, NivelL, 40},
+	}
+
+	for _, tc := range testCases {
+		codigo, err := Codificar(bytes.Repeat([]byte("a"), tc.longitud), tc.nivel)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if codigo.Version() != tc.version {
+			t.Errorf("Expected version %d for %d bytes, got %d", tc.version, tc.longitud, codigo.Version())
+		}
+		if codigo.Tamano() != tc.version*4+17 {
+			t.Errorf("Expected size %d, got %d", tc.version*4+17, codigo.Tamano())
+		}
+	}
+}
+
+func TestCodificar_DatosMuyLargos(t *testing.T) {
+	if _, err := Codificar(make([]byte, 2954), NivelL); err != ErrDatosMuyLargos {
+		t.Errorf("Expected ErrDatosMuyLargos, got %v", err)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	codigo, err := Codificar([]byte("HOLA"), NivelQ)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	svg := codigo.SVG(120)
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `viewBox="0 0 29 29"`) {
+		t.Errorf("Expected a 29x29 viewBox, got %s", svg[:min(len(svg), 200)])
+	}
+}
@@ -8,6 +8,7 @@ public final class ValidationConstants {
 
     public static final double IGV_RATE = 0.18;
     public static final double TOLERANCE = 0.01;
+    public static final long IGV_TOLERANCE_CENTS = 1;
     public static final String ESTADO_VALIDO = "Válido";
     public static final String ESTADO_INVALIDO = "Inválido";
 }
@@ -24,7 +24,7 @@ public class DocumentConsumer {
             message.getDocumentId(), message.getUuid());
 
         try {
-            documentProcessorService.processDocument(message.getDocumentId());
+            documentProcessorService.processDocument(message.getDocumentId(), message.getUuid());
             logger.info("Mensaje procesado exitosamente");
         } catch (Exception e) {
             logger.error("Error al procesar el mensaje", e);
@@ -0,0 +1,18 @@
+package com.efact.validator.model;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import lombok.Data;
+
+/**
+ * Cargo o descuento de item o global con motivo del catálogo 53. MS1 deriva esCargo y afectaBase del código.
+ */
+@Data
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class CargoDescuento {
+    private String codigo;
+    private Double factor;
+    private Double montoBase;
+    private Double monto;
+    private boolean esCargo;
+    private boolean afectaBase;
+}
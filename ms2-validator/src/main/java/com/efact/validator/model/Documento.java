@@ -1,6 +1,7 @@
 package com.efact.validator.model;
 
 import com.fasterxml.jackson.annotation.JsonIgnore;
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
 import lombok.Data;
 import org.springframework.data.annotation.Id;
 import org.springframework.data.mongodb.core.mapping.Document;
@@ -9,6 +10,7 @@ import org.springframework.data.mongodb.core.mapping.Field;
 import java.util.List;
 
 @Data
+@JsonIgnoreProperties(ignoreUnknown = true)
 @Document(collection = "documents")
 public class Documento {
     @Id
@@ -33,6 +35,42 @@ public class Documento {
     @Field("montoTotalSinImpuestos")
     private Double montoTotalSinImpuestos;
 
+    // Los subtotales solo se usan para validar; quedan fuera del JSON firmado para que las firmas ya emitidas sigan verificándose
+    @JsonIgnore
+    @Field("totalGravado")
+    private Double totalGravado;
+
+    @JsonIgnore
+    @Field("totalExonerado")
+    private Double totalExonerado;
+
+    @JsonIgnore
+    @Field("totalInafecto")
+    private Double totalInafecto;
+
+    @JsonIgnore
+    @Field("totalGratuito")
+    private Double totalGratuito;
+
+    @JsonIgnore
+    @Field("iscTotal")
+    private Double iscTotal;
+
+    @JsonIgnore
+    @Field("icbperTotal")
+    private Double icbperTotal;
+
+    @JsonIgnore
+    @Field("totalDescuentos")
+    private Double totalDescuentos;
+
+    @JsonIgnore
+    @Field("totalCargos")
+    private Double totalCargos;
+
+    @Field("tasaIgv")
+    private Double tasaIgv;
+
     @Field("igvTotal")
     private Double igvTotal;
 
@@ -42,6 +80,10 @@ public class Documento {
     @Field("items")
     private List<Item> items;
 
+    @JsonIgnore
+    @Field("cargosDescuentos")
+    private List<CargoDescuento> cargosDescuentos;
+
     @Field("validacion")
     private Validacion validacion;
 }
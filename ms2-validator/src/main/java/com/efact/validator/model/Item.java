@@ -1,12 +1,31 @@
 package com.efact.validator.model;
 
+import com.efact.validator.util.CantidadSerializer;
+import com.fasterxml.jackson.annotation.JsonIgnore;
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.annotation.JsonSerialize;
 import lombok.Data;
 
+import java.math.BigDecimal;
+import java.util.List;
+
 @Data
+@JsonIgnoreProperties(ignoreUnknown = true)
 public class Item {
     private String descripcion;
     private Double precioUnitario;
-    private Integer cantidad;
+    @JsonSerialize(using = CantidadSerializer.class)
+    private BigDecimal cantidad;
     private Double precioTotal;
     private Double igvTotal;
+
+    // Igual que los subtotales del documento, estos campos no forman parte del JSON firmado
+    @JsonIgnore
+    private String tipoAfectacionIgv;
+    @JsonIgnore
+    private Double iscTotal;
+    @JsonIgnore
+    private Double icbperTotal;
+    @JsonIgnore
+    private List<CargoDescuento> cargosDescuentos;
 }
@@ -6,5 +6,6 @@ import lombok.Data;
 public class Validacion {
     private String fechaValidacion;
     private String firma;
+    private String digestValue;
     private String estado;
 }
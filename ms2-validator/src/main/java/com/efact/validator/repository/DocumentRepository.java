@@ -8,5 +8,5 @@ import java.util.Optional;
 
 @Repository
 public interface DocumentRepository extends MongoRepository<Documento, String> {
-    Optional<Documento> findByIdDocumento(String idDocumento);
+    Optional<Documento> findByUuid(String uuid);
 }
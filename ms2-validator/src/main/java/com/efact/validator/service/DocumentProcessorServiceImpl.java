@@ -7,6 +7,10 @@ import com.efact.validator.model.Validacion;
 import com.efact.validator.repository.DocumentRepository;
 import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
+import org.springframework.data.mongodb.core.MongoTemplate;
+import org.springframework.data.mongodb.core.query.Criteria;
+import org.springframework.data.mongodb.core.query.Query;
+import org.springframework.data.mongodb.core.query.Update;
 import org.springframework.stereotype.Service;
 
 import java.time.Instant;
@@ -20,21 +24,25 @@ public class DocumentProcessorServiceImpl implements IDocumentProcessorService {
     private final DocumentRepository documentRepository;
     private final IValidationService validationService;
     private final ISignatureService signatureService;
+    private final MongoTemplate mongoTemplate;
 
     public DocumentProcessorServiceImpl(
             DocumentRepository documentRepository,
             IValidationService validationService,
-            ISignatureService signatureService) {
+            ISignatureService signatureService,
+            MongoTemplate mongoTemplate) {
         this.documentRepository = documentRepository;
         this.validationService = validationService;
         this.signatureService = signatureService;
+        this.mongoTemplate = mongoTemplate;
     }
 
     @Override
-    public void processDocument(String documentId) {
+    public void processDocument(String documentId, String uuid) {
         logger.info("Procesando documento: {}", documentId);
 
-        Optional<Documento> optionalDoc = documentRepository.findByIdDocumento(documentId);
+        // idDocumento solo es único dentro de cada emisor; el uuid identifica el documento en toda la colección
+        Optional<Documento> optionalDoc = documentRepository.findByUuid(uuid);
 
         if (optionalDoc.isEmpty()) {
             logger.error("{}: {}", MessageConstants.DOCUMENTO_NO_ENCONTRADO, documentId);
@@ -51,6 +59,7 @@ public class DocumentProcessorServiceImpl implements IDocumentProcessorService {
         if (isValid) {
             String signature = signatureService.signDocument(document);
             validacion.setFirma(signature);
+            validacion.setDigestValue(signatureService.digestDocument(document));
             validacion.setEstado(ValidationConstants.ESTADO_VALIDO);
             logger.info("Documento {} validado y firmado exitosamente", documentId);
         } else {
@@ -58,8 +67,11 @@ public class DocumentProcessorServiceImpl implements IDocumentProcessorService {
             logger.warn("Documento {} marcado como inválido", documentId);
         }
 
-        document.setValidacion(validacion);
-        documentRepository.save(document);
+        // Solo se actualiza la validación para no sobrescribir campos que MS1 gestiona y este modelo no conoce
+        mongoTemplate.updateFirst(
+            Query.query(Criteria.where("uuid").is(uuid)),
+            Update.update("validacion", validacion),
+            Documento.class);
 
         logger.info("Documento {} procesado y actualizado en la base de datos", documentId);
     }
@@ -2,5 +2,5 @@ package com.efact.validator.service;
 
 public interface IDocumentProcessorService {
 
-    void processDocument(String documentId);
+    void processDocument(String documentId, String uuid);
 }
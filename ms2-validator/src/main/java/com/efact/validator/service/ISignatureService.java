@@ -8,6 +8,8 @@ public interface ISignatureService {
 
     String signDocument(Documento document);
 
+    String digestDocument(Documento document);
+
     boolean verifySignature(Documento document, String signature);
 
     PublicKey getPublicKey();
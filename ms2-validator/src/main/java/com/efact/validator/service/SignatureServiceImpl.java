@@ -63,14 +63,7 @@ public class SignatureServiceImpl implements ISignatureService {
     @Override
     public String signDocument(Documento document) {
         try {
-            Documento docCopy = DocumentUtils.cloneDocument(document, objectMapper);
-            docCopy.setValidacion(null);
-
-            String documentJson = objectMapper.writeValueAsString(docCopy);
-            logger.info("JSON generado al firmar documento {}: {}", document.getIdDocumento(), documentJson);
-
-            MessageDigest digest = MessageDigest.getInstance("SHA-256");
-            byte[] hash = digest.digest(documentJson.getBytes());
+            byte[] hash = hashDocument(document);
 
             Signature signature = Signature.getInstance("SHA256withRSA");
             signature.initSign(privateKey);
@@ -88,16 +81,19 @@ public class SignatureServiceImpl implements ISignatureService {
     }
 
     @Override
-    public boolean verifySignature(Documento document, String signature) {
+    public String digestDocument(Documento document) {
         try {
-            Documento docCopy = DocumentUtils.cloneDocument(document, objectMapper);
-            docCopy.setValidacion(null);
-
-            String documentJson = objectMapper.writeValueAsString(docCopy);
-            logger.info("JSON generado para verificar: {}", documentJson);
+            return Base64.getEncoder().encodeToString(hashDocument(document));
+        } catch (Exception e) {
+            logger.error("Error al calcular el resumen del documento", e);
+            throw new SignatureException("Fallo al calcular el resumen del documento", e);
+        }
+    }
 
-            MessageDigest digest = MessageDigest.getInstance("SHA-256");
-            byte[] hash = digest.digest(documentJson.getBytes());
+    @Override
+    public boolean verifySignature(Documento document, String signature) {
+        try {
+            byte[] hash = hashDocument(document);
 
             byte[] signatureBytes = Base64.getDecoder().decode(signature);
 
@@ -112,6 +108,18 @@ public class SignatureServiceImpl implements ISignatureService {
         }
     }
 
+    // SHA-256 del JSON del documento sin su validación: lo que se firma y el DigestValue del QR
+    private byte[] hashDocument(Documento document) throws Exception {
+        Documento docCopy = DocumentUtils.cloneDocument(document, objectMapper);
+        docCopy.setValidacion(null);
+
+        String documentJson = objectMapper.writeValueAsString(docCopy);
+        logger.info("JSON generado para el documento {}: {}", document.getIdDocumento(), documentJson);
+
+        MessageDigest digest = MessageDigest.getInstance("SHA-256");
+        return digest.digest(documentJson.getBytes());
+    }
+
     @Override
     public PublicKey getPublicKey() {
         return publicKey;
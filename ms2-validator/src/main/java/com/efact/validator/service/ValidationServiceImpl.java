@@ -1,15 +1,21 @@
 package com.efact.validator.service;
 
 import com.efact.validator.constants.MessageConstants;
+import com.efact.validator.model.CargoDescuento;
 import com.efact.validator.model.Documento;
 import com.efact.validator.model.Item;
+import com.efact.validator.util.AfectacionUtils;
 import com.efact.validator.util.MathUtils;
 import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 import org.springframework.stereotype.Service;
 
+import java.util.List;
+
 import static com.efact.validator.constants.ValidationConstants.IGV_RATE;
+import static com.efact.validator.constants.ValidationConstants.IGV_TOLERANCE_CENTS;
 import static com.efact.validator.constants.ValidationConstants.TOLERANCE;
+import static com.efact.validator.util.MathUtils.valueOrZero;
 
 @Service
 public class ValidationServiceImpl implements IValidationService {
@@ -43,8 +49,19 @@ public class ValidationServiceImpl implements IValidationService {
         for (int i = 0; i < document.getItems().size(); i++) {
             Item item = document.getItems().get(i);
 
-            double expectedPrecioTotal = item.getPrecioUnitario() * item.getCantidad();
-            double expectedIgvTotal = expectedPrecioTotal * IGV_RATE;
+            if (!validateMontosPorFactor(item.getCargosDescuentos(), "Ítem " + i)) {
+                return false;
+            }
+
+            // Los cargos y descuentos del item que afectan la base modifican el precio total
+            double expectedPrecioTotal = item.getPrecioUnitario() * item.getCantidad().doubleValue()
+                + ajusteBase(item.getCargosDescuentos());
+
+            // Solo los items gravados llevan IGV, calculado sobre el precio más el ISC
+            double expectedIgvTotal = 0;
+            if (AfectacionUtils.GRAVADO.equals(AfectacionUtils.categoria(item.getTipoAfectacionIgv()))) {
+                expectedIgvTotal = (item.getPrecioTotal() + valueOrZero(item.getIscTotal())) * tasaIgv(document);
+            }
 
             if (!MathUtils.areEqual(item.getPrecioTotal(), expectedPrecioTotal, TOLERANCE)) {
                 logger.error("Ítem {}: precioTotal no coincide. Esperado: {}, Obtenido: {}",
@@ -62,31 +79,155 @@ public class ValidationServiceImpl implements IValidationService {
     }
 
     private boolean validateTotals(Documento document) {
-        double expectedMontoSinImpuestos = document.getItems().stream()
-            .mapToDouble(Item::getPrecioTotal)
-            .sum();
-
-        double expectedIgvTotal = expectedMontoSinImpuestos * IGV_RATE;
-        double expectedMontoTotal = expectedMontoSinImpuestos + expectedIgvTotal;
-
-        if (!MathUtils.areEqual(document.getMontoTotalSinImpuestos(), expectedMontoSinImpuestos, TOLERANCE)) {
-            logger.error("montoTotalSinImpuestos no coincide. Esperado: {}, Obtenido: {}",
-                expectedMontoSinImpuestos, document.getMontoTotalSinImpuestos());
+        if (!validateMontosPorFactor(document.getCargosDescuentos(), "Documento")) {
             return false;
         }
 
-        if (!MathUtils.areEqual(document.getIgvTotal(), expectedIgvTotal, TOLERANCE)) {
-            logger.error("igvTotal no coincide. Esperado: {}, Obtenido: {}",
-                expectedIgvTotal, document.getIgvTotal());
+        Subtotales subtotales = new Subtotales(document);
+
+        double expectedMontoSinImpuestos = subtotales.gravado + subtotales.exonerado + subtotales.inafecto;
+        double expectedIgvTotal = (subtotales.gravado + subtotales.iscGravado) * tasaIgv(document);
+
+        if (!sameSubtotal("totalGravado", document.getTotalGravado(), subtotales.gravado)
+            || !sameSubtotal("totalExonerado", document.getTotalExonerado(), subtotales.exonerado)
+            || !sameSubtotal("totalInafecto", document.getTotalInafecto(), subtotales.inafecto)
+            || !sameSubtotal("totalGratuito", document.getTotalGratuito(), subtotales.gratuito)
+            || !sameTotal("montoTotalSinImpuestos", document.getMontoTotalSinImpuestos(), expectedMontoSinImpuestos)
+            || !sameTotal("iscTotal", valueOrZero(document.getIscTotal()), subtotales.iscGravado)
+            || !sameTotal("icbperTotal", valueOrZero(document.getIcbperTotal()), subtotales.icbper)
+            || !sameTotal("totalDescuentos", valueOrZero(document.getTotalDescuentos()), subtotales.descuentos)
+            || !sameTotal("totalCargos", valueOrZero(document.getTotalCargos()), subtotales.cargos)) {
             return false;
         }
 
-        if (!MathUtils.areEqual(document.getMontoTotal(), expectedMontoTotal, TOLERANCE)) {
-            logger.error("montoTotal no coincide. Esperado: {}, Obtenido: {}",
-                expectedMontoTotal, document.getMontoTotal());
+        // Igual que MS1: se acepta el IGV de la base total con la tolerancia de SUNAT o, si ningún cargo o
+        // descuento global cambia la base, la suma del IGV de cada item
+        boolean sinAjusteGlobal = ajusteBase(document.getCargosDescuentos()) == 0;
+        boolean igvAceptado = MathUtils.centsDifference(document.getIgvTotal(), expectedIgvTotal) <= IGV_TOLERANCE_CENTS
+            || sinAjusteGlobal && MathUtils.areEqual(document.getIgvTotal(), subtotales.igvItems, TOLERANCE);
+        if (!igvAceptado) {
+            logger.error("igvTotal no coincide. Esperado: {} ± {} o {}, Obtenido: {}",
+                expectedIgvTotal, TOLERANCE, subtotales.igvItems, document.getIgvTotal());
             return false;
         }
 
+        double expectedMontoTotal = document.getMontoTotalSinImpuestos() + valueOrZero(document.getIscTotal())
+            + document.getIgvTotal() + valueOrZero(document.getIcbperTotal())
+            - subtotales.descuentosNoBase + subtotales.cargosNoBase;
+
+        return sameTotal("montoTotal", document.getMontoTotal(), expectedMontoTotal);
+    }
+
+    // Los cargos o descuentos porcentuales deben cumplir monto = montoBase × factor
+    private boolean validateMontosPorFactor(List<CargoDescuento> cargosDescuentos, String origen) {
+        if (cargosDescuentos == null) {
+            return true;
+        }
+        for (int i = 0; i < cargosDescuentos.size(); i++) {
+            CargoDescuento cargoDescuento = cargosDescuentos.get(i);
+            if (valueOrZero(cargoDescuento.getFactor()) == 0) {
+                continue;
+            }
+            double expectedMonto = valueOrZero(cargoDescuento.getMontoBase()) * cargoDescuento.getFactor();
+            if (!MathUtils.areEqual(valueOrZero(cargoDescuento.getMonto()), expectedMonto, TOLERANCE)) {
+                logger.error("{}: monto del cargo o descuento {} no coincide. Esperado: {}, Obtenido: {}",
+                    origen, i, expectedMonto, cargoDescuento.getMonto());
+                return false;
+            }
+        }
         return true;
     }
+
+    // ajusteBase devuelve cargos menos descuentos que afectan la base imponible
+    private static double ajusteBase(List<CargoDescuento> cargosDescuentos) {
+        if (cargosDescuentos == null) {
+            return 0;
+        }
+        double ajuste = 0;
+        for (CargoDescuento cargoDescuento : cargosDescuentos) {
+            if (!cargoDescuento.isAfectaBase()) {
+                continue;
+            }
+            ajuste += cargoDescuento.isEsCargo() ? valueOrZero(cargoDescuento.getMonto()) : -valueOrZero(cargoDescuento.getMonto());
+        }
+        return ajuste;
+    }
+
+    // Los documentos anteriores a las afectaciones no tienen subtotales
+    private boolean sameSubtotal(String campo, Double obtenido, double esperado) {
+        return obtenido == null || sameTotal(campo, obtenido, esperado);
+    }
+
+    private boolean sameTotal(String campo, double obtenido, double esperado) {
+        if (MathUtils.areEqual(obtenido, esperado, TOLERANCE)) {
+            return true;
+        }
+        logger.error("{} no coincide. Esperado: {}, Obtenido: {}", campo, esperado, obtenido);
+        return false;
+    }
+
+    // Subtotales es la suma de los items por categoría de afectación; los gratuitos no forman parte de la base.
+    // Los cargos y descuentos globales que afectan la base modifican el total gravado.
+    private static final class Subtotales {
+        private double gravado;
+        private double exonerado;
+        private double inafecto;
+        private double gratuito;
+        private double iscGravado;
+        private double igvItems;
+        private double icbper;
+        private double descuentos;
+        private double cargos;
+        private double descuentosNoBase;
+        private double cargosNoBase;
+
+        private Subtotales(Documento document) {
+            for (Item item : document.getItems()) {
+                agregarCargosDescuentos(item.getCargosDescuentos());
+                icbper += valueOrZero(item.getIcbperTotal());
+
+                if (AfectacionUtils.esGratuito(item.getTipoAfectacionIgv())) {
+                    gratuito += item.getPrecioTotal();
+                    continue;
+                }
+
+                switch (AfectacionUtils.categoria(item.getTipoAfectacionIgv())) {
+                    case AfectacionUtils.EXONERADO:
+                        exonerado += item.getPrecioTotal();
+                        break;
+                    case AfectacionUtils.INAFECTO:
+                        inafecto += item.getPrecioTotal();
+                        break;
+                    default:
+                        gravado += item.getPrecioTotal();
+                        iscGravado += valueOrZero(item.getIscTotal());
+                        igvItems += item.getIgvTotal();
+                }
+            }
+
+            gravado += ajusteBase(document.getCargosDescuentos());
+            agregarCargosDescuentos(document.getCargosDescuentos());
+        }
+
+        private void agregarCargosDescuentos(List<CargoDescuento> cargosDescuentos) {
+            if (cargosDescuentos == null) {
+                return;
+            }
+            for (CargoDescuento cargoDescuento : cargosDescuentos) {
+                double monto = valueOrZero(cargoDescuento.getMonto());
+                if (cargoDescuento.isEsCargo()) {
+                    cargos += monto;
+                    cargosNoBase += cargoDescuento.isAfectaBase() ? 0 : monto;
+                } else {
+                    descuentos += monto;
+                    descuentosNoBase += cargoDescuento.isAfectaBase() ? 0 : monto;
+                }
+            }
+        }
+    }
+
+    // Usa la tasa guardada por MS1 al emitir; los documentos anteriores no la tienen
+    private double tasaIgv(Documento document) {
+        return document.getTasaIgv() != null ? document.getTasaIgv() : IGV_RATE;
+    }
 }
@@ -0,0 +1,40 @@
+package com.efact.validator.util;
+
+import java.util.Set;
+
+/**
+ * Clasifica los tipos de afectación al IGV del catálogo 07 de SUNAT igual que MS1.
+ * Los items sin tipo de afectación son de documentos anteriores y se tratan como gravado oneroso.
+ */
+public final class AfectacionUtils {
+
+    public static final String GRAVADO = "GRAVADO";
+    public static final String EXONERADO = "EXONERADO";
+    public static final String INAFECTO = "INAFECTO";
+
+    private static final String GRAVADO_ONEROSO = "10";
+    private static final Set<String> ONEROSOS = Set.of(GRAVADO_ONEROSO, "20", "30");
+
+    private AfectacionUtils() {
+        throw new UnsupportedOperationException();
+    }
+
+    public static String categoria(String tipoAfectacionIgv) {
+        switch (codigo(tipoAfectacionIgv).charAt(0)) {
+            case '2':
+                return EXONERADO;
+            case '3':
+                return INAFECTO;
+            default:
+                return GRAVADO;
+        }
+    }
+
+    public static boolean esGratuito(String tipoAfectacionIgv) {
+        return !ONEROSOS.contains(codigo(tipoAfectacionIgv));
+    }
+
+    private static String codigo(String tipoAfectacionIgv) {
+        return tipoAfectacionIgv == null || tipoAfectacionIgv.isEmpty() ? GRAVADO_ONEROSO : tipoAfectacionIgv;
+    }
+}
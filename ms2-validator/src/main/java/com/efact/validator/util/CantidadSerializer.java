@@ -0,0 +1,20 @@
+package com.efact.validator.util;
+
+import com.fasterxml.jackson.core.JsonGenerator;
+import com.fasterxml.jackson.databind.JsonSerializer;
+import com.fasterxml.jackson.databind.SerializerProvider;
+
+import java.io.IOException;
+import java.math.BigDecimal;
+
+/**
+ * Escribe la cantidad sin ceros sobrantes, de modo que 2 y 2.00 se firmen igual que las
+ * cantidades enteras de los documentos anteriores a las cantidades decimales.
+ */
+public class CantidadSerializer extends JsonSerializer<BigDecimal> {
+
+    @Override
+    public void serialize(BigDecimal value, JsonGenerator gen, SerializerProvider serializers) throws IOException {
+        gen.writeNumber(value.stripTrailingZeros().toPlainString());
+    }
+}
@@ -9,4 +9,13 @@ public final class MathUtils {
     public static boolean areEqual(double a, double b, double tolerance) {
         return Math.abs(a - b) < tolerance;
     }
+
+    // Compara importes redondeados a céntimos, para aplicar tolerancias expresadas en céntimos sin errores de coma flotante
+    public static long centsDifference(double a, double b) {
+        return Math.abs(Math.round(a * 100) - Math.round(b * 100));
+    }
+
+    public static double valueOrZero(Double value) {
+        return value != null ? value : 0;
+    }
 }